@@ -12,6 +12,7 @@
 	"github.com/ehazlett/simplelog"
 	_ "github.com/rancher/norman/controller"
 	"github.com/rancher/norman/pkg/kwrapper/k8s"
+	"github.com/rancher/rancher/pkg/auth/audit"
 	"github.com/rancher/rancher/pkg/data/management"
 	"github.com/rancher/rancher/pkg/logserver"
 	"github.com/rancher/rancher/pkg/rancher"
@@ -29,6 +30,7 @@
 func main() {
 	management.RegisterPasswordResetCommand()
 	management.RegisterEnsureDefaultAdminCommand()
+	audit.RegisterVerifyCommand()
 	if reexec.Init() {
 		return
 	}
@@ -49,7 +51,10 @@ func main() {
 		os.Setenv("PATH", newPath)
 	}
 
-	var config rancher.Options
+	var (
+		config               rancher.Options
+		auditLogKafkaBrokers cli.StringSlice
+	)
 
 	app := cli.NewApp()
 	app.Version = version.FriendlyVersion()
@@ -116,7 +121,7 @@ func main() {
 			Name:        "audit-log-path",
 			EnvVar:      "AUDIT_LOG_PATH",
 			Value:       "/var/log/auditlog/rancher-api-audit.log",
-			Usage:       "Log path for Rancher Server API. Default path is /var/log/auditlog/rancher-api-audit.log",
+			Usage:       "Log path for Rancher Server API. Default path is /var/log/auditlog/rancher-api-audit.log, set to an empty string to only send entries to the audit log sinks",
 			Destination: &config.AuditLogPath,
 		},
 		cli.IntFlag{
@@ -147,6 +152,71 @@ func main() {
 			Usage:       "Audit log level: 0 - disable audit log, 1 - log event metadata, 2 - log event metadata and request body, 3 - log event metadata, request body and response body",
 			Destination: &config.AuditLevel,
 		},
+		cli.StringFlag{
+			Name:        "audit-log-syslog-address",
+			EnvVar:      "AUDIT_LOG_SYSLOG_ADDRESS",
+			Usage:       "Send audit log entries to an RFC 5424 syslog receiver. Example: \"tls://syslog.example.com:6514\"",
+			Destination: &config.AuditLogSinks.SyslogAddress,
+		},
+		cli.StringFlag{
+			Name:        "audit-log-webhook-url",
+			EnvVar:      "AUDIT_LOG_WEBHOOK_URL",
+			Usage:       "Send batches of audit log entries to an HTTP(S) endpoint",
+			Destination: &config.AuditLogSinks.WebhookURL,
+		},
+		cli.StringSliceFlag{
+			Name:   "audit-log-kafka-broker",
+			EnvVar: "AUDIT_LOG_KAFKA_BROKERS",
+			Usage:  "Kafka broker to send audit log entries to, use the tls:// scheme for TLS connections",
+			Value:  &auditLogKafkaBrokers,
+		},
+		cli.StringFlag{
+			Name:        "audit-log-kafka-topic",
+			EnvVar:      "AUDIT_LOG_KAFKA_TOPIC",
+			Value:       "rancher-audit-log",
+			Usage:       "Kafka topic audit log entries are sent to",
+			Destination: &config.AuditLogSinks.KafkaTopic,
+		},
+		cli.StringFlag{
+			Name:        "audit-log-sink-cacerts",
+			EnvVar:      "AUDIT_LOG_SINK_CACERTS",
+			Usage:       "Path to a PEM bundle used to verify TLS connections to audit log sinks, defaults to the system roots",
+			Destination: &config.AuditLogSinks.CACertsFile,
+		},
+		cli.StringFlag{
+			Name:        "audit-log-spool-dir",
+			EnvVar:      "AUDIT_LOG_SPOOL_DIR",
+			Value:       "/var/log/auditlog/spool",
+			Usage:       "Directory audit log entries are spooled to while a sink is unavailable",
+			Destination: &config.AuditLogSinks.SpoolDir,
+		},
+		cli.IntFlag{
+			Name:        "audit-log-spool-maxsize",
+			EnvVar:      "AUDIT_LOG_SPOOL_MAXSIZE",
+			Value:       100,
+			Usage:       "Defines the maximum size in megabytes of each audit log sink's spool, entries are dropped once it is full",
+			Destination: &config.AuditLogSinks.SpoolMaxSize,
+		},
+		cli.BoolFlag{
+			Name:        "audit-log-hash-chain",
+			EnvVar:      "AUDIT_LOG_HASH_CHAIN",
+			Usage:       "Link every audit log entry to the hash of the previous one and periodically write signed checkpoints, verify the log with audit-log-verify",
+			Destination: &config.AuditLogChain.Enabled,
+		},
+		cli.IntFlag{
+			Name:        "audit-log-checkpoint-interval",
+			EnvVar:      "AUDIT_LOG_CHECKPOINT_INTERVAL",
+			Value:       1000,
+			Usage:       "Defines the number of audit log entries between signed checkpoints",
+			Destination: &config.AuditLogChain.CheckpointInterval,
+		},
+		cli.StringFlag{
+			Name:        "audit-log-checkpoint-secret",
+			EnvVar:      "AUDIT_LOG_CHECKPOINT_SECRET",
+			Value:       "cattle-system/rancher-audit-log-checkpoint-key",
+			Usage:       "namespace/name of the secret holding the hmac-key or ed25519-key used to sign checkpoints, an HMAC key is generated if it doesn't exist",
+			Destination: &config.AuditLogChain.KeySecret,
+		},
 		cli.StringFlag{
 			Name:        "profile-listen-address",
 			Value:       "127.0.0.1:6060",
@@ -169,6 +239,7 @@ func main() {
 				log.Println(http.ListenAndServe(profileAddress, nil))
 			}()
 		}
+		config.AuditLogSinks.KafkaBrokers = auditLogKafkaBrokers
 		initLogs(c, config)
 		return run(c, config)
 	}
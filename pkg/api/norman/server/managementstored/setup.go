@@ -149,6 +149,8 @@ func Setup(ctx context.Context, apiContext *config.ScaledContext, clusterManager
 		client.GlobalDnsType,
 		client.GlobalDnsProviderType,
 		client.RancherUserNotificationType,
+		client.AuditPolicyType,
+		client.LoginLockoutType,
 	)
 
 	factory.BatchCreateCRDs(ctx, config.ManagementStorageContext, scheme.Scheme, schemas, &projectschema.Version,
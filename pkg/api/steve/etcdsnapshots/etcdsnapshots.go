@@ -0,0 +1,79 @@
+// Package etcdsnapshots provides a read-only catalog of the etcd snapshots of all clusters, normalizing the
+// EtcdBackup objects of RKE1 clusters and the ETCDSnapshot objects of RKE2/K3s clusters.
+package etcdsnapshots
+
+import (
+	"net/http"
+
+	"github.com/rancher/apiserver/pkg/types"
+	"github.com/rancher/rancher/pkg/features"
+	"github.com/rancher/rancher/pkg/wrangler"
+	steve "github.com/rancher/steve/pkg/server"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	KindEtcdBackup   = "EtcdBackup"
+	KindETCDSnapshot = "ETCDSnapshot"
+
+	StatusSuccessful = "successful"
+	StatusFailed     = "failed"
+	StatusInProgress = "inProgress"
+	StatusMissing    = "missing"
+
+	// kubernetesVersionCacheSize is the number of RKE2/K3s snapshots the decoded Kubernetes version is cached for.
+	kubernetesVersionCacheSize = 4096
+)
+
+// EtcdSnapshotCatalogEntry is the normalized metadata of an etcd snapshot of a cluster.
+type EtcdSnapshotCatalogEntry struct {
+	// Kind is the kind of the object the entry was built from, EtcdBackup for RKE1 clusters and ETCDSnapshot for
+	// RKE2/K3s clusters.
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// ClusterID is the ID of the management cluster.
+	ClusterID string `json:"clusterId,omitempty"`
+	// ClusterName is the display name of the cluster for RKE1 clusters and the name of the provisioning cluster for
+	// RKE2/K3s clusters.
+	ClusterName string `json:"clusterName,omitempty"`
+	// NodeName is the node a local RKE2/K3s snapshot is stored on. Local RKE1 snapshots are stored on all etcd nodes.
+	NodeName          string    `json:"nodeName,omitempty"`
+	Filename          string    `json:"filename,omitempty"`
+	Location          string    `json:"location,omitempty"`
+	Size              int64     `json:"size,omitempty"`
+	KubernetesVersion string    `json:"kubernetesVersion,omitempty"`
+	S3                *S3Target `json:"s3,omitempty"`
+	Status            string    `json:"status"`
+	Message           string    `json:"message,omitempty"`
+	CreatedAt         string    `json:"createdAt,omitempty"`
+}
+
+// S3Target is the S3 location a snapshot was uploaded to.
+type S3Target struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	Bucket   string `json:"bucket,omitempty"`
+	Region   string `json:"region,omitempty"`
+	Folder   string `json:"folder,omitempty"`
+}
+
+// Register adds the read-only etcdSnapshotCatalogEntry collection. It supports the cluster query parameter, matching
+// the ID or name of the cluster and repeatable to select multiple clusters, and the newerThan and olderThan query
+// parameters, durations like 24h filtering snapshots by their age.
+func Register(server *steve.Server, clients *wrangler.Context) {
+	s := &store{
+		etcdBackups:        clients.Mgmt.EtcdBackup().Cache(),
+		mgmtClusters:       clients.Mgmt.Cluster().Cache(),
+		kubernetesVersions: cache.NewLRUExpireCache(kubernetesVersionCacheSize),
+	}
+	if features.RKE2.Enabled() {
+		s.etcdSnapshots = clients.RKE.ETCDSnapshot().Cache()
+		s.provClusters = clients.Provisioning.Cluster().Cache()
+	}
+
+	server.BaseSchemas.MustImportAndCustomize(EtcdSnapshotCatalogEntry{}, func(schema *types.APISchema) {
+		schema.CollectionMethods = []string{http.MethodGet}
+		schema.ResourceMethods = []string{http.MethodGet}
+		schema.Store = s
+	})
+}
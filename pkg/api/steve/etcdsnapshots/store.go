@@ -0,0 +1,316 @@
+package etcdsnapshots
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rancher/apiserver/pkg/apierror"
+	"github.com/rancher/apiserver/pkg/store/empty"
+	"github.com/rancher/apiserver/pkg/types"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/management/clusterprovisioner"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2/provisioningcluster"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	provcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rke/services"
+	rketypes "github.com/rancher/rke/types"
+	"github.com/rancher/wrangler/pkg/schemas/validation"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	etcdBackupsResource   = "management.cattle.io/etcdbackups"
+	etcdSnapshotsResource = "rke.cattle.io/etcdsnapshots"
+
+	// kubernetesVersionCacheTTL is how long the Kubernetes version decoded from the metadata of a snapshot is cached,
+	// the metadata of a snapshot never changes.
+	kubernetesVersionCacheTTL = 24 * time.Hour
+)
+
+type store struct {
+	empty.Store
+	etcdBackups   mgmtcontrollers.EtcdBackupCache
+	mgmtClusters  mgmtcontrollers.ClusterCache
+	etcdSnapshots rkecontrollers.ETCDSnapshotCache
+	provClusters  provcontrollers.ClusterCache
+
+	kubernetesVersions *cache.LRUExpireCache
+}
+
+func (s *store) ByID(apiOp *types.APIRequest, schema *types.APISchema, id string) (types.APIObject, error) {
+	namespace, name, ok := strings.Cut(id, "/")
+	if !ok {
+		return types.APIObject{}, validation.NotFound
+	}
+
+	if s.etcdSnapshots != nil {
+		snapshot, err := s.etcdSnapshots.Get(namespace, name)
+		if err == nil {
+			if apiOp.AccessControl.CanDo(apiOp, etcdSnapshotsResource, "get", namespace, name) != nil {
+				return types.APIObject{}, validation.NotFound
+			}
+			return toAPIObject(schema, s.fromETCDSnapshot(snapshot)), nil
+		} else if !apierrors.IsNotFound(err) {
+			return types.APIObject{}, err
+		}
+	}
+
+	backup, err := s.etcdBackups.Get(namespace, name)
+	if apierrors.IsNotFound(err) {
+		return types.APIObject{}, validation.NotFound
+	} else if err != nil {
+		return types.APIObject{}, err
+	}
+	if apiOp.AccessControl.CanDo(apiOp, etcdBackupsResource, "get", namespace, name) != nil {
+		return types.APIObject{}, validation.NotFound
+	}
+	return toAPIObject(schema, s.fromEtcdBackup(backup)), nil
+}
+
+func (s *store) List(apiOp *types.APIRequest, schema *types.APISchema) (types.APIObjectList, error) {
+	f, err := parseFilter(apiOp.Request.URL.Query())
+	if err != nil {
+		return types.APIObjectList{}, err
+	}
+
+	var entries []EtcdSnapshotCatalogEntry
+	now := time.Now()
+
+	if s.etcdSnapshots != nil {
+		snapshots, err := s.etcdSnapshots.List("", labels.Everything())
+		if err != nil {
+			return types.APIObjectList{}, err
+		}
+		for _, snapshot := range snapshots {
+			if apiOp.AccessControl.CanDo(apiOp, etcdSnapshotsResource, "get", snapshot.Namespace, snapshot.Name) != nil {
+				continue
+			}
+			if entry := s.fromETCDSnapshot(snapshot); f.matches(entry, now) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	backups, err := s.etcdBackups.List("", labels.Everything())
+	if err != nil {
+		return types.APIObjectList{}, err
+	}
+	for _, backup := range backups {
+		if apiOp.AccessControl.CanDo(apiOp, etcdBackupsResource, "get", backup.Namespace, backup.Name) != nil {
+			continue
+		}
+		if entry := s.fromEtcdBackup(backup); f.matches(entry, now) {
+			entries = append(entries, entry)
+		}
+	}
+
+	sortEntries(entries)
+	result := types.APIObjectList{
+		Objects: make([]types.APIObject, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		result.Objects = append(result.Objects, toAPIObject(schema, entry))
+	}
+	return result, nil
+}
+
+func toAPIObject(schema *types.APISchema, entry EtcdSnapshotCatalogEntry) types.APIObject {
+	return types.APIObject{
+		Type:   schema.ID,
+		ID:     entry.Namespace + "/" + entry.Name,
+		Object: entry,
+	}
+}
+
+// sortEntries sorts the entries by cluster, newest snapshot first.
+func sortEntries(entries []EtcdSnapshotCatalogEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ClusterID != entries[j].ClusterID {
+			return entries[i].ClusterID < entries[j].ClusterID
+		}
+		if entries[i].CreatedAt != entries[j].CreatedAt {
+			return entries[i].CreatedAt > entries[j].CreatedAt
+		}
+		return entries[i].Name < entries[j].Name
+	})
+}
+
+// filter selects the entries returned by List.
+type filter struct {
+	clusters  []string
+	newerThan time.Duration
+	olderThan time.Duration
+}
+
+func parseFilter(query url.Values) (filter, error) {
+	var (
+		f   filter
+		err error
+	)
+	for _, cluster := range query["cluster"] {
+		if cluster != "" {
+			f.clusters = append(f.clusters, cluster)
+		}
+	}
+	if value := query.Get("newerThan"); value != "" {
+		if f.newerThan, err = time.ParseDuration(value); err != nil || f.newerThan <= 0 {
+			return f, apierror.NewAPIError(validation.InvalidFormat, fmt.Sprintf("invalid newerThan %q, must be a positive duration like 24h", value))
+		}
+	}
+	if value := query.Get("olderThan"); value != "" {
+		if f.olderThan, err = time.ParseDuration(value); err != nil || f.olderThan <= 0 {
+			return f, apierror.NewAPIError(validation.InvalidFormat, fmt.Sprintf("invalid olderThan %q, must be a positive duration like 720h", value))
+		}
+	}
+	return f, nil
+}
+
+func (f filter) matches(entry EtcdSnapshotCatalogEntry, now time.Time) bool {
+	if len(f.clusters) > 0 {
+		found := false
+		for _, cluster := range f.clusters {
+			if cluster == entry.ClusterID || cluster == entry.ClusterName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.newerThan == 0 && f.olderThan == 0 {
+		return true
+	}
+	createdAt, err := time.Parse(time.RFC3339, entry.CreatedAt)
+	if err != nil {
+		return false
+	}
+	age := now.Sub(createdAt)
+	if f.newerThan > 0 && age > f.newerThan {
+		return false
+	}
+	if f.olderThan > 0 && age < f.olderThan {
+		return false
+	}
+	return true
+}
+
+// fromETCDSnapshot returns the catalog entry of the snapshot of an RKE2/K3s cluster.
+func (s *store) fromETCDSnapshot(snapshot *rkev1.ETCDSnapshot) EtcdSnapshotCatalogEntry {
+	entry := EtcdSnapshotCatalogEntry{
+		Kind:              KindETCDSnapshot,
+		Namespace:         snapshot.Namespace,
+		Name:              snapshot.Name,
+		ClusterName:       snapshot.Spec.ClusterName,
+		NodeName:          snapshot.SnapshotFile.NodeName,
+		Filename:          snapshot.SnapshotFile.Name,
+		Location:          snapshot.SnapshotFile.Location,
+		Size:              snapshot.SnapshotFile.Size,
+		KubernetesVersion: s.kubernetesVersion(snapshot),
+		Message:           snapshot.SnapshotFile.Message,
+		CreatedAt:         snapshot.CreationTimestamp.UTC().Format(time.RFC3339),
+	}
+	if entry.ClusterName == "" {
+		entry.ClusterName = snapshot.Labels[rke2.ClusterNameLabel]
+	}
+	if s.provClusters != nil && entry.ClusterName != "" {
+		if cluster, err := s.provClusters.Get(snapshot.Namespace, entry.ClusterName); err == nil {
+			entry.ClusterID = cluster.Status.ClusterName
+		}
+	}
+	if snapshot.SnapshotFile.CreatedAt != nil {
+		entry.CreatedAt = snapshot.SnapshotFile.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if s3 := snapshot.SnapshotFile.S3; s3 != nil {
+		// S3 snapshots are not stored on a node
+		entry.NodeName = ""
+		entry.S3 = &S3Target{
+			Endpoint: s3.Endpoint,
+			Bucket:   s3.Bucket,
+			Region:   s3.Region,
+			Folder:   s3.Folder,
+		}
+	}
+
+	switch {
+	case snapshot.Status.Missing:
+		entry.Status = StatusMissing
+	case snapshot.SnapshotFile.Status == StatusSuccessful, snapshot.SnapshotFile.Status == StatusFailed:
+		entry.Status = snapshot.SnapshotFile.Status
+	default:
+		entry.Status = StatusInProgress
+	}
+	return entry
+}
+
+// kubernetesVersion returns the Kubernetes version of the cluster spec stored in the metadata of the snapshot, or an
+// empty string if the snapshot has no metadata.
+func (s *store) kubernetesVersion(snapshot *rkev1.ETCDSnapshot) string {
+	if snapshot.SnapshotFile.Metadata == "" {
+		return ""
+	}
+	if version, ok := s.kubernetesVersions.Get(snapshot.UID); ok {
+		return version.(string)
+	}
+	var version string
+	if spec, err := provisioningcluster.SnapshotClusterSpec(snapshot); err == nil {
+		version = spec.KubernetesVersion
+	}
+	s.kubernetesVersions.Add(snapshot.UID, version, kubernetesVersionCacheTTL)
+	return version
+}
+
+// fromEtcdBackup returns the catalog entry of the snapshot of an RKE1 cluster.
+func (s *store) fromEtcdBackup(backup *v3.EtcdBackup) EtcdSnapshotCatalogEntry {
+	entry := EtcdSnapshotCatalogEntry{
+		Kind:              KindEtcdBackup,
+		Namespace:         backup.Namespace,
+		Name:              backup.Name,
+		ClusterID:         backup.Spec.ClusterID,
+		KubernetesVersion: backup.Status.KubernetesVersion,
+		CreatedAt:         backup.CreationTimestamp.UTC().Format(time.RFC3339),
+	}
+	if entry.ClusterID == "" {
+		entry.ClusterID = backup.Namespace
+	}
+	if cluster, err := s.mgmtClusters.Get(entry.ClusterID); err == nil {
+		entry.ClusterName = cluster.Spec.DisplayName
+	}
+
+	if filename, err := clusterprovisioner.GetBackupFilenameFromURL(backup.Spec.Filename); err == nil {
+		entry.Filename = filename
+		entry.Location = backup.Spec.Filename
+	} else if backup.Spec.Filename != "" {
+		entry.Filename = backup.Spec.Filename
+		entry.Location = "file://" + path.Join(services.EtcdSnapshotPath, backup.Spec.Filename)
+	}
+	if s3 := backup.Spec.BackupConfig.S3BackupConfig; s3 != nil {
+		entry.S3 = &S3Target{
+			Endpoint: s3.Endpoint,
+			Bucket:   s3.BucketName,
+			Region:   s3.Region,
+			Folder:   s3.Folder,
+		}
+	}
+
+	switch {
+	case rketypes.BackupConditionCompleted.IsTrue(backup):
+		entry.Status = StatusSuccessful
+	case rketypes.BackupConditionCompleted.IsFalse(backup):
+		entry.Status = StatusFailed
+		entry.Message = rketypes.BackupConditionCompleted.GetMessage(backup)
+	default:
+		entry.Status = StatusInProgress
+	}
+	return entry
+}
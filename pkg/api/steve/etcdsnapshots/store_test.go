@@ -0,0 +1,245 @@
+package etcdsnapshots
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	rketypes "github.com/rancher/rke/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+func TestFromETCDSnapshot(t *testing.T) {
+	s := &store{kubernetesVersions: cache.NewLRUExpireCache(10)}
+	createdAt := metav1.NewTime(time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	local := s.fromETCDSnapshot(&rkev1.ETCDSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "c1-etcd-snapshot-1", UID: "1"},
+		Spec:       rkev1.ETCDSnapshotSpec{ClusterName: "c1"},
+		SnapshotFile: rkev1.ETCDSnapshotFile{
+			Name:      "etcd-snapshot-1",
+			NodeName:  "node1",
+			Location:  "file:///var/lib/rancher/rke2/server/db/snapshots/etcd-snapshot-1",
+			Metadata:  snapshotMetadata(t, "v1.25.7+rke2r1"),
+			CreatedAt: &createdAt,
+			Size:      1024,
+			Status:    "successful",
+		},
+	})
+	assert.Equal(t, EtcdSnapshotCatalogEntry{
+		Kind:              KindETCDSnapshot,
+		Namespace:         "fleet-default",
+		Name:              "c1-etcd-snapshot-1",
+		ClusterName:       "c1",
+		NodeName:          "node1",
+		Filename:          "etcd-snapshot-1",
+		Location:          "file:///var/lib/rancher/rke2/server/db/snapshots/etcd-snapshot-1",
+		Size:              1024,
+		KubernetesVersion: "v1.25.7+rke2r1",
+		Status:            StatusSuccessful,
+		CreatedAt:         "2023-03-01T12:00:00Z",
+	}, local)
+
+	s3 := s.fromETCDSnapshot(&rkev1.ETCDSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "c1-etcd-snapshot-1-s3", UID: "2"},
+		Spec:       rkev1.ETCDSnapshotSpec{ClusterName: "c1"},
+		SnapshotFile: rkev1.ETCDSnapshotFile{
+			Name:     "etcd-snapshot-1",
+			NodeName: "s3",
+			S3:       &rkev1.ETCDSnapshotS3{Endpoint: "s3.amazonaws.com", Bucket: "backups", Region: "us-east-1", Folder: "c1"},
+		},
+		Status: rkev1.ETCDSnapshotStatus{Missing: true},
+	})
+	assert.Empty(t, s3.NodeName)
+	assert.Empty(t, s3.KubernetesVersion)
+	assert.Equal(t, &S3Target{Endpoint: "s3.amazonaws.com", Bucket: "backups", Region: "us-east-1", Folder: "c1"}, s3.S3)
+	assert.Equal(t, StatusMissing, s3.Status)
+}
+
+func TestFromEtcdBackup(t *testing.T) {
+	s := &store{mgmtClusters: &fakeClusterCache{clusters: map[string]*v3.Cluster{
+		"c-abcde": {Spec: v3.ClusterSpec{DisplayName: "production"}},
+	}}}
+
+	completed := &v3.EtcdBackup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "c-abcde", Name: "c-abcde-rl-xyz12", CreationTimestamp: metav1.NewTime(time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC))},
+		Spec: rketypes.EtcdBackupSpec{
+			ClusterID: "c-abcde",
+			Filename:  "https://s3.amazonaws.com/backups/rke1/c-abcde-rl-xyz12_2023-03-01T12:00:00Z.zip",
+			BackupConfig: rketypes.BackupConfig{S3BackupConfig: &rketypes.S3BackupConfig{
+				AccessKey:  "access",
+				SecretKey:  "secret",
+				BucketName: "backups",
+				Region:     "us-east-1",
+				Endpoint:   "s3.amazonaws.com",
+				Folder:     "rke1",
+			}},
+		},
+		Status: rketypes.EtcdBackupStatus{KubernetesVersion: "v1.24.10-rancher4-1"},
+	}
+	rketypes.BackupConditionCompleted.True(completed)
+
+	assert.Equal(t, EtcdSnapshotCatalogEntry{
+		Kind:              KindEtcdBackup,
+		Namespace:         "c-abcde",
+		Name:              "c-abcde-rl-xyz12",
+		ClusterID:         "c-abcde",
+		ClusterName:       "production",
+		Filename:          "c-abcde-rl-xyz12_2023-03-01T12:00:00Z.zip",
+		Location:          "https://s3.amazonaws.com/backups/rke1/c-abcde-rl-xyz12_2023-03-01T12:00:00Z.zip",
+		KubernetesVersion: "v1.24.10-rancher4-1",
+		S3:                &S3Target{Endpoint: "s3.amazonaws.com", Bucket: "backups", Region: "us-east-1", Folder: "rke1"},
+		Status:            StatusSuccessful,
+		CreatedAt:         "2023-03-01T12:00:00Z",
+	}, s.fromEtcdBackup(completed))
+
+	failed := &v3.EtcdBackup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "c-fghij", Name: "c-fghij-rl-xyz12"},
+		Spec: rketypes.EtcdBackupSpec{
+			ClusterID: "c-fghij",
+			Filename:  "c-fghij-rl-xyz12_2023-03-01T12:00:00Z.zip",
+		},
+	}
+	rketypes.BackupConditionCompleted.False(failed)
+	rketypes.BackupConditionCompleted.Message(failed, "etcd snapshot failed")
+
+	entry := s.fromEtcdBackup(failed)
+	assert.Empty(t, entry.ClusterName)
+	assert.Nil(t, entry.S3)
+	assert.Equal(t, "file:///opt/rke/etcd-snapshots/c-fghij-rl-xyz12_2023-03-01T12:00:00Z.zip", entry.Location)
+	assert.Equal(t, StatusFailed, entry.Status)
+	assert.Equal(t, "etcd snapshot failed", entry.Message)
+}
+
+func TestFilter(t *testing.T) {
+	now := time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)
+	day := func(d int) string {
+		return now.Add(-time.Duration(d) * 24 * time.Hour).Format(time.RFC3339)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		entry EtcdSnapshotCatalogEntry
+		want  bool
+	}{
+		{
+			name:  "no filter",
+			entry: EtcdSnapshotCatalogEntry{ClusterID: "c-abcde", CreatedAt: day(100)},
+			want:  true,
+		},
+		{
+			name:  "cluster id",
+			query: "cluster=c-abcde",
+			entry: EtcdSnapshotCatalogEntry{ClusterID: "c-abcde", ClusterName: "production"},
+			want:  true,
+		},
+		{
+			name:  "cluster name",
+			query: "cluster=staging&cluster=production",
+			entry: EtcdSnapshotCatalogEntry{ClusterID: "c-abcde", ClusterName: "production"},
+			want:  true,
+		},
+		{
+			name:  "other cluster",
+			query: "cluster=staging",
+			entry: EtcdSnapshotCatalogEntry{ClusterID: "c-abcde", ClusterName: "production"},
+		},
+		{
+			name:  "newer than",
+			query: "newerThan=48h",
+			entry: EtcdSnapshotCatalogEntry{CreatedAt: day(1)},
+			want:  true,
+		},
+		{
+			name:  "not newer than",
+			query: "newerThan=48h",
+			entry: EtcdSnapshotCatalogEntry{CreatedAt: day(3)},
+		},
+		{
+			name:  "older than",
+			query: "olderThan=720h",
+			entry: EtcdSnapshotCatalogEntry{CreatedAt: day(31)},
+			want:  true,
+		},
+		{
+			name:  "not older than",
+			query: "olderThan=720h&cluster=c-abcde",
+			entry: EtcdSnapshotCatalogEntry{ClusterID: "c-abcde", CreatedAt: day(29)},
+		},
+		{
+			name:  "age of unknown creation time",
+			query: "olderThan=720h",
+			entry: EtcdSnapshotCatalogEntry{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			f, err := parseFilter(query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, f.matches(tt.entry, now))
+		})
+	}
+
+	for _, query := range []string{"newerThan=1d", "olderThan=-24h"} {
+		values, err := url.ParseQuery(query)
+		require.NoError(t, err)
+		_, err = parseFilter(values)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestSortEntries(t *testing.T) {
+	entries := []EtcdSnapshotCatalogEntry{
+		{ClusterID: "c-b", Name: "b1", CreatedAt: "2023-03-01T00:00:00Z"},
+		{ClusterID: "c-a", Name: "a1", CreatedAt: "2023-03-01T00:00:00Z"},
+		{ClusterID: "c-a", Name: "a2", CreatedAt: "2023-03-02T00:00:00Z"},
+	}
+	sortEntries(entries)
+	assert.Equal(t, []string{"a2", "a1", "b1"}, []string{entries[0].Name, entries[1].Name, entries[2].Name})
+}
+
+// snapshotMetadata returns the metadata of a snapshot of a cluster with the given Kubernetes version.
+func snapshotMetadata(t *testing.T, kubernetesVersion string) string {
+	spec, err := json.Marshal(map[string]string{"kubernetesVersion": kubernetesVersion})
+	require.NoError(t, err)
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	_, err = gz.Write(spec)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	metadata, err := json.Marshal(map[string]string{"provisioning-cluster-spec": base64.StdEncoding.EncodeToString(b.Bytes())})
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(metadata)
+}
+
+type fakeClusterCache struct {
+	clusters map[string]*v3.Cluster
+}
+
+func (f *fakeClusterCache) Get(name string) (*v3.Cluster, error) {
+	if cluster, ok := f.clusters[name]; ok {
+		return cluster, nil
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{Group: "management.cattle.io", Resource: "clusters"}, name)
+}
+func (f *fakeClusterCache) List(selector labels.Selector) ([]*v3.Cluster, error)                { return nil, nil }
+func (f *fakeClusterCache) AddIndexer(indexName string, indexer mgmtcontrollers.ClusterIndexer) {}
+func (f *fakeClusterCache) GetByIndex(indexName, key string) ([]*v3.Cluster, error)             { return nil, nil }
@@ -15,6 +15,10 @@ func Register(server *steve.Server, clients *wrangler.Context) {
 		machines: clients.CAPI.Machine(),
 		secrets:  clients.Core.Secret(),
 	}
+	planOutput := &planOutputHandler{
+		machines: clients.CAPI.Machine().Cache(),
+		secrets:  clients.Core.Secret().Cache(),
+	}
 
 	server.SchemaFactory.AddTemplate(schema2.Template{
 		Group: "cluster.x-k8s.io",
@@ -25,12 +29,17 @@ func Register(server *steve.Server, clients *wrangler.Context) {
 			}
 			schema.LinkHandlers["shell"] = sshClient
 			schema.LinkHandlers["sshkeys"] = sshClient
+			schema.LinkHandlers["planoutput"] = planOutput
 			schema.Formatter = func(request *types.APIRequest, resource *types.RawResource) {
 				if err := request.AccessControl.CanUpdate(request, types.APIObject{}, request.Schema); err != nil ||
 					resource.APIObject.Data().String("spec", "infrastructureRef", "apiVersion") != rke2.RKEMachineAPIVersion {
 					delete(resource.Links, "shell")
 					delete(resource.Links, "sshkeys")
 				}
+				if err := request.AccessControl.CanUpdate(request, types.APIObject{}, request.Schema); err != nil ||
+					resource.APIObject.Data().String("spec", "bootstrap", "configRef", "kind") != "RKEBootstrap" {
+					delete(resource.Links, "planoutput")
+				}
 			}
 		},
 	})
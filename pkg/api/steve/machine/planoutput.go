@@ -0,0 +1,87 @@
+package machine
+
+import (
+	"net/http"
+
+	"github.com/rancher/apiserver/pkg/types"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	"github.com/rancher/rancher/pkg/provisioningv2/rke2/planner"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/schemas/validation"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// machinePlanOutput is the output of the plan currently applied to a machine, and of the plans applied before it.
+type machinePlanOutput struct {
+	MachineName string               `json:"machineName"`
+	Current     *planner.PlanOutput  `json:"current,omitempty"`
+	History     []planner.PlanOutput `json:"history,omitempty"`
+}
+
+type planOutputHandler struct {
+	machines capicontrollers.MachineCache
+	secrets  corecontrollers.SecretCache
+}
+
+func (p *planOutputHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	apiRequest := types.GetAPIContext(req.Context())
+	// the output of the instructions can contain credentials, so it is restricted like the shell of the machine
+	if err := apiRequest.AccessControl.CanUpdate(apiRequest, types.APIObject{}, apiRequest.Schema); err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	output, err := p.planOutput(apiRequest.Namespace, apiRequest.Name)
+	if err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+	apiRequest.WriteResponse(http.StatusOK, types.APIObject{
+		Type:   "machinePlanOutput",
+		ID:     apiRequest.Name,
+		Object: output,
+	})
+}
+
+func (p *planOutputHandler) planOutput(namespace, name string) (*machinePlanOutput, error) {
+	machine, err := p.machines.Get(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if machine.Spec.Bootstrap.ConfigRef == nil || machine.Spec.Bootstrap.ConfigRef.Kind != "RKEBootstrap" {
+		// only machines bootstrapped by RKEBootstrap have a plan
+		return nil, validation.NotFound
+	}
+
+	result := &machinePlanOutput{
+		MachineName: machine.Name,
+	}
+	planSecretName := rke2.PlanSecretFromBootstrapName(machine.Spec.Bootstrap.ConfigRef.Name)
+	planSecret, err := p.secrets.Get(namespace, planSecretName)
+	if apierrors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if result.Current, err = planner.SecretToPlanOutput(planSecret); err != nil {
+		return nil, err
+	}
+
+	historySecret, err := p.secrets.Get(namespace, planner.PlanOutputHistorySecretName(planSecretName))
+	if apierrors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return nil, err
+	}
+	history, err := planner.SecretToPlanOutputHistory(historySecret)
+	if err != nil {
+		return nil, err
+	}
+	for _, output := range history {
+		if result.Current == nil || output.AppliedChecksum != result.Current.AppliedChecksum {
+			result.History = append(result.History, output)
+		}
+	}
+	return result, nil
+}
@@ -6,6 +6,7 @@
 	"github.com/rancher/rancher/pkg/api/steve/catalog"
 	"github.com/rancher/rancher/pkg/api/steve/clusters"
 	"github.com/rancher/rancher/pkg/api/steve/disallow"
+	"github.com/rancher/rancher/pkg/api/steve/etcdsnapshots"
 	"github.com/rancher/rancher/pkg/api/steve/machine"
 	"github.com/rancher/rancher/pkg/api/steve/navlinks"
 	"github.com/rancher/rancher/pkg/api/steve/settings"
@@ -20,6 +21,7 @@ func Setup(ctx context.Context, server *steve.Server, config *wrangler.Context)
 		return err
 	}
 	machine.Register(server, config)
+	etcdsnapshots.Register(server, config)
 	navlinks.Register(ctx, server)
 	settings.Register(server)
 	disallow.Register(server)
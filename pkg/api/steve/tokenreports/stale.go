@@ -0,0 +1,192 @@
+// Package tokenreports provides a HTTPHandler reporting tokens that weren't used recently. This handler should be
+// registered at Endpoint
+package tokenreports
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rancher/rancher/pkg/auth/tokens"
+	"github.com/rancher/rancher/pkg/auth/util"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that this URL is accessible at - used for routing
+	Endpoint = "/v1/staleTokenReport"
+	// defaultStaleDays is used when neither the days query parameter nor auth-token-max-idle-days is set.
+	defaultStaleDays = 90
+	logPrefix        = "stale-token-report"
+)
+
+// Report lists the tokens not used within Days, grouped by user.
+type Report struct {
+	Days  int          `json:"days"`
+	Users []UserTokens `json:"users"`
+}
+
+// UserTokens are the stale tokens of a single user.
+type UserTokens struct {
+	UserID      string       `json:"userId"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Username    string       `json:"username,omitempty"`
+	Tokens      []StaleToken `json:"tokens"`
+}
+
+// StaleToken describes a token not used within the report's time frame.
+type StaleToken struct {
+	Name           string `json:"name"`
+	Kind           string `json:"kind,omitempty"`
+	Description    string `json:"description,omitempty"`
+	ClusterName    string `json:"clusterName,omitempty"`
+	Created        string `json:"created"`
+	ExpiresAt      string `json:"expiresAt,omitempty"`
+	LastUsedAt     string `json:"lastUsedAt,omitempty"`
+	LastUsedFrom   string `json:"lastUsedFrom,omitempty"`
+	IdleDays       int    `json:"idleDays"`
+	Enabled        bool   `json:"enabled"`
+	DisabledReason string `json:"disabledReason,omitempty"`
+}
+
+// Handler implements http.Handler and serves the stale token report to users allowed to list all tokens.
+type Handler struct {
+	Tokens               v3.TokenLister
+	Users                v3.UserLister
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) *Handler {
+	return &Handler{
+		Tokens:               scaledContext.Management.Tokens("").Controller().Lister(),
+		Users:                scaledContext.Management.Users("").Controller().Lister(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// ServeHTTP implements http.Handler. The optional days query parameter overrides the number of days after which a
+// token is considered stale.
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	authorized, err := h.authorize(req)
+	if err != nil {
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	days := defaultStaleDays
+	if maxIdle := tokens.MaxIdle(); maxIdle > 0 {
+		days = int(maxIdle.Hours() / 24)
+	}
+	if value := req.URL.Query().Get("days"); value != "" {
+		days, err = strconv.Atoi(value)
+		if err != nil || days < 0 {
+			util.ReturnHTTPError(writer, req, http.StatusBadRequest, "days must be a non-negative integer")
+			return
+		}
+	}
+
+	report, err := h.report(days, time.Now())
+	if err != nil {
+		logrus.Errorf("[%s] Failed to build report: %v", logPrefix, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(report); err != nil {
+		logrus.Warnf("[%s] Failed to write report: %v", logPrefix, err)
+	}
+}
+
+// authorize checks that the user can list tokens of all users.
+func (h *Handler) authorize(r *http.Request) (bool, error) {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return false, fmt.Errorf("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = v
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    "management.cattle.io",
+				Resource: "tokens",
+				Verb:     "list",
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
+
+func (h *Handler) report(days int, now time.Time) (*Report, error) {
+	allTokens, err := h.Tokens.List("", labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	maxIdle := time.Duration(days) * 24 * time.Hour
+	byUser := map[string]*UserTokens{}
+	for _, token := range allTokens {
+		if !tokens.IsUserToken(token) || tokens.IsExpired(*token) || now.Sub(tokens.IdleSince(token)) <= maxIdle {
+			continue
+		}
+
+		userTokens, ok := byUser[token.UserID]
+		if !ok {
+			userTokens = &UserTokens{UserID: token.UserID}
+			if user, err := h.Users.Get("", token.UserID); err == nil {
+				userTokens.DisplayName = user.DisplayName
+				userTokens.Username = user.Username
+			}
+			byUser[token.UserID] = userTokens
+		}
+		userTokens.Tokens = append(userTokens.Tokens, StaleToken{
+			Name:           token.Name,
+			Kind:           token.Labels[tokens.TokenKindLabel],
+			Description:    token.Description,
+			ClusterName:    token.ClusterName,
+			Created:        token.CreationTimestamp.UTC().Format(time.RFC3339),
+			ExpiresAt:      token.ExpiresAt,
+			LastUsedAt:     token.LastUsedAt,
+			LastUsedFrom:   token.LastUsedFrom,
+			IdleDays:       int(now.Sub(tokens.IdleSince(token)).Hours() / 24),
+			Enabled:        token.Enabled == nil || *token.Enabled,
+			DisabledReason: token.Annotations[tokens.DisabledReasonAnnotation],
+		})
+	}
+
+	report := &Report{Days: days, Users: []UserTokens{}}
+	for _, userTokens := range byUser {
+		sort.Slice(userTokens.Tokens, func(i, j int) bool {
+			return userTokens.Tokens[i].IdleDays > userTokens.Tokens[j].IdleDays
+		})
+		report.Users = append(report.Users, *userTokens)
+	}
+	sort.Slice(report.Users, func(i, j int) bool {
+		return report.Users[i].UserID < report.Users[j].UserID
+	})
+	return report, nil
+}
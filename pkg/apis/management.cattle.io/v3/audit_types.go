@@ -0,0 +1,75 @@
+package v3
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// AuditLevel is the amount of information recorded in the Rancher API audit log for a request.
+type AuditLevel string
+
+const (
+	// AuditLevelNone omits matching requests from the audit log.
+	AuditLevelNone AuditLevel = "None"
+	// AuditLevelMetadata logs request and response metadata.
+	AuditLevelMetadata AuditLevel = "Metadata"
+	// AuditLevelRequest logs metadata and the request body.
+	AuditLevelRequest AuditLevel = "Request"
+	// AuditLevelRequestResponse logs metadata, the request body and the response body.
+	AuditLevelRequestResponse AuditLevel = "RequestResponse"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuditPolicy overrides the global audit level for the requests matched by its rules.
+// The rules of all AuditPolicies are evaluated in order of the policies' names and the first matching rule wins.
+// Requests that match no rule are logged at the level set by the audit-level flag.
+// The redactions of all AuditPolicies apply in addition to the built-in redaction of secrets, credentials and
+// passwords.
+type AuditPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object’s metadata. More info:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AuditPolicySpec `json:"spec"`
+}
+
+type AuditPolicySpec struct {
+	Rules      []AuditPolicyRule    `json:"rules,omitempty"`
+	Redactions []AuditRedactionRule `json:"redactions,omitempty"`
+}
+
+// AuditPolicyRule maps requests to an audit level. A request matches the rule if it matches every non-empty list.
+type AuditPolicyRule struct {
+	// Level is the audit level for matching requests, None omits them from the audit log entirely.
+	Level AuditLevel `json:"level" norman:"required,type=enum,options=None|Metadata|Request|RequestResponse"`
+	// Users are user names such as u-abc12 or system:serviceaccount:cattle-system:rancher.
+	Users []string `json:"users,omitempty"`
+	// UserGroups are group principals or kubernetes groups of the requesting user.
+	UserGroups []string `json:"userGroups,omitempty"`
+	// Verbs are kubernetes style verbs: get, list, watch, create, update, patch, delete.
+	// Norman actions are matched by their action name.
+	Verbs []string `json:"verbs,omitempty"`
+	// RequestURIPrefixes match the beginning of the request URI, e.g. /v3/settings.
+	RequestURIPrefixes []string `json:"requestURIPrefixes,omitempty"`
+	// APIGroups are the API groups of the requested resource, "" is the core group.
+	APIGroups []string `json:"apiGroups,omitempty"`
+	// Resources are resource types matched case-insensitively in singular or plural form, e.g. secrets.
+	Resources []string `json:"resources,omitempty"`
+}
+
+// AuditRedactionRule removes values from the headers and bodies logged for requests to the matching resources.
+type AuditRedactionRule struct {
+	// Resources limits the rule to requests for these resource types, matched like AuditPolicyRule.Resources.
+	// An empty list applies the rule to every request.
+	Resources []string `json:"resources,omitempty"`
+	// Headers are request or response headers omitted from the log, e.g. X-Api-Key.
+	Headers []string `json:"headers,omitempty"`
+	// Paths are JSONPath expressions selecting body values to redact, e.g. $.amazonec2Config.secretKey or
+	// $.data[*].spec.apiKey. Supported are child (.name or ['name']), wildcard (.* or [*]), index ([0]) and
+	// recursive descent (..name).
+	Paths []string `json:"paths,omitempty"`
+	// Keys are regular expressions matched against body keys at any depth, the values of matching keys are redacted,
+	// e.g. ^(api|access)Key$.
+	Keys []string `json:"keys,omitempty"`
+}
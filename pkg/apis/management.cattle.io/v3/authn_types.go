@@ -35,12 +35,35 @@ type Token struct {
 	Current         bool              `json:"current"`
 	ClusterName     string            `json:"clusterName,omitempty" norman:"noupdate,type=reference[cluster]"`
 	Enabled         *bool             `json:"enabled,omitempty" norman:"default=true"`
+	// LastUsedAt is the RFC3339 time the token last authenticated a request. It is updated at most once a minute.
+	LastUsedAt string `json:"lastUsedAt,omitempty" norman:"nocreate,noupdate"`
+	// LastUsedFrom is the source IP of the request recorded in LastUsedAt.
+	LastUsedFrom string `json:"lastUsedFrom,omitempty" norman:"nocreate,noupdate"`
+	// Scopes restrict the token to requests matching at least one scope. A token without scopes carries all
+	// permissions of its user. Scoped tokens aren't synced to downstream clusters, so they can't be used with the
+	// authorized cluster endpoint.
+	Scopes []TokenScope `json:"scopes,omitempty" norman:"noupdate"`
 }
 
 func (t *Token) ObjClusterName() string {
 	return t.ClusterName
 }
 
+// TokenScope allows requests matching every non-empty list. Scopes only narrow the permissions of the token's user,
+// they never grant access the user doesn't have.
+type TokenScope struct {
+	// Verbs are kubernetes style verbs: get, list, watch, create, update, patch, delete. Norman actions are matched
+	// by their action name.
+	Verbs []string `json:"verbs,omitempty"`
+	// APIGroups are the API groups of the requested resource, "" is the core group.
+	APIGroups []string `json:"apiGroups,omitempty"`
+	// Resources are resource types matched case-insensitively in singular or plural form, e.g. apps.
+	Resources []string `json:"resources,omitempty"`
+	// Projects are project IDs such as c-abc12:p-xyz. Requests must address a namespace of one of the projects, or
+	// the project itself through the Norman API. Requests for cluster scoped resources are not allowed.
+	Projects []string `json:"projects,omitempty"`
+}
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -106,6 +129,64 @@ type Principals struct {
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// LoginLockout temporarily blocks the logins of a username or of a source IP after repeated failed logins. Failed
+// logins are counted in memory by each Rancher replica, lockouts are shared by all replicas. Deleting the LoginLockout
+// unlocks the logins.
+type LoginLockout struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object’s metadata. More info:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LoginLockoutSpec `json:"spec"`
+}
+
+type LoginLockoutSpec struct {
+	// Username is the lowercased username the logins are blocked for, empty if the lockout blocks a source IP.
+	Username string `json:"username,omitempty"`
+	// SourceIP is the address the logins are blocked from, empty if the lockout blocks a username.
+	SourceIP string `json:"sourceIP,omitempty"`
+	// Failures is the number of failed logins that caused the lockout.
+	Failures int `json:"failures,omitempty"`
+	// Count is the number of consecutive lockouts. The lockout duration doubles with each lockout.
+	Count int `json:"count,omitempty"`
+	// LockedUntil is the time the lockout expires.
+	LockedUntil metav1.Time `json:"lockedUntil,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SCIMGroup is a group of an auth provider provisioned by the identity provider through the SCIM API. Its members are
+// members of the group principal before they log in for the first time.
+// SCIMGroup will have a CRD (and controller) generated for it, but will not be exposed in the API.
+type SCIMGroup struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object’s metadata. More info:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SCIMGroupSpec `json:"spec"`
+}
+
+type SCIMGroupSpec struct {
+	// Provider is the name of the auth provider of the group.
+	Provider string `json:"provider"`
+	// DisplayName is the name of the group in the identity provider.
+	DisplayName string `json:"displayName,omitempty"`
+	// ExternalID is the ID of the group in the identity provider.
+	ExternalID string `json:"externalId,omitempty"`
+	// PrincipalID is the ID of the group principal, e.g. okta_group://engineering.
+	PrincipalID string `json:"principalId"`
+	// Members are the names of the Users that are members of the group.
+	Members []string `json:"members,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 type Group struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -158,6 +239,20 @@ type SetPasswordInput struct {
 	NewPassword string `json:"newPassword" norman:"type=string,required"`
 }
 
+// EnrollTOTPOutput is the secret of a pending TOTP enrollment of a local user and its recovery codes. The enrollment is
+// activated by the first valid code generated from the secret.
+type EnrollTOTPOutput struct {
+	Secret string `json:"secret"`
+	// URL is the otpauth URL of the secret, to be rendered as QR code for authenticator apps.
+	URL           string   `json:"url"`
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type VerifyTOTPInput struct {
+	// Code is a code generated from the TOTP secret of the user, or one of its recovery codes.
+	Code string `json:"code" norman:"type=string,required"`
+}
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -451,6 +546,44 @@ type OIDCConfig struct {
 	PrivateKey         string `json:"privateKey" norman:"type=password"`
 	RancherURL         string `json:"rancherUrl" norman:"required,notnullable"`
 	GroupSearchEnabled *bool  `json:"groupSearchEnabled"`
+
+	// ClaimMapping maps the claims of the issuer to principals.
+	ClaimMapping OIDCClaimMapping `json:"claimMapping,omitempty"`
+	// AdditionalIssuers are further issuers that users can log in with, side by side with the issuer of the config.
+	// No user prefix of an issuer may start with the user prefix of another.
+	AdditionalIssuers []OIDCIssuerConfig `json:"additionalIssuers,omitempty"`
+}
+
+// OIDCClaimMapping maps the claims of the user info of an OIDC issuer to principals. Claims are referenced by name,
+// nested claims by their path with dots, e.g. realm_access.roles. Alternatives separated by | are tried in order, e.g.
+// preferred_username|email.
+type OIDCClaimMapping struct {
+	// UsernameClaim is the claim of the login name of users, email by default.
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	// DisplayNameClaim is the claim of the display name of users, name by default.
+	DisplayNameClaim string `json:"displayNameClaim,omitempty"`
+	// GroupsClaim is the claim of the groups of users, a list or a single group. By default the groups are the
+	// segments of the full_group_path claim if it is set, and the groups claim otherwise.
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+	// UserPrefix is prepended to the subject of users in their principal IDs. It can't be changed once users of the
+	// provider exist.
+	UserPrefix string `json:"userPrefix,omitempty"`
+	// GroupPrefix is prepended to the names of groups in their principal IDs and display names.
+	GroupPrefix string `json:"groupPrefix,omitempty"`
+}
+
+// OIDCIssuerConfig is an OIDC issuer that users can log in with in addition to the issuer of an OIDCConfig. The user
+// and group prefixes of its claim mapping default to the name of the issuer followed by a colon, so the principals of
+// different issuers never collide.
+type OIDCIssuerConfig struct {
+	// Name identifies the issuer in logins, it must be unique within the config.
+	Name         string           `json:"name" norman:"required"`
+	ClientID     string           `json:"clientId" norman:"required"`
+	ClientSecret string           `json:"clientSecret,omitempty" norman:"type=password"`
+	Scopes       string           `json:"scope,omitempty" mapstructure:"scope"`
+	AuthEndpoint string           `json:"authEndpoint" norman:"required"`
+	Issuer       string           `json:"issuer" norman:"required"`
+	ClaimMapping OIDCClaimMapping `json:"claimMapping,omitempty"`
 }
 
 type OIDCTestOutput struct {
@@ -37,6 +37,9 @@ type BasicLogin struct {
 	GenericLogin `json:",inline"`
 	Username     string `json:"username" norman:"type=string,required"`
 	Password     string `json:"password" norman:"type=string,required"`
+	// TOTPCode is the second login step of local users with multi-factor authentication, a code generated from their
+	// TOTP secret or one of their recovery codes.
+	TOTPCode string `json:"totpCode,omitempty"`
 }
 
 // +genclient
@@ -185,11 +188,15 @@ type OIDCProvider struct {
 	AuthProvider      `json:",inline"`
 
 	RedirectURL string `json:"redirectUrl"`
+	// IssuerRedirectURLs are the redirect URLs of the additional issuers, by name.
+	IssuerRedirectURLs map[string]string `json:"issuerRedirectUrls,omitempty"`
 }
 
 type OIDCLogin struct {
 	GenericLogin `json:",inline"`
 	Code         string `json:"code" norman:"type=string,required"`
+	// Issuer is the name of the additional issuer the code is from, empty for the issuer of the config.
+	Issuer string `json:"issuer,omitempty"`
 }
 
 type KeyCloakOIDCProvider struct {
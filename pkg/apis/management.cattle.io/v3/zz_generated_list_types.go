@@ -60,6 +60,23 @@ func NewActiveDirectoryProvider(namespace, name string, obj ActiveDirectoryProvi
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// AuditPolicyList is a list of AuditPolicy resources
+type AuditPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AuditPolicy `json:"items"`
+}
+
+func NewAuditPolicy(namespace, name string, obj AuditPolicy) *AuditPolicy {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("AuditPolicy").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // AuthConfigList is a list of AuthConfig resources
 type AuthConfigList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -689,6 +706,23 @@ func NewLocalProvider(namespace, name string, obj LocalProvider) *LocalProvider
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// LoginLockoutList is a list of LoginLockout resources
+type LoginLockoutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []LoginLockout `json:"items"`
+}
+
+func NewLoginLockout(namespace, name string, obj LoginLockout) *LoginLockout {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("LoginLockout").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // ManagedChartList is a list of ManagedChart resources
 type ManagedChartList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -1165,6 +1199,23 @@ func NewRoleTemplate(namespace, name string, obj RoleTemplate) *RoleTemplate {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// SCIMGroupList is a list of SCIMGroup resources
+type SCIMGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SCIMGroup `json:"items"`
+}
+
+func NewSCIMGroup(namespace, name string, obj SCIMGroup) *SCIMGroup {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("SCIMGroup").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // SamlProviderList is a list of SamlProvider resources
 type SamlProviderList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -30,6 +30,7 @@
 var (
 	APIServiceResourceName                              = "apiservices"
 	ActiveDirectoryProviderResourceName                 = "activedirectoryproviders"
+	AuditPolicyResourceName                             = "auditpolicies"
 	AuthConfigResourceName                              = "authconfigs"
 	AuthProviderResourceName                            = "authproviders"
 	AuthTokenResourceName                               = "authtokens"
@@ -68,6 +69,7 @@
 	GroupMemberResourceName                             = "groupmembers"
 	KontainerDriverResourceName                         = "kontainerdrivers"
 	LocalProviderResourceName                           = "localproviders"
+	LoginLockoutResourceName                            = "loginlockouts"
 	ManagedChartResourceName                            = "managedcharts"
 	MonitorMetricResourceName                           = "monitormetrics"
 	MultiClusterAppResourceName                         = "multiclusterapps"
@@ -97,6 +99,7 @@
 	RkeK8sServiceOptionResourceName                     = "rkek8sserviceoptions"
 	RkeK8sSystemImageResourceName                       = "rkek8ssystemimages"
 	RoleTemplateResourceName                            = "roletemplates"
+	SCIMGroupResourceName                               = "scimgroups"
 	SamlProviderResourceName                            = "samlproviders"
 	SamlTokenResourceName                               = "samltokens"
 	SettingResourceName                                 = "settings"
@@ -133,6 +136,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&APIServiceList{},
 		&ActiveDirectoryProvider{},
 		&ActiveDirectoryProviderList{},
+		&AuditPolicy{},
+		&AuditPolicyList{},
 		&AuthConfig{},
 		&AuthConfigList{},
 		&AuthProvider{},
@@ -209,6 +214,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&KontainerDriverList{},
 		&LocalProvider{},
 		&LocalProviderList{},
+		&LoginLockout{},
+		&LoginLockoutList{},
 		&ManagedChart{},
 		&ManagedChartList{},
 		&MonitorMetric{},
@@ -267,6 +274,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&RkeK8sSystemImageList{},
 		&RoleTemplate{},
 		&RoleTemplateList{},
+		&SCIMGroup{},
+		&SCIMGroupList{},
 		&SamlProvider{},
 		&SamlProviderList{},
 		&SamlToken{},
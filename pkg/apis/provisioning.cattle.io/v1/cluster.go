@@ -39,6 +39,8 @@ type ClusterStatus struct {
 	AgentDeployed      bool                                `json:"agentDeployed,omitempty"`
 	ObservedGeneration int64                               `json:"observedGeneration"`
 	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
+	// ConfigValidation are the problems found validating the RKE config against the release of the Kubernetes version
+	ConfigValidation []rkev1.ConfigValidationResult `json:"configValidation,omitempty"`
 }
 
 type ImportedConfig struct {
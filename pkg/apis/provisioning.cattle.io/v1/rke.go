@@ -73,6 +73,7 @@ type RKEConfig struct {
 
 	ETCDSnapshotCreate   *rkev1.ETCDSnapshotCreate   `json:"etcdSnapshotCreate,omitempty"`
 	ETCDSnapshotRestore  *rkev1.ETCDSnapshotRestore  `json:"etcdSnapshotRestore,omitempty"`
+	ETCDSnapshotSource   *rkev1.ETCDSnapshotSource   `json:"etcdSnapshotSource,omitempty"`
 	RotateCertificates   *rkev1.RotateCertificates   `json:"rotateCertificates,omitempty"`
 	RotateEncryptionKeys *rkev1.RotateEncryptionKeys `json:"rotateEncryptionKeys,omitempty"`
 
@@ -152,6 +152,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = make([]genericcondition.GenericCondition, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConfigValidation != nil {
+		in, out := &in.ConfigValidation, &out.ConfigValidation
+		*out = make([]rkecattleiov1.ConfigValidationResult, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -195,6 +200,11 @@ func (in *RKEConfig) DeepCopyInto(out *RKEConfig) {
 		*out = new(rkecattleiov1.ETCDSnapshotRestore)
 		**out = **in
 	}
+	if in.ETCDSnapshotSource != nil {
+		in, out := &in.ETCDSnapshotSource, &out.ETCDSnapshotSource
+		*out = new(rkecattleiov1.ETCDSnapshotSource)
+		**out = **in
+	}
 	if in.RotateCertificates != nil {
 		in, out := &in.RotateCertificates, &out.RotateCertificates
 		*out = new(rkecattleiov1.RotateCertificates)
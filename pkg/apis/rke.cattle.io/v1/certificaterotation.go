@@ -1,6 +1,26 @@
 package v1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 type RotateCertificates struct {
 	Generation int64    `json:"generation,omitempty"`
 	Services   []string `json:"services,omitempty"`
 }
+
+type AutomaticCertificateRotation struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// ExpiresInDays rotates the certificates once a certificate of a node expires within the given number of days,
+	// defaults to the rotate-certs-if-expiring-in-days setting
+	ExpiresInDays int `json:"expiresInDays,omitempty"`
+}
+
+// CertificateExpiration is the certificate of a machine that expires first.
+type CertificateExpiration struct {
+	MachineName string      `json:"machineName,omitempty"`
+	Certificate string      `json:"certificate,omitempty"`
+	ExpiresAt   metav1.Time `json:"expiresAt,omitempty"`
+	// CheckedAt is the time the expiration of the certificates of the machine was collected
+	CheckedAt metav1.Time `json:"checkedAt,omitempty"`
+}
@@ -31,6 +31,43 @@ type RKEClusterSpecCommon struct {
 	ETCD                  *ETCD                  `json:"etcd,omitempty"`
 	// Increment to force all nodes to re-provision
 	ProvisionGeneration int `json:"provisionGeneration,omitempty"`
+	// MaintenanceWindows restricts changes that restart or drain nodes, including certificate and encryption key
+	// rotation, to the given windows. Changes that don't restart nodes are applied right away. If no windows are set,
+	// all changes are applied right away.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// StrictConfigValidation stops the planner from configuring machines while the validation of the config against
+	// the release of the Kubernetes version has errors
+	StrictConfigValidation bool `json:"strictConfigValidation,omitempty"`
+	// AutomaticCertificateRotation collects the expiration of the certificates of the nodes and rotates them before
+	// they expire
+	AutomaticCertificateRotation *AutomaticCertificateRotation `json:"automaticCertificateRotation,omitempty"`
+	// AutomaticEncryptionKeyRotation rotates the secrets encryption keys of the cluster periodically
+	AutomaticEncryptionKeyRotation *AutomaticEncryptionKeyRotation `json:"automaticEncryptionKeyRotation,omitempty"`
+}
+
+type ConfigValidationSeverity string
+
+const (
+	ConfigValidationSeverityWarning ConfigValidationSeverity = "Warning"
+	ConfigValidationSeverityError   ConfigValidationSeverity = "Error"
+)
+
+// ConfigValidationResult is a problem found validating the config of a cluster against the release of its Kubernetes
+// version.
+type ConfigValidationResult struct {
+	Severity ConfigValidationSeverity `json:"severity,omitempty"`
+	// Field is the path of the invalid field
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type MaintenanceWindow struct {
+	// Schedule is a standard cron expression for the start of the window, e.g. "0 2 * * 6" for Saturdays at 2am.
+	Schedule string `json:"schedule,omitempty"`
+	// Duration is how long the window stays open after it started, e.g. "4h".
+	Duration string `json:"duration,omitempty"`
+	// TimeZone is the IANA time zone the schedule is evaluated in, e.g. "Europe/Berlin". Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 type LocalClusterAuthEndpoint struct {
@@ -58,6 +95,40 @@ type ClusterUpgradeStrategy struct {
 	// How many workers should be upgraded at a time
 	WorkerConcurrency  string       `json:"workerConcurrency,omitempty"`
 	WorkerDrainOptions DrainOptions `json:"workerDrainOptions,omitempty"`
+	// WorkerRolloutStages upgrades the workers in ordered stages, each stage only starting once the workers of the
+	// previous stages are upgraded. Workers not selected by any stage are upgraded last with WorkerConcurrency.
+	WorkerRolloutStages []RolloutStage `json:"workerRolloutStages,omitempty"`
+
+	// UpgradeHealthPolicy rolls back Kubernetes version upgrades that don't become healthy
+	UpgradeHealthPolicy *UpgradeHealthPolicy `json:"upgradeHealthPolicy,omitempty"`
+}
+
+type RolloutStage struct {
+	// Name identifies the stage, it must be unique among the stages
+	Name string `json:"name"`
+	// MachineSelector selects the workers of the stage among the workers not selected by a previous stage, all of them
+	// are selected if empty
+	MachineSelector *metav1.LabelSelector `json:"machineSelector,omitempty"`
+	// Size limits the number of workers of the stage, as a number or a percentage of all workers. All selected workers
+	// are part of the stage if empty.
+	Size string `json:"size,omitempty"`
+	// Concurrency is how many workers of the stage should be upgraded at a time, defaults to WorkerConcurrency
+	Concurrency string `json:"concurrency,omitempty"`
+	// ApprovalAnnotation This annotation will need to be populated on the cluster with the value of the
+	// pendingApproval field of the worker rollout status before the planner will start upgrading the workers of
+	// the stage.
+	ApprovalAnnotation string `json:"approvalAnnotation,omitempty"`
+}
+
+type UpgradeHealthPolicy struct {
+	// ProbeFailureBudget is the number of failed probes of upgraded machines tolerated before the upgrade is rolled
+	// back. 0 disables the check.
+	ProbeFailureBudget int `json:"probeFailureBudget,omitempty"`
+	// TimeoutSeconds is the time an upgrade may take, from the first machine receiving the plan of the new version,
+	// before it is rolled back. 0 disables the timeout.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// RestoreSnapshot takes an etcd snapshot before the upgrade starts and restores it when the upgrade is rolled back.
+	RestoreSnapshot bool `json:"restoreSnapshot,omitempty"`
 }
 
 type DrainOptions struct {
@@ -27,6 +27,7 @@ type RKEControlPlaneSpec struct {
 	LocalClusterAuthEndpoint LocalClusterAuthEndpoint `json:"localClusterAuthEndpoint"`
 	ETCDSnapshotCreate       *ETCDSnapshotCreate      `json:"etcdSnapshotCreate,omitempty"`
 	ETCDSnapshotRestore      *ETCDSnapshotRestore     `json:"etcdSnapshotRestore,omitempty"`
+	ETCDSnapshotSource       *ETCDSnapshotSource      `json:"etcdSnapshotSource,omitempty"`
 	RotateCertificates       *RotateCertificates      `json:"rotateCertificates,omitempty"`
 	RotateEncryptionKeys     *RotateEncryptionKeys    `json:"rotateEncryptionKeys,omitempty"`
 	KubernetesVersion        string                   `json:"kubernetesVersion,omitempty"`
@@ -51,4 +52,102 @@ type RKEControlPlaneStatus struct {
 	ConfigGeneration              int64                               `json:"configGeneration,omitempty"`
 	Initialized                   bool                                `json:"initialized,omitempty"`
 	AgentConnected                bool                                `json:"agentConnected,omitempty"`
+	PlanPreview                   *PlanPreview                        `json:"planPreview,omitempty"`
+	Upgrade                       *UpgradeStatus                      `json:"upgrade,omitempty"`
+	UpgradeHistory                []UpgradeHistoryEntry               `json:"upgradeHistory,omitempty"`
+	WorkerRollout                 *WorkerRolloutStatus                `json:"workerRollout,omitempty"`
+	CertificateExpiration         []CertificateExpiration             `json:"certificateExpiration,omitempty"`
+	CertificateRotationTime       *metav1.Time                        `json:"certificateRotationTime,omitempty"`
+	EncryptionKeyRotationHistory  []EncryptionKeyRotationHistoryEntry `json:"encryptionKeyRotationHistory,omitempty"`
+}
+
+// WorkerRolloutStatus tracks a rollout of plan changes to the workers in the stages of the upgrade strategy.
+type WorkerRolloutStatus struct {
+	// ID identifies the rollout, so approvals of the stages of a previous rollout aren't reused
+	ID string `json:"id,omitempty"`
+	// Stage is the stage being rolled out
+	Stage string `json:"stage,omitempty"`
+	// PendingApproval is the value the approval annotation of the stage is waiting for, if any
+	PendingApproval string `json:"pendingApproval,omitempty"`
+}
+
+type UpgradePhase string
+
+const (
+	// UpgradePhaseSnapshot takes an etcd snapshot before any machine is upgraded.
+	UpgradePhaseSnapshot UpgradePhase = "Snapshot"
+	// UpgradePhaseSnapshotRestart restores the plans of the etcd machines after the snapshot was taken.
+	UpgradePhaseSnapshotRestart UpgradePhase = "SnapshotRestart"
+	// UpgradePhaseUpgrading upgrades the machines while watching the upgrade health policy.
+	UpgradePhaseUpgrading UpgradePhase = "Upgrading"
+	// UpgradePhaseRollingBack waits for the spec of the cluster to be reverted to the spec applied before the upgrade.
+	UpgradePhaseRollingBack UpgradePhase = "RollingBack"
+)
+
+type UpgradeResult string
+
+const (
+	UpgradeResultSucceeded  UpgradeResult = "Succeeded"
+	UpgradeResultRolledBack UpgradeResult = "RolledBack"
+	UpgradeResultCancelled  UpgradeResult = "Cancelled"
+	UpgradeResultSuperseded UpgradeResult = "Superseded"
+)
+
+// UpgradeStatus tracks a Kubernetes version upgrade of a control plane with an upgrade health policy.
+type UpgradeStatus struct {
+	FromVersion string       `json:"fromVersion,omitempty"`
+	ToVersion   string       `json:"toVersion,omitempty"`
+	Phase       UpgradePhase `json:"phase,omitempty"`
+	StartedAt   metav1.Time  `json:"startedAt,omitempty"`
+	// RolloutStartedAt is when the first machine received the plan of the new version. The timeout of the upgrade
+	// health policy runs from it, so time spent waiting for a maintenance window doesn't count.
+	RolloutStartedAt *metav1.Time `json:"rolloutStartedAt,omitempty"`
+	// SnapshotName is the name the etcd snapshot taken before the upgrade was saved with.
+	SnapshotName string `json:"snapshotName,omitempty"`
+	// RestoreSnapshot is the etcdsnapshot object restored to roll back the upgrade.
+	RestoreSnapshot string `json:"restoreSnapshot,omitempty"`
+	Message         string `json:"message,omitempty"`
+}
+
+type UpgradeHistoryEntry struct {
+	FromVersion     string        `json:"fromVersion,omitempty"`
+	ToVersion       string        `json:"toVersion,omitempty"`
+	StartedAt       metav1.Time   `json:"startedAt,omitempty"`
+	FinishedAt      metav1.Time   `json:"finishedAt,omitempty"`
+	Result          UpgradeResult `json:"result,omitempty"`
+	RestoreSnapshot string        `json:"restoreSnapshot,omitempty"`
+	Message         string        `json:"message,omitempty"`
+}
+
+type PlanChange string
+
+const (
+	// PlanChangeNone indicates the plan of a node is unchanged.
+	PlanChangeNone PlanChange = "none"
+	// PlanChangeMinor indicates the plan of a node is updated right away without restarting or draining the node.
+	PlanChangeMinor PlanChange = "minor"
+	// PlanChangeMajor indicates the plan of a node is updated within the concurrency of the upgrade strategy and the
+	// node is restarted, after being drained if draining is enabled.
+	PlanChangeMajor PlanChange = "major"
+)
+
+// PlanPreview is the outcome of a dry run of the planner, set while the control plane is annotated with
+// rke.cattle.io/dry-run=true. No plans are changed during a dry run.
+type PlanPreview struct {
+	// ObservedGeneration is the generation of the control plane the preview was computed for.
+	ObservedGeneration int64             `json:"observedGeneration"`
+	Nodes              []NodePlanPreview `json:"nodes,omitempty"`
+}
+
+// NodePlanPreview lists the differences between the plan last applied to a machine and the plan the planner would
+// assign to it.
+type NodePlanPreview struct {
+	MachineName                 string     `json:"machineName,omitempty"`
+	NodeName                    string     `json:"nodeName,omitempty"`
+	Change                      PlanChange `json:"change,omitempty"`
+	Drain                       bool       `json:"drain,omitempty"`
+	ChangedFiles                []string   `json:"changedFiles,omitempty"`
+	ChangedInstructions         []string   `json:"changedInstructions,omitempty"`
+	ChangedPeriodicInstructions []string   `json:"changedPeriodicInstructions,omitempty"`
+	ChangedProbes               []string   `json:"changedProbes,omitempty"`
 }
@@ -1,5 +1,9 @@
 package v1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 type RotateEncryptionKeysPhase string
 
 const (
@@ -16,3 +20,28 @@
 type RotateEncryptionKeys struct {
 	Generation int64 `json:"generation,omitempty"`
 }
+
+type AutomaticEncryptionKeyRotation struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// IntervalDays is the number of days after the last successful rotation at which the encryption keys are rotated
+	// again, defaults to 90
+	IntervalDays int `json:"intervalDays,omitempty"`
+}
+
+// EncryptionKeyRotationHistoryEntry records a rotation of the encryption keys of a cluster.
+type EncryptionKeyRotationHistoryEntry struct {
+	Generation int64       `json:"generation,omitempty"`
+	Leader     string      `json:"leader,omitempty"`
+	StartedAt  metav1.Time `json:"startedAt,omitempty"`
+	FinishedAt metav1.Time `json:"finishedAt,omitempty"`
+	// Phases are the phases the rotation went through, in order
+	Phases []EncryptionKeyRotationPhaseTransition `json:"phases,omitempty"`
+	// Result is either Done or Failed once the rotation finished
+	Result        RotateEncryptionKeysPhase `json:"result,omitempty"`
+	FailureReason string                    `json:"failureReason,omitempty"`
+}
+
+type EncryptionKeyRotationPhaseTransition struct {
+	Phase RotateEncryptionKeysPhase `json:"phase,omitempty"`
+	Time  metav1.Time               `json:"time,omitempty"`
+}
@@ -1,6 +1,9 @@
 package v1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 type ETCDSnapshotPhase string
 
@@ -38,6 +41,26 @@ type ETCDSnapshotRestore struct {
 	RestoreRKEConfig string `json:"restoreRKEConfig,omitempty"`
 }
 
+// ETCDSnapshotSource seeds a new cluster with the datastore of an etcd snapshot of another cluster, e.g. to build a
+// disaster recovery cluster or to rehearse an upgrade on a clone. The new cluster is provisioned with the server token
+// of the source cluster, which is required to restore its snapshots, and the snapshot is restored once the control
+// plane is initialized. The creator of the new cluster must be allowed to update the source cluster.
+type ETCDSnapshotSource struct {
+	// Name refers to the etcdsnapshot object of the source cluster, it has to be in the namespace of the new cluster.
+	Name string `json:"name,omitempty"`
+	// MachineName is the etcd machine of the new cluster the file of a local snapshot was copied to. S3 snapshots
+	// are restored from their S3 location.
+	MachineName string `json:"machineName,omitempty"`
+	// Set to either none (or empty string), all, or kubernetesVersion
+	RestoreRKEConfig string `json:"restoreRKEConfig,omitempty"`
+	// ServerURL is the Rancher server URL the cluster agent connects to instead of the server-url setting, e.g. the
+	// regional address of Rancher for a disaster recovery cluster.
+	ServerURL string `json:"serverURL,omitempty"`
+	// KeepSourceNodes keeps the nodes of the source cluster found in the restored datastore. By default they are
+	// deleted so only the nodes of the new cluster are registered.
+	KeepSourceNodes bool `json:"keepSourceNodes,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -66,7 +89,8 @@ type ETCDSnapshotFile struct {
 }
 
 type ETCDSnapshotStatus struct {
-	Missing bool `json:"missing"`
+	Missing    bool                                `json:"missing"`
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
 }
 
 type ETCD struct {
@@ -74,4 +98,40 @@ type ETCD struct {
 	SnapshotScheduleCron string          `json:"snapshotScheduleCron,omitempty"`
 	SnapshotRetention    int             `json:"snapshotRetention,omitempty"`
 	S3                   *ETCDSnapshotS3 `json:"s3,omitempty"`
+
+	// LocalSnapshotRetentionPolicy and S3SnapshotRetentionPolicy replace SnapshotRetention with tiered retention of
+	// local and S3 snapshots. Once either is set, snapshots are pruned by Rancher. The etcd nodes only prune snapshots
+	// beyond twice the number the policies can keep, or SnapshotRetention if that is more.
+	LocalSnapshotRetentionPolicy *ETCDSnapshotRetentionPolicy `json:"localSnapshotRetentionPolicy,omitempty"`
+	S3SnapshotRetentionPolicy    *ETCDSnapshotRetentionPolicy `json:"s3SnapshotRetentionPolicy,omitempty"`
+
+	// SnapshotVerification periodically restores the latest local snapshot of etcd nodes into a scratch etcd
+	// instance and records the result as the Verified condition of the snapshot.
+	SnapshotVerification *ETCDSnapshotVerification `json:"snapshotVerification,omitempty"`
+}
+
+type ETCDSnapshotVerification struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// IntervalSeconds is the time between two verifications, defaults to a day.
+	IntervalSeconds int `json:"intervalSeconds,omitempty"`
+	// MachineLabelSelector selects the etcd machines verifying their latest local snapshot, defaults to the init node.
+	MachineLabelSelector *metav1.LabelSelector `json:"machineLabelSelector,omitempty"`
+	// Image provides the etcd and etcdctl binaries for the scratch instance, defaults to the
+	// etcd-snapshot-verification-image setting.
+	Image string `json:"image,omitempty"`
+}
+
+// ETCDSnapshotRetentionPolicy keeps the most recent snapshot of each of the configured number of hours, days, weeks,
+// months and years, e.g. keep 24 hourly, 7 daily, 4 weekly and 12 monthly snapshots. All other snapshots are pruned.
+type ETCDSnapshotRetentionPolicy struct {
+	// Last is the number of most recent snapshots to keep regardless of when they were taken.
+	Last    int `json:"last,omitempty"`
+	Hourly  int `json:"hourly,omitempty"`
+	Daily   int `json:"daily,omitempty"`
+	Weekly  int `json:"weekly,omitempty"`
+	Monthly int `json:"monthly,omitempty"`
+	Yearly  int `json:"yearly,omitempty"`
+	// MaxAgeDays prunes snapshots older than the number of days even if they would be kept otherwise. The most
+	// recent snapshot is never pruned.
+	MaxAgeDays int `json:"maxAgeDays,omitempty"`
 }
@@ -0,0 +1,34 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ProvisioningEventSeverity string
+
+const (
+	ProvisioningEventSeverityInfo  ProvisioningEventSeverity = "Info"
+	ProvisioningEventSeverityError ProvisioningEventSeverity = "Error"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProvisioningEvent is a timestamped entry of the provisioning history of a cluster. Events are labeled with the name
+// of their cluster, machine and severity, and the oldest events of a cluster are removed once it has too many.
+type ProvisioningEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ProvisioningEventSpec `json:"spec"`
+}
+
+type ProvisioningEventSpec struct {
+	ClusterName string `json:"clusterName,omitempty" wrangler:"required"`
+	// MachineName is the name of the machine the event is about, it is empty for events about the whole cluster
+	MachineName string `json:"machineName,omitempty"`
+	// Phase is the provisioning condition of the cluster or the phase of the machine the event was recorded in
+	Phase     string                    `json:"phase,omitempty"`
+	Severity  ProvisioningEventSeverity `json:"severity,omitempty"`
+	Message   string                    `json:"message,omitempty"`
+	Timestamp metav1.MicroTime          `json:"timestamp,omitempty"`
+}
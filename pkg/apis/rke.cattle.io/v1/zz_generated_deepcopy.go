@@ -45,11 +45,73 @@ func (in *AuthConfig) DeepCopy() *AuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutomaticCertificateRotation) DeepCopyInto(out *AutomaticCertificateRotation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomaticCertificateRotation.
+func (in *AutomaticCertificateRotation) DeepCopy() *AutomaticCertificateRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(AutomaticCertificateRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutomaticEncryptionKeyRotation) DeepCopyInto(out *AutomaticEncryptionKeyRotation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomaticEncryptionKeyRotation.
+func (in *AutomaticEncryptionKeyRotation) DeepCopy() *AutomaticEncryptionKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(AutomaticEncryptionKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateExpiration) DeepCopyInto(out *CertificateExpiration) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+	in.CheckedAt.DeepCopyInto(&out.CheckedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateExpiration.
+func (in *CertificateExpiration) DeepCopy() *CertificateExpiration {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateExpiration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterUpgradeStrategy) DeepCopyInto(out *ClusterUpgradeStrategy) {
 	*out = *in
 	in.ControlPlaneDrainOptions.DeepCopyInto(&out.ControlPlaneDrainOptions)
 	in.WorkerDrainOptions.DeepCopyInto(&out.WorkerDrainOptions)
+	if in.WorkerRolloutStages != nil {
+		in, out := &in.WorkerRolloutStages, &out.WorkerRolloutStages
+		*out = make([]RolloutStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpgradeHealthPolicy != nil {
+		in, out := &in.UpgradeHealthPolicy, &out.UpgradeHealthPolicy
+		*out = new(UpgradeHealthPolicy)
+		**out = **in
+	}
 	return
 }
 
@@ -63,6 +125,22 @@ func (in *ClusterUpgradeStrategy) DeepCopy() *ClusterUpgradeStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigValidationResult) DeepCopyInto(out *ConfigValidationResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigValidationResult.
+func (in *ConfigValidationResult) DeepCopy() *ConfigValidationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigValidationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomMachine) DeepCopyInto(out *CustomMachine) {
 	*out = *in
@@ -221,6 +299,21 @@ func (in *ETCD) DeepCopyInto(out *ETCD) {
 		*out = new(ETCDSnapshotS3)
 		**out = **in
 	}
+	if in.LocalSnapshotRetentionPolicy != nil {
+		in, out := &in.LocalSnapshotRetentionPolicy, &out.LocalSnapshotRetentionPolicy
+		*out = new(ETCDSnapshotRetentionPolicy)
+		**out = **in
+	}
+	if in.S3SnapshotRetentionPolicy != nil {
+		in, out := &in.S3SnapshotRetentionPolicy, &out.S3SnapshotRetentionPolicy
+		*out = new(ETCDSnapshotRetentionPolicy)
+		**out = **in
+	}
+	if in.SnapshotVerification != nil {
+		in, out := &in.SnapshotVerification, &out.SnapshotVerification
+		*out = new(ETCDSnapshotVerification)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -241,7 +334,7 @@ func (in *ETCDSnapshot) DeepCopyInto(out *ETCDSnapshot) {
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
 	in.SnapshotFile.DeepCopyInto(&out.SnapshotFile)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -353,6 +446,22 @@ func (in *ETCDSnapshotRestore) DeepCopy() *ETCDSnapshotRestore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ETCDSnapshotRetentionPolicy) DeepCopyInto(out *ETCDSnapshotRetentionPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDSnapshotRetentionPolicy.
+func (in *ETCDSnapshotRetentionPolicy) DeepCopy() *ETCDSnapshotRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ETCDSnapshotRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ETCDSnapshotS3) DeepCopyInto(out *ETCDSnapshotS3) {
 	*out = *in
@@ -369,6 +478,22 @@ func (in *ETCDSnapshotS3) DeepCopy() *ETCDSnapshotS3 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ETCDSnapshotSource) DeepCopyInto(out *ETCDSnapshotSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDSnapshotSource.
+func (in *ETCDSnapshotSource) DeepCopy() *ETCDSnapshotSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ETCDSnapshotSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ETCDSnapshotSpec) DeepCopyInto(out *ETCDSnapshotSpec) {
 	*out = *in
@@ -388,6 +513,11 @@ func (in *ETCDSnapshotSpec) DeepCopy() *ETCDSnapshotSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ETCDSnapshotStatus) DeepCopyInto(out *ETCDSnapshotStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -401,6 +531,69 @@ func (in *ETCDSnapshotStatus) DeepCopy() *ETCDSnapshotStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ETCDSnapshotVerification) DeepCopyInto(out *ETCDSnapshotVerification) {
+	*out = *in
+	if in.MachineLabelSelector != nil {
+		in, out := &in.MachineLabelSelector, &out.MachineLabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDSnapshotVerification.
+func (in *ETCDSnapshotVerification) DeepCopy() *ETCDSnapshotVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ETCDSnapshotVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationHistoryEntry) DeepCopyInto(out *EncryptionKeyRotationHistoryEntry) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]EncryptionKeyRotationPhaseTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationHistoryEntry.
+func (in *EncryptionKeyRotationHistoryEntry) DeepCopy() *EncryptionKeyRotationHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeyRotationPhaseTransition) DeepCopyInto(out *EncryptionKeyRotationPhaseTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeyRotationPhaseTransition.
+func (in *EncryptionKeyRotationPhaseTransition) DeepCopy() *EncryptionKeyRotationPhaseTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeyRotationPhaseTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvVar) DeepCopyInto(out *EnvVar) {
 	*out = *in
@@ -443,6 +636,22 @@ func (in *LocalClusterAuthEndpoint) DeepCopy() *LocalClusterAuthEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mirror) DeepCopyInto(out *Mirror) {
 	*out = *in
@@ -471,6 +680,142 @@ func (in *Mirror) DeepCopy() *Mirror {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePlanPreview) DeepCopyInto(out *NodePlanPreview) {
+	*out = *in
+	if in.ChangedFiles != nil {
+		in, out := &in.ChangedFiles, &out.ChangedFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedInstructions != nil {
+		in, out := &in.ChangedInstructions, &out.ChangedInstructions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedPeriodicInstructions != nil {
+		in, out := &in.ChangedPeriodicInstructions, &out.ChangedPeriodicInstructions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedProbes != nil {
+		in, out := &in.ChangedProbes, &out.ChangedProbes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePlanPreview.
+func (in *NodePlanPreview) DeepCopy() *NodePlanPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePlanPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanPreview) DeepCopyInto(out *PlanPreview) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodePlanPreview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanPreview.
+func (in *PlanPreview) DeepCopy() *PlanPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisioningEvent) DeepCopyInto(out *ProvisioningEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningEvent.
+func (in *ProvisioningEvent) DeepCopy() *ProvisioningEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisioningEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProvisioningEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisioningEventList) DeepCopyInto(out *ProvisioningEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProvisioningEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningEventList.
+func (in *ProvisioningEventList) DeepCopy() *ProvisioningEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisioningEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProvisioningEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisioningEventSpec) DeepCopyInto(out *ProvisioningEventSpec) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningEventSpec.
+func (in *ProvisioningEventSpec) DeepCopy() *ProvisioningEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisioningEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RKEBootstrap) DeepCopyInto(out *RKEBootstrap) {
 	*out = *in
@@ -751,6 +1096,21 @@ func (in *RKEClusterSpecCommon) DeepCopyInto(out *RKEClusterSpecCommon) {
 		*out = new(ETCD)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutomaticCertificateRotation != nil {
+		in, out := &in.AutomaticCertificateRotation, &out.AutomaticCertificateRotation
+		*out = new(AutomaticCertificateRotation)
+		**out = **in
+	}
+	if in.AutomaticEncryptionKeyRotation != nil {
+		in, out := &in.AutomaticEncryptionKeyRotation, &out.AutomaticEncryptionKeyRotation
+		*out = new(AutomaticEncryptionKeyRotation)
+		**out = **in
+	}
 	return
 }
 
@@ -896,6 +1256,11 @@ func (in *RKEControlPlaneSpec) DeepCopyInto(out *RKEControlPlaneSpec) {
 		*out = new(ETCDSnapshotRestore)
 		**out = **in
 	}
+	if in.ETCDSnapshotSource != nil {
+		in, out := &in.ETCDSnapshotSource, &out.ETCDSnapshotSource
+		*out = new(ETCDSnapshotSource)
+		**out = **in
+	}
 	if in.RotateCertificates != nil {
 		in, out := &in.RotateCertificates, &out.RotateCertificates
 		*out = new(RotateCertificates)
@@ -947,6 +1312,46 @@ func (in *RKEControlPlaneStatus) DeepCopyInto(out *RKEControlPlaneStatus) {
 		*out = new(ETCDSnapshotCreate)
 		**out = **in
 	}
+	if in.PlanPreview != nil {
+		in, out := &in.PlanPreview, &out.PlanPreview
+		*out = new(PlanPreview)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeHistory != nil {
+		in, out := &in.UpgradeHistory, &out.UpgradeHistory
+		*out = make([]UpgradeHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkerRollout != nil {
+		in, out := &in.WorkerRollout, &out.WorkerRollout
+		*out = new(WorkerRolloutStatus)
+		**out = **in
+	}
+	if in.CertificateExpiration != nil {
+		in, out := &in.CertificateExpiration, &out.CertificateExpiration
+		*out = make([]CertificateExpiration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertificateRotationTime != nil {
+		in, out := &in.CertificateRotationTime, &out.CertificateRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EncryptionKeyRotationHistory != nil {
+		in, out := &in.EncryptionKeyRotationHistory, &out.EncryptionKeyRotationHistory
+		*out = make([]EncryptionKeyRotationHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1059,6 +1464,27 @@ func (in *RegistryConfig) DeepCopy() *RegistryConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStage) DeepCopyInto(out *RolloutStage) {
+	*out = *in
+	if in.MachineSelector != nil {
+		in, out := &in.MachineSelector, &out.MachineSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStage.
+func (in *RolloutStage) DeepCopy() *RolloutStage {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RotateCertificates) DeepCopyInto(out *RotateCertificates) {
 	*out = *in
@@ -1095,3 +1521,74 @@ func (in *RotateEncryptionKeys) DeepCopy() *RotateEncryptionKeys {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeHealthPolicy) DeepCopyInto(out *UpgradeHealthPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeHealthPolicy.
+func (in *UpgradeHealthPolicy) DeepCopy() *UpgradeHealthPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeHealthPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeHistoryEntry) DeepCopyInto(out *UpgradeHistoryEntry) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeHistoryEntry.
+func (in *UpgradeHistoryEntry) DeepCopy() *UpgradeHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.RolloutStartedAt != nil {
+		in, out := &in.RolloutStartedAt, &out.RolloutStartedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerRolloutStatus) DeepCopyInto(out *WorkerRolloutStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerRolloutStatus.
+func (in *WorkerRolloutStatus) DeepCopy() *WorkerRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -60,6 +60,23 @@ func NewETCDSnapshot(namespace, name string, obj ETCDSnapshot) *ETCDSnapshot {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// ProvisioningEventList is a list of ProvisioningEvent resources
+type ProvisioningEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ProvisioningEvent `json:"items"`
+}
+
+func NewProvisioningEvent(namespace, name string, obj ProvisioningEvent) *ProvisioningEvent {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ProvisioningEvent").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // RKEBootstrapList is a list of RKEBootstrap resources
 type RKEBootstrapList struct {
 	metav1.TypeMeta `json:",inline"`
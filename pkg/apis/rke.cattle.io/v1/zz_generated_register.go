@@ -30,6 +30,7 @@
 var (
 	CustomMachineResourceName        = "custommachines"
 	ETCDSnapshotResourceName         = "etcdsnapshots"
+	ProvisioningEventResourceName    = "provisioningevents"
 	RKEBootstrapResourceName         = "rkebootstraps"
 	RKEBootstrapTemplateResourceName = "rkebootstraptemplates"
 	RKEClusterResourceName           = "rkeclusters"
@@ -61,6 +62,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CustomMachineList{},
 		&ETCDSnapshot{},
 		&ETCDSnapshotList{},
+		&ProvisioningEvent{},
+		&ProvisioningEventList{},
 		&RKEBootstrap{},
 		&RKEBootstrapList{},
 		&RKEBootstrapTemplate{},
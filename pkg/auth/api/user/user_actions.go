@@ -10,15 +10,23 @@
 	"github.com/rancher/norman/parse"
 	"github.com/rancher/norman/types"
 	"github.com/rancher/rancher/pkg/auth/providerrefresh"
+	"github.com/rancher/rancher/pkg/auth/providers/common"
+	"github.com/rancher/rancher/pkg/auth/providers/local"
 	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/settings"
 	"golang.org/x/crypto/bcrypt"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func (h *Handler) UserFormatter(apiContext *types.APIContext, resource *types.RawResource) {
 	resource.AddAction(apiContext, "setpassword")
+	if canUpdate := h.userCanUpdate(apiContext); canUpdate {
+		resource.AddAction(apiContext, "enrolltotp")
+		resource.AddAction(apiContext, "resettotp")
+		resource.AddAction(apiContext, "unlocklogin")
+	}
 
 	if canRefresh := h.userCanRefresh(apiContext); canRefresh {
 		resource.AddAction(apiContext, "refreshauthprovideraccess")
@@ -27,15 +35,27 @@ func (h *Handler) UserFormatter(apiContext *types.APIContext, resource *types.Ra
 
 func (h *Handler) CollectionFormatter(apiContext *types.APIContext, collection *types.GenericCollection) {
 	collection.AddAction(apiContext, "changepassword")
+	collection.AddAction(apiContext, "enrolltotp")
+	collection.AddAction(apiContext, "activatetotp")
+	collection.AddAction(apiContext, "disabletotp")
 	if canRefresh := h.userCanRefresh(apiContext); canRefresh {
 		collection.AddAction(apiContext, "refreshauthprovideraccess")
 	}
 }
 
+// LoginUnlocker removes the lockouts of usernames after too many failed logins.
+type LoginUnlocker interface {
+	Unlock(username string) error
+}
+
 type Handler struct {
 	UserClient               v3.UserInterface
 	GlobalRoleBindingsClient v3.GlobalRoleBindingInterface
 	UserAuthRefresher        providerrefresh.UserAuthRefresher
+	MFA                      *local.MFA
+	LoginUnlocker            LoginUnlocker
+	UserAttributeLister      v3.UserAttributeLister
+	PasswordPolicy           *local.PasswordPolicy
 }
 
 func (h *Handler) Actions(actionName string, action *types.Action, apiContext *types.APIContext) error {
@@ -52,6 +72,18 @@ func (h *Handler) Actions(actionName string, action *types.Action, apiContext *t
 		if err := h.refreshAttributes(actionName, action, apiContext); err != nil {
 			return err
 		}
+	case "enrolltotp", "activatetotp", "disabletotp":
+		if err := h.totp(actionName, apiContext); err != nil {
+			return err
+		}
+	case "resettotp":
+		if err := h.resetTOTP(apiContext); err != nil {
+			return err
+		}
+	case "unlocklogin":
+		if err := h.unlockLogin(apiContext); err != nil {
+			return err
+		}
 	default:
 		return errors.Errorf("bad action %v", actionName)
 	}
@@ -103,19 +135,23 @@ func (h *Handler) changePassword(actionName string, action *types.Action, reques
 		return httperror.NewAPIError(httperror.InvalidBodyContent, "invalid current password")
 	}
 
+	if err := h.PasswordPolicy.Validate(user, newPass); err != nil {
+		return err
+	}
+
 	newPassHash, err := HashPasswordString(newPass)
 	if err != nil {
 		return err
 	}
 
-	user.Password = newPassHash
-	user.MustChangePassword = false
-	user, err = h.UserClient.Update(user)
-	if err != nil {
+	if err := h.PasswordPolicy.RecordPassword(user, newPassHash); err != nil {
 		return err
 	}
 
-	return nil
+	user.Password = newPassHash
+	user.MustChangePassword = false
+	_, err = h.UserClient.Update(user)
+	return err
 }
 
 func (h *Handler) setPassword(actionName string, action *types.Action, request *types.APIContext) error {
@@ -146,6 +182,14 @@ func (h *Handler) setPassword(actionName string, action *types.Action, request *
 		return httperror.NewAPIError(httperror.InvalidBodyContent, err.Error())
 	}
 
+	user, err := h.UserClient.Get(request.ID, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := h.PasswordPolicy.Validate(user, newPass); err != nil {
+		return err
+	}
+
 	userData[client.UserFieldPassword] = newPass
 	if err := hashPassword(userData); err != nil {
 		return err
@@ -153,6 +197,10 @@ func (h *Handler) setPassword(actionName string, action *types.Action, request *
 	userData[client.UserFieldMustChangePassword] = false
 	delete(userData, "me")
 
+	if err := h.PasswordPolicy.RecordPassword(user, userData[client.UserFieldPassword].(string)); err != nil {
+		return err
+	}
+
 	userData, err = store.Update(request, request.Schema, userData, request.ID)
 	if err != nil {
 		return err
@@ -183,6 +231,123 @@ func (h *Handler) userCanRefresh(request *types.APIContext) bool {
 	return request.AccessControl.CanDo(v3.UserGroupVersionKind.Group, v3.UserResource.Name, "create", request, nil, request.Schema) == nil
 }
 
+// totp handles the TOTP multi-factor authentication actions of the current user. Administrators issue enrollments
+// to other users with the enrolltotp action of their user, e.g. to admins that have to enroll before they can log in.
+func (h *Handler) totp(actionName string, request *types.APIContext) error {
+	userID := request.Request.Header.Get("Impersonate-User")
+	if userID == "" {
+		return errors.New("can't find user")
+	}
+	if request.ID != "" {
+		if actionName != "enrolltotp" {
+			return errors.Errorf("bad action %v", actionName)
+		}
+		if canUpdate := h.userCanUpdate(request); !canUpdate {
+			return httperror.NewAPIError(httperror.PermissionDenied, "can not issue a multi-factor authentication enrollment")
+		}
+		userID = request.ID
+	}
+	user, err := h.UserClient.Get(userID, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !isLocalUser(user) {
+		return httperror.NewAPIError(httperror.InvalidAction, "multi-factor authentication is only available to local users")
+	}
+
+	if actionName == "enrolltotp" {
+		enrollment, err := h.MFA.Enroll(user)
+		if err != nil {
+			return err
+		}
+		request.WriteResponse(http.StatusOK, map[string]interface{}{
+			"type":          client.EnrollTOTPOutputType,
+			"secret":        enrollment.Secret,
+			"url":           enrollment.URL,
+			"recoveryCodes": enrollment.RecoveryCodes,
+		})
+		return nil
+	}
+
+	actionInput, err := parse.ReadBody(request.Request)
+	if err != nil {
+		return err
+	}
+	code, ok := actionInput[client.VerifyTOTPInputFieldCode].(string)
+	if !ok || len(code) == 0 {
+		return httperror.NewAPIError(httperror.InvalidBodyContent, "must specify code")
+	}
+	if actionName == "activatetotp" {
+		err = h.MFA.Activate(user, code)
+	} else {
+		err = h.MFA.Disable(user, code)
+	}
+	if err != nil {
+		return err
+	}
+	request.WriteResponse(http.StatusOK, nil)
+	return nil
+}
+
+// resetTOTP removes the multi-factor authentication of a user that lost the authenticator and the recovery codes.
+func (h *Handler) resetTOTP(request *types.APIContext) error {
+	if canUpdate := h.userCanUpdate(request); !canUpdate {
+		return httperror.NewAPIError(httperror.PermissionDenied, "can not reset multi-factor authentication")
+	}
+	user, err := h.UserClient.Get(request.ID, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := h.MFA.Reset(user); err != nil {
+		return err
+	}
+	request.WriteResponse(http.StatusOK, nil)
+	return nil
+}
+
+// unlockLogin removes the lockouts of the usernames of a user after too many failed logins.
+func (h *Handler) unlockLogin(request *types.APIContext) error {
+	if canUpdate := h.userCanUpdate(request); !canUpdate {
+		return httperror.NewAPIError(httperror.PermissionDenied, "can not unlock logins")
+	}
+	user, err := h.UserClient.Get(request.ID, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// users of external auth providers log in with the usernames recorded in their attributes
+	usernames := []string{user.Username}
+	attribs, err := h.UserAttributeLister.Get("", user.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if attribs != nil {
+		for _, extra := range attribs.ExtraByProvider {
+			usernames = append(usernames, extra[common.UserAttributeUserName]...)
+		}
+	}
+	for _, username := range usernames {
+		if err := h.LoginUnlocker.Unlock(username); err != nil {
+			return err
+		}
+	}
+	request.WriteResponse(http.StatusOK, nil)
+	return nil
+}
+
+func (h *Handler) userCanUpdate(request *types.APIContext) bool {
+	return request.AccessControl.CanDo(v3.UserGroupVersionKind.Group, v3.UserResource.Name, "update", request, nil, request.Schema) == nil
+}
+
+func isLocalUser(user *v3.User) bool {
+	for _, principalID := range user.PrincipalIDs {
+		if strings.HasPrefix(principalID, local.Name+"://") {
+			return true
+		}
+	}
+	return false
+}
+
 // validatePassword will ensure a password is at least the minimum required length in runes,
 // that the username and password do not match, and that the new password is not the same as the current password.
 func validatePassword(user string, currentPass string, pass string, minPassLen int) error {
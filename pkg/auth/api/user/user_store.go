@@ -9,6 +9,7 @@
 	"github.com/rancher/norman/httperror"
 	"github.com/rancher/norman/store/transform"
 	"github.com/rancher/norman/types"
+	"github.com/rancher/rancher/pkg/auth/providers/local"
 	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/settings"
@@ -23,9 +24,10 @@
 
 type userStore struct {
 	types.Store
-	mu          sync.Mutex
-	userIndexer cache.Indexer
-	userManager user.Manager
+	mu             sync.Mutex
+	userIndexer    cache.Indexer
+	userManager    user.Manager
+	passwordPolicy *local.PasswordPolicy
 }
 
 func SetUserStore(schema *types.Schema, mgmt *config.ScaledContext) {
@@ -36,10 +38,11 @@ func SetUserStore(schema *types.Schema, mgmt *config.ScaledContext) {
 	userInformer.AddIndexers(userIndexers)
 
 	store := &userStore{
-		Store:       schema.Store,
-		mu:          sync.Mutex{},
-		userIndexer: userInformer.GetIndexer(),
-		userManager: mgmt.UserManager,
+		Store:          schema.Store,
+		mu:             sync.Mutex{},
+		userIndexer:    userInformer.GetIndexer(),
+		userManager:    mgmt.UserManager,
+		passwordPolicy: local.NewPasswordPolicy(mgmt),
 	}
 
 	t := &transform.Store{
@@ -121,6 +124,9 @@ func (s *userStore) Create(apiContext *types.APIContext, schema *types.Schema, d
 	if err := validatePassword(username, "", password, settings.PasswordMinLength.GetInt()); err != nil {
 		return nil, httperror.NewAPIError(httperror.InvalidBodyContent, err.Error())
 	}
+	if err := s.passwordPolicy.Validate(nil, password); err != nil {
+		return nil, err
+	}
 
 	if err := hashPassword(data); err != nil {
 		return nil, err
@@ -169,6 +175,12 @@ func (s *userStore) Create(apiContext *types.APIContext, schema *types.Schema, d
 		}
 	}
 
+	if id, ok := created[types.ResourceFieldID].(string); ok {
+		if err := s.passwordPolicy.Record(id); err != nil {
+			logrus.Warnf("error while recording the password of user %s: %v", id, err)
+		}
+	}
+
 	delete(created, client.UserFieldPassword)
 
 	return created, nil
@@ -13,6 +13,7 @@
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pborman/uuid"
@@ -44,6 +45,7 @@
 	LevelRequestResponse
 
 	generateKubeconfigURI = "action=generateKubeconfig"
+	enrollTOTPURI         = "action=enrolltotp"
 )
 
 var (
@@ -53,7 +55,7 @@
 	}
 	sensitiveRequestHeader  = []string{"Cookie", "Authorization", "X-Api-Tunnel-Params", "X-Api-Tunnel-Token", "X-Api-Auth-Header", "X-Amz-Security-Token"}
 	sensitiveResponseHeader = []string{"Cookie", "Set-Cookie", "X-Api-Set-Cookie-Header"}
-	sensitiveBodyFields     = []string{"credentials", "applicationSecret", "oauthCredential", "serviceAccountCredential", "spKey", "spCert", "certificate", "privateKey"}
+	sensitiveBodyFields     = []string{"credentials", "applicationSecret", "oauthCredential", "serviceAccountCredential", "spKey", "spCert", "certificate", "privateKey", "totpCode"}
 	// ErrUnsupportedEncoding is returned when the response encoding is unsupported
 	ErrUnsupportedEncoding = fmt.Errorf("unsupported encoding")
 	secretBaseType         = regexp.MustCompile(".\"baseType\":\"([A-Za-z]*[S|s]ecret)\".")
@@ -61,9 +63,13 @@
 
 type auditLog struct {
 	log               *log
+	annotations       *annotations
 	writer            *LogWriter
+	level             Level
 	reqBody           []byte
 	keysToRedactRegex *regexp.Regexp
+	// redactions are the custom redactions from AuditPolicies applying to the request.
+	redactions []*redaction
 }
 
 type log struct {
@@ -80,10 +86,44 @@ type log struct {
 	RequestBody       []byte       `json:"requestBody,omitempty"`
 	ResponseBody      []byte       `json:"responseBody,omitempty"`
 	UserLoginName     string       `json:"userLoginName,omitempty"`
+	// Annotations are added by the handlers serving the request, e.g. to record security events it caused.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 var userKey struct{}
 
+type annotationsKey struct{}
+
+// annotations collects the annotations added to the audit log entry of a request.
+type annotations struct {
+	sync.Mutex
+	values map[string]string
+}
+
+// AddAnnotation adds an annotation to the audit log entry of the request of ctx. It does nothing if the request isn't
+// audited.
+func AddAnnotation(ctx context.Context, key, value string) {
+	a, ok := ctx.Value(annotationsKey{}).(*annotations)
+	if !ok {
+		return
+	}
+	a.Lock()
+	defer a.Unlock()
+	if a.values == nil {
+		a.values = map[string]string{}
+	}
+	a.values[key] = value
+}
+
+func (a *annotations) get() map[string]string {
+	if a == nil {
+		return nil
+	}
+	a.Lock()
+	defer a.Unlock()
+	return a.values
+}
+
 // User holds information about the user who caused the audit log
 type User struct {
 	Name  string              `json:"name,omitempty"`
@@ -120,9 +160,10 @@ func FromContext(ctx context.Context) (*User, bool) {
 	return u, ok
 }
 
-func newAuditLog(writer *LogWriter, req *http.Request, keysToRedactRegex *regexp.Regexp) (*auditLog, error) {
+func newAuditLog(writer *LogWriter, level Level, req *http.Request, keysToRedactRegex *regexp.Regexp) (*auditLog, error) {
 	auditLog := &auditLog{
 		writer: writer,
+		level:  level,
 		log: &log{
 			AuditID:          k8stypes.UID(uuid.NewRandom().String()),
 			RequestURI:       req.RequestURI,
@@ -130,12 +171,14 @@ func newAuditLog(writer *LogWriter, req *http.Request, keysToRedactRegex *regexp
 			RemoteAddr:       req.RemoteAddr,
 			RequestTimestamp: time.Now().Format(time.RFC3339),
 		},
+		annotations:       &annotations{},
 		keysToRedactRegex: keysToRedactRegex,
+		redactions:        writer.redactionsFor(req),
 	}
 
 	contentType := req.Header.Get("Content-Type")
 	loginReq := isLoginRequest(req.RequestURI)
-	if writer.Level >= LevelRequest || loginReq {
+	if level >= LevelRequest || loginReq {
 		if bodyMethods[req.Method] && strings.HasPrefix(contentType, contentTypeJSON) {
 			reqBody, err := readBodyWithoutLosingContent(req)
 			if err != nil {
@@ -147,7 +190,7 @@ func newAuditLog(writer *LogWriter, req *http.Request, keysToRedactRegex *regexp
 					auditLog.log.UserLoginName = loginName
 				}
 			}
-			if writer.Level >= LevelRequest {
+			if level >= LevelRequest {
 				auditLog.reqBody = reqBody
 			}
 		}
@@ -158,9 +201,10 @@ func newAuditLog(writer *LogWriter, req *http.Request, keysToRedactRegex *regexp
 func (a *auditLog) write(userInfo *User, reqHeaders, resHeaders http.Header, resCode int, resBody []byte) error {
 	a.log.User = userInfo
 	a.log.ResponseTimestamp = time.Now().Format(time.RFC3339)
-	a.log.RequestHeader = filterOutHeaders(reqHeaders, sensitiveRequestHeader)
-	a.log.ResponseHeader = filterOutHeaders(resHeaders, sensitiveResponseHeader)
+	a.log.RequestHeader = a.filterOutHeaders(reqHeaders, sensitiveRequestHeader)
+	a.log.ResponseHeader = a.filterOutHeaders(resHeaders, sensitiveResponseHeader)
 	a.log.ResponseCode = resCode
+	a.log.Annotations = a.annotations.get()
 
 	if a.log.UserLoginName != "" {
 		if a.log.User.Extra == nil {
@@ -195,17 +239,12 @@ func (a *auditLog) write(userInfo *User, reqHeaders, resHeaders http.Header, res
 
 	compactBuffer.WriteString("\n")
 
-	_, err = a.writer.Output.Write(compactBuffer.Bytes())
-	if err != nil {
-		return fmt.Errorf("failed to write log to output: %w", err)
-	}
-
-	return nil
+	return a.writer.write(compactBuffer.Bytes())
 }
 
 // writeRequest attempts to write the API request to the log message.
 func (a *auditLog) writeRequest(buf *bytes.Buffer) {
-	if a.writer.Level < LevelRequest || len(a.reqBody) == 0 {
+	if a.level < LevelRequest || len(a.reqBody) == 0 {
 		return
 	}
 
@@ -215,7 +254,7 @@ func (a *auditLog) writeRequest(buf *bytes.Buffer) {
 
 // writeResponse attempt to write the API response to the log message.
 func (a *auditLog) writeResponse(buf *bytes.Buffer, resHeaders http.Header, resBody []byte) (err error) {
-	if a.writer.Level < LevelRequestResponse || resHeaders.Get("Content-Type") != contentTypeJSON || len(resBody) == 0 {
+	if a.level < LevelRequestResponse || resHeaders.Get("Content-Type") != contentTypeJSON || len(resBody) == 0 {
 		return nil
 	}
 
@@ -271,6 +310,17 @@ func filterOutHeaders(headers http.Header, filterKeys []string) map[string][]str
 	return newHeader
 }
 
+// filterOutHeaders removes the given headers as well as those redacted by custom redactions.
+func (a *auditLog) filterOutHeaders(headers http.Header, filterKeys []string) map[string][]string {
+	newHeader := filterOutHeaders(headers, filterKeys)
+	for _, r := range a.redactions {
+		for _, header := range r.headers {
+			delete(newHeader, header)
+		}
+	}
+	return newHeader
+}
+
 func isExist(array []string, key string) bool {
 	for _, v := range array {
 		if v == key {
@@ -298,6 +348,17 @@ func (a *auditLog) redactSensitiveData(requestURI string, body []byte) []byte {
 		changed = redact(m, "config")
 	}
 
+	if strings.Contains(requestURI, enrollTOTPURI) {
+		changed = redact(m, "secret") || changed
+		changed = redact(m, "url") || changed
+		changed = redact(m, "recoveryCodes") || changed
+	}
+
+	// Redact values selected by custom redactions.
+	for _, r := range a.redactions {
+		changed = r.apply(m) || changed
+	}
+
 	// Redact values for data considered sensitive: passwords, tokens, etc.
 	if !a.redactMap(m) && !changed {
 		return body
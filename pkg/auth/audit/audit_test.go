@@ -8,6 +8,7 @@
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"regexp"
@@ -16,6 +17,8 @@
 
 	"github.com/rancher/rancher/pkg/data/management"
 	"github.com/stretchr/testify/suite"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
 )
 
 var errAny = errors.New("any error is allowed")
@@ -242,7 +245,7 @@ func (a *AuditTest) TestCompression() {
 	req, err := http.NewRequest(http.MethodGet, "/test", nil)
 	a.Require().NoErrorf(err, "Failed to create request: %v", err)
 
-	auditLog, err := newAuditLog(writer, req, sensitiveRegex)
+	auditLog, err := newAuditLog(writer, writer.Level, req, sensitiveRegex)
 	a.Require().NoErrorf(err, "Failed to create AuditLog: %v", err)
 
 	const testString = "{\"test\":\"response\"}"
@@ -359,7 +362,7 @@ func (a *AuditTest) TestCompression() {
 	for i := range tests {
 		test := tests[i]
 		a.Run(test.name, func() {
-			writer.Level = test.level
+			auditLog.level = test.level
 			auditLog.reqBody = []byte(test.reqBody)
 			// write the test to the audit logger
 			err := auditLog.write(nil, req.Header, test.respHeader, test.returnCode, test.respBody)
@@ -408,7 +411,7 @@ func (a *AuditTest) TestFilterSensitiveHeader() {
 	req, err := http.NewRequest(http.MethodGet, "/test", nil)
 	a.Require().NoErrorf(err, "Failed to create request: %v", err)
 
-	auditLog, err := newAuditLog(writer, req, sensitiveRegex)
+	auditLog, err := newAuditLog(writer, writer.Level, req, sensitiveRegex)
 	a.Require().NoErrorf(err, "Failed to create AuditLog: %v", err)
 
 	tests := []struct {
@@ -477,11 +480,10 @@ func (a *AuditTest) TestFilterSensitiveHeader() {
 			expectedRespHeader: http.Header{"Content-Type": []string{"application/json"}, "Content-Encoding": []string{"none"}},
 		},
 	}
-	writer.Level = LevelMetadata
 	for i := range tests {
 		test := tests[i]
 		a.Run(test.name, func() {
-			writer.Level = 1
+			auditLog.level = LevelMetadata
 			// write the test to the audit logger
 			auditLog.log.RequestHeader = test.reqHeader
 			err := auditLog.write(nil, test.reqHeader, test.respHeader, 0, []byte{})
@@ -526,6 +528,32 @@ func (a *AuditTest) addMeta(log *log, reqHeader, respHeader http.Header, reqBody
 	return string(retJSON)
 }
 
+func (a *AuditTest) TestAnnotations() {
+	tmpPath := a.T().TempDir() + "/audit.log"
+	writer := NewLogWriter(tmpPath, LevelMetadata, 30, 30, 100)
+	a.Require().NotNil(writer, "Failed to create auditWriter.")
+
+	middleware, err := NewAuditLogMiddleware(writer)
+	a.Require().NoError(err)
+	handler := middleware(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		AddAnnotation(req.Context(), "auth.cattle.io/test", "value")
+		rw.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	req, err := http.NewRequest(http.MethodPost, "/v3-public/localProviders/local?action=login", nil)
+	a.Require().NoErrorf(err, "Failed to create request: %v", err)
+	req = req.WithContext(request.WithUser(req.Context(), &user.DefaultInfo{Name: "system:unauthenticated"}))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry log
+	a.Require().NoError(json.Unmarshal([]byte(a.drain(tmpPath)), &entry))
+	a.Equal(http.StatusUnauthorized, entry.ResponseCode)
+	a.Equal(map[string]string{"auth.cattle.io/test": "value"}, entry.Annotations)
+
+	// annotating a request that isn't audited does nothing
+	AddAnnotation(req.Context(), "auth.cattle.io/test", "value")
+}
+
 // read a file's content then truncate
 func (a *AuditTest) drain(tmpFile string) string {
 	data, err := os.ReadFile(tmpFile)
@@ -0,0 +1,485 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ChainKeyHMAC is the secret key holding a raw HMAC-SHA256 key used to sign checkpoints.
+	ChainKeyHMAC = "hmac-key"
+	// ChainKeyEd25519 is the secret key holding a PEM encoded PKCS #8 ed25519 private key used to sign checkpoints.
+	ChainKeyEd25519 = "ed25519-key"
+
+	algorithmHMAC    = "hmac-sha256"
+	algorithmEd25519 = "ed25519"
+
+	defaultCheckpointInterval = 1000
+	hmacKeyLength             = 32
+	// lastLineReadSize is how much of the end of a log file is read to find its last entry when resuming the chain.
+	lastLineReadSize = 1024 * 1024
+)
+
+// ChainConfig configures tamper evident audit logging. Every entry records the hash of the previous entry and a
+// checkpoint signed with a key held in a kubernetes secret is written every CheckpointInterval entries.
+type ChainConfig struct {
+	Enabled            bool
+	CheckpointInterval int
+	// KeySecret is the namespace/name of the secret holding the checkpoint signing key. An HMAC key is generated and
+	// stored in it if the secret does not exist.
+	KeySecret string
+}
+
+// chainLink is added to every entry of a hash chained log.
+type chainLink struct {
+	Seq      uint64 `json:"seq"`
+	PrevHash string `json:"prevHash"`
+}
+
+// checkpoint signs the position of the chain it is written at.
+type checkpoint struct {
+	Timestamp string `json:"timestamp"`
+	Algorithm string `json:"algorithm"`
+	Signature string `json:"signature"`
+}
+
+// chainBreak is written when the chain can't be continued from the existing log, starting a new chain. The verifier
+// reports it along with the reason.
+type chainBreak struct {
+	Reason string `json:"reason"`
+}
+
+// chainedEntry is the subset of an entry the verifier needs.
+type chainedEntry struct {
+	Chain      *chainLink  `json:"chain,omitempty"`
+	Checkpoint *checkpoint `json:"checkpoint,omitempty"`
+	ChainBreak *chainBreak `json:"chainBreak,omitempty"`
+}
+
+type signer interface {
+	algorithm() string
+	sign(message []byte) []byte
+	verify(message, signature []byte) bool
+}
+
+type hmacSigner struct {
+	key []byte
+}
+
+func (h *hmacSigner) algorithm() string { return algorithmHMAC }
+
+func (h *hmacSigner) sign(message []byte) []byte {
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+func (h *hmacSigner) verify(message, signature []byte) bool {
+	return hmac.Equal(h.sign(message), signature)
+}
+
+// ed25519Signer can verify with only a public key, so auditors don't need the signing key.
+type ed25519Signer struct {
+	private ed25519.PrivateKey
+	public  ed25519.PublicKey
+}
+
+func (e *ed25519Signer) algorithm() string { return algorithmEd25519 }
+
+func (e *ed25519Signer) sign(message []byte) []byte {
+	return ed25519.Sign(e.private, message)
+}
+
+func (e *ed25519Signer) verify(message, signature []byte) bool {
+	return ed25519.Verify(e.public, message, signature)
+}
+
+// hashChain links entries together. It is not safe for concurrent use, the LogWriter serializes writes.
+type hashChain struct {
+	signer             signer
+	checkpointInterval uint64
+	seq                uint64
+	prevHash           string
+	// breakReason is set when the chain couldn't be resumed, a chain break entry is written before the next entry.
+	breakReason string
+	// breakNewline is set when the log ends with an incomplete line the chain break entry must not be appended to.
+	breakNewline bool
+}
+
+// link adds the chain fields to an entry and advances the chain to it.
+func (c *hashChain) link(entry []byte) []byte {
+	c.seq++
+	line := bytes.TrimSuffix(bytes.TrimSuffix(entry, []byte("\n")), []byte("}"))
+	if len(line) > 1 {
+		line = append(line, ',')
+	}
+	line = append(line, fmt.Sprintf(`"chain":{"seq":%d,"prevHash":"%s"}}`, c.seq, c.prevHash)...)
+	c.prevHash = hashLine(line)
+	return append(line, '\n')
+}
+
+// checkpointDue returns true once CheckpointInterval entries have been written since the last checkpoint.
+func (c *hashChain) checkpointDue() bool {
+	return c.seq%c.checkpointInterval == 0
+}
+
+// checkpoint returns a signed checkpoint entry, itself part of the chain, so removing it breaks the chain.
+func (c *hashChain) checkpoint(now time.Time) []byte {
+	timestamp := now.Format(time.RFC3339)
+	signature := c.signer.sign(checkpointMessage(c.seq+1, c.prevHash, timestamp))
+	entry := fmt.Sprintf(`{"checkpoint":{"timestamp":"%s","algorithm":"%s","signature":"%s"}}`,
+		timestamp, c.signer.algorithm(), base64.StdEncoding.EncodeToString(signature))
+	return c.link([]byte(entry))
+}
+
+// resume continues the chain from the last entry written to the log at path or its most recent backup. If that entry
+// is incomplete, not valid JSON or not chained, a new chain is started with a chain break entry.
+func (c *hashChain) resume(path string) error {
+	files, err := logFiles(path)
+	if err != nil {
+		return err
+	}
+	for i := len(files) - 1; i >= 0; i-- {
+		line, complete, err := lastLine(files[i])
+		if err != nil {
+			return err
+		}
+		if len(line) == 0 {
+			continue
+		}
+		var entry chainedEntry
+		switch {
+		case !complete:
+			c.breakReason = fmt.Sprintf("last entry of %s is incomplete", filepath.Base(files[i]))
+			c.breakNewline = true
+		case json.Unmarshal(line, &entry) != nil:
+			c.breakReason = fmt.Sprintf("last entry of %s is not valid JSON", filepath.Base(files[i]))
+		case entry.Chain == nil:
+			c.breakReason = fmt.Sprintf("last entry of %s is not chained", filepath.Base(files[i]))
+		default:
+			c.seq = entry.Chain.Seq
+			c.prevHash = hashLine(line)
+		}
+		return nil
+	}
+	return nil
+}
+
+// breakEntry returns the chain break entry to write before the next entry if the chain couldn't be resumed, and
+// whether the log needs a newline before it.
+func (c *hashChain) breakEntry() ([]byte, bool) {
+	if c.breakReason == "" {
+		return nil, false
+	}
+	reason, _ := json.Marshal(c.breakReason)
+	entry := c.link([]byte(fmt.Sprintf(`{"chainBreak":{"reason":%s}}`, reason)))
+	newline := c.breakNewline
+	c.breakReason, c.breakNewline = "", false
+	return entry, newline
+}
+
+func checkpointMessage(seq uint64, prevHash, timestamp string) []byte {
+	return []byte(fmt.Sprintf("%d:%s:%s", seq, prevHash, timestamp))
+}
+
+func hashLine(line []byte) string {
+	sum := sha256.Sum256(line)
+	return hex.EncodeToString(sum[:])
+}
+
+// logFiles returns the rotated backups of the log at path, oldest first, followed by the log itself if it exists.
+func logFiles(path string) ([]string, error) {
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(path, ext) + "-"
+	backups, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil, err
+	}
+	// lumberjack names backups with a sortable timestamp.
+	sort.Strings(backups)
+
+	if _, err := os.Stat(path); err == nil {
+		backups = append(backups, path)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	return backups, nil
+}
+
+// lastLine returns the last line of a file without its newline, and whether the line is complete, i.e. ends with a
+// newline.
+func lastLine(path string) ([]byte, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, true, nil
+		}
+		return nil, false, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, false, err
+	}
+	offset := info.Size() - lastLineReadSize
+	if offset < 0 {
+		offset = 0
+	}
+	data, err := io.ReadAll(io.NewSectionReader(f, offset, info.Size()-offset))
+	if err != nil {
+		return nil, false, err
+	}
+
+	complete := bytes.HasSuffix(data, []byte("\n")) || len(data) == 0
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+		data = data[i+1:]
+	}
+	return data, complete, nil
+}
+
+// signerFromSecret builds a signer from the data of a checkpoint key secret. An ed25519 public key is accepted in
+// place of the private key, which is enough to verify checkpoints.
+func signerFromSecret(data map[string][]byte) (signer, error) {
+	if key, ok := data[ChainKeyEd25519]; ok {
+		return parseEd25519Key(key)
+	}
+	if key, ok := data[ChainKeyHMAC]; ok && len(key) > 0 {
+		return &hmacSigner{key: key}, nil
+	}
+	return nil, fmt.Errorf("secret must contain either %s or %s", ChainKeyHMAC, ChainKeyEd25519)
+}
+
+// parseEd25519Key parses a PEM encoded PKCS #8 private key or PKIX public key.
+func parseEd25519Key(data []byte) (*ed25519Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode ed25519 key PEM")
+	}
+
+	if block.Type == "PUBLIC KEY" {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ed25519 public key: %w", err)
+		}
+		public, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, errors.New("public key is not an ed25519 key")
+		}
+		return &ed25519Signer{public: public}, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ed25519 private key: %w", err)
+	}
+	private, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an ed25519 key")
+	}
+	return &ed25519Signer{private: private, public: private.Public().(ed25519.PublicKey)}, nil
+}
+
+// chainSigner loads the checkpoint signing key from its secret, generating an HMAC key if the secret doesn't exist.
+func chainSigner(secretRef string, secrets corecontrollers.SecretClient) (signer, error) {
+	namespace, name, ok := strings.Cut(secretRef, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid audit log checkpoint secret [%s], must be namespace/name", secretRef)
+	}
+
+	secret, err := secrets.Get(namespace, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		key := make([]byte, hmacKeyLength)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		secret, err = secrets.Create(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{ChainKeyHMAC: key},
+		})
+		if apierrors.IsAlreadyExists(err) {
+			secret, err = secrets.Get(namespace, name, metav1.GetOptions{})
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit log checkpoint secret: %w", err)
+	}
+
+	s, err := signerFromSecret(secret.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit log checkpoint secret [%s]: %w", secretRef, err)
+	}
+	if e, ok := s.(*ed25519Signer); ok && e.private == nil {
+		return nil, fmt.Errorf("audit log checkpoint secret [%s] must hold an ed25519 private key", secretRef)
+	}
+	return s, nil
+}
+
+// ChainError describes the first broken link found when verifying a hash chained log.
+type ChainError struct {
+	File   string
+	Line   int
+	Reason string
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Reason)
+}
+
+// ChainReport summarizes a verified hash chained log.
+type ChainReport struct {
+	Entries     uint64
+	Checkpoints uint64
+	// FirstSeq is the sequence number the chain starts at, entries before it have been rotated out of the log set.
+	FirstSeq uint64
+	// Unsigned is the number of entries written after the last checkpoint. Truncation of these can't be detected.
+	Unsigned uint64
+	// Breaks are the chain break entries found, where a new chain was started because the previous one couldn't be
+	// continued. Entries between the last chained entry and a break can't be verified.
+	Breaks []*ChainError
+}
+
+// verifyChain walks the log at path and its rotated backups, oldest first, and returns a *ChainError for the first
+// entry that doesn't link to its predecessor or carries an invalid checkpoint signature. Invalid and unchained entries
+// followed by a chain break entry are reported in the breaks of the report instead.
+func verifyChain(path string, s signer) (*ChainReport, error) {
+	files, err := logFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no audit logs found at %s", path)
+	}
+
+	report := &ChainReport{}
+	var (
+		prevHash string
+		seq      uint64
+		// pending is the first invalid or unchained entry since the last chained one, it's an error unless a chain
+		// break entry follows.
+		pending *ChainError
+	)
+	for _, file := range files {
+		err := forEachLine(file, func(lineNumber int, line []byte) error {
+			var entry chainedEntry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				if pending == nil {
+					pending = &ChainError{File: file, Line: lineNumber, Reason: fmt.Sprintf("entry is not valid JSON: %v", err)}
+				}
+				return nil
+			}
+			if entry.Chain == nil {
+				if report.Entries == 0 {
+					// Entries written before the chain was enabled.
+					return nil
+				}
+				if pending == nil {
+					pending = &ChainError{File: file, Line: lineNumber, Reason: "entry is missing its chain link"}
+				}
+				return nil
+			}
+
+			switch {
+			case entry.ChainBreak != nil:
+				report.Breaks = append(report.Breaks, &ChainError{File: file, Line: lineNumber, Reason: entry.ChainBreak.Reason})
+				pending = nil
+				if report.Entries == 0 {
+					report.FirstSeq = entry.Chain.Seq
+				}
+			case pending != nil:
+				return pending
+			case report.Entries == 0:
+				report.FirstSeq = entry.Chain.Seq
+			case entry.Chain.Seq != seq+1:
+				return &ChainError{File: file, Line: lineNumber, Reason: fmt.Sprintf("expected sequence %d, found %d", seq+1, entry.Chain.Seq)}
+			case entry.Chain.PrevHash != prevHash:
+				return &ChainError{File: file, Line: lineNumber, Reason: "previous hash does not match the preceding entry"}
+			}
+
+			if entry.Checkpoint != nil {
+				if err := verifyCheckpoint(entry, s); err != nil {
+					return &ChainError{File: file, Line: lineNumber, Reason: err.Error()}
+				}
+				report.Checkpoints++
+				report.Unsigned = 0
+			} else {
+				report.Unsigned++
+			}
+
+			report.Entries++
+			seq = entry.Chain.Seq
+			prevHash = hashLine(line)
+			return nil
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+	if pending != nil {
+		return report, pending
+	}
+	return report, nil
+}
+
+func verifyCheckpoint(entry chainedEntry, s signer) error {
+	if entry.Checkpoint.Algorithm != s.algorithm() {
+		return fmt.Errorf("checkpoint is signed with %s, verifying key is %s", entry.Checkpoint.Algorithm, s.algorithm())
+	}
+	signature, err := base64.StdEncoding.DecodeString(entry.Checkpoint.Signature)
+	if err != nil {
+		return fmt.Errorf("checkpoint signature is not valid base64: %w", err)
+	}
+	if !s.verify(checkpointMessage(entry.Chain.Seq, entry.Chain.PrevHash, entry.Checkpoint.Timestamp), signature) {
+		return errors.New("checkpoint signature is invalid")
+	}
+	return nil
+}
+
+func forEachLine(path string, f func(int, []byte) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if err := f(lineNumber, bytes.TrimSuffix(line, []byte("\n"))); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	lumberjack "gopkg.in/natefinch/lumberjack.v2"
+)
+
+func newChainedWriter(t *testing.T, path string, s signer) *LogWriter {
+	writer := &LogWriter{
+		Level:  LevelMetadata,
+		Output: &lumberjack.Logger{Filename: path},
+		chain:  &hashChain{signer: s, checkpointInterval: 3},
+	}
+	require.NoError(t, writer.chain.resume(path))
+	t.Cleanup(func() { writer.Output.Close() })
+	return writer
+}
+
+func writeEntries(t *testing.T, writer *LogWriter, from, to int) {
+	for i := from; i < to; i++ {
+		require.NoError(t, writer.write([]byte(fmt.Sprintf(`{"auditID":"%d"}`+"\n", i))))
+	}
+}
+
+func TestHashChain(t *testing.T) {
+	key := []byte("secret")
+	path := filepath.Join(t.TempDir(), "rancher-api-audit.log")
+
+	writer := newChainedWriter(t, path, &hmacSigner{key: key})
+	writeEntries(t, writer, 0, 5)
+	writer.Output.Close()
+
+	// A new writer continues the existing chain.
+	writer = newChainedWriter(t, path, &hmacSigner{key: key})
+	writeEntries(t, writer, 5, 8)
+
+	report, err := verifyChain(path, &hmacSigner{key: key})
+	require.NoError(t, err)
+	// 8 entries plus a checkpoint after every third chained line.
+	assert.Equal(t, &ChainReport{Entries: 11, Checkpoints: 3, FirstSeq: 1, Unsigned: 1}, report)
+
+	_, err = verifyChain(path, &hmacSigner{key: []byte("wrong")})
+	var chainErr *ChainError
+	require.True(t, errors.As(err, &chainErr), "expected a ChainError, got %v", err)
+	assert.Equal(t, 4, chainErr.Line)
+	assert.Equal(t, "checkpoint signature is invalid", chainErr.Reason)
+}
+
+func TestHashChainDetectsTampering(t *testing.T) {
+	key := []byte("secret")
+	tests := []struct {
+		name       string
+		tamper     func([][]byte) [][]byte
+		wantLine   int
+		wantReason string
+	}{
+		{
+			name: "edited entry",
+			tamper: func(lines [][]byte) [][]byte {
+				lines[1] = bytes.Replace(lines[1], []byte(`"auditID":"1"`), []byte(`"auditID":"x"`), 1)
+				return lines
+			},
+			wantLine:   3,
+			wantReason: "previous hash does not match the preceding entry",
+		},
+		{
+			name: "deleted entry",
+			tamper: func(lines [][]byte) [][]byte {
+				return append(lines[:1], lines[2:]...)
+			},
+			wantLine:   2,
+			wantReason: "expected sequence 2, found 3",
+		},
+		{
+			name: "deleted checkpoint",
+			tamper: func(lines [][]byte) [][]byte {
+				return append(lines[:3], lines[4:]...)
+			},
+			wantLine:   4,
+			wantReason: "expected sequence 4, found 5",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rancher-api-audit.log")
+			writer := newChainedWriter(t, path, &hmacSigner{key: key})
+			writeEntries(t, writer, 0, 5)
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			lines := test.tamper(bytes.SplitAfter(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")))
+			require.NoError(t, os.WriteFile(path, bytes.Join(lines, nil), 0600))
+
+			_, err = verifyChain(path, &hmacSigner{key: key})
+			var chainErr *ChainError
+			require.True(t, errors.As(err, &chainErr), "expected a ChainError, got %v", err)
+			assert.Equal(t, test.wantLine, chainErr.Line)
+			assert.Equal(t, test.wantReason, chainErr.Reason)
+		})
+	}
+}
+
+func TestHashChainBreak(t *testing.T) {
+	key := []byte("secret")
+	tests := []struct {
+		name       string
+		append     string
+		wantReason string
+	}{
+		{name: "incomplete entry", append: `{"auditID":"3"`, wantReason: "last entry of rancher-api-audit.log is incomplete"},
+		{name: "invalid entry", append: "garbage\n", wantReason: "last entry of rancher-api-audit.log is not valid JSON"},
+		{name: "unchained entry", append: `{"auditID":"3"}` + "\n", wantReason: "last entry of rancher-api-audit.log is not chained"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rancher-api-audit.log")
+			writer := newChainedWriter(t, path, &hmacSigner{key: key})
+			writeEntries(t, writer, 0, 3)
+			writer.Output.Close()
+
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+			require.NoError(t, err)
+			_, err = f.WriteString(test.append)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			// A new chain is started with an explicit chain break entry that the verifier reports.
+			writer = newChainedWriter(t, path, &hmacSigner{key: key})
+			writeEntries(t, writer, 4, 6)
+
+			report, err := verifyChain(path, &hmacSigner{key: key})
+			require.NoError(t, err)
+			assert.Equal(t, []*ChainError{{File: path, Line: 6, Reason: test.wantReason}}, report.Breaks)
+
+			// Without the chain break entry, the entry is a broken link.
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			lines := bytes.SplitAfter(data, []byte("\n"))
+			require.NoError(t, os.WriteFile(path, bytes.Join(append(lines[:5:5], lines[6:]...), nil), 0600))
+			_, err = verifyChain(path, &hmacSigner{key: key})
+			var chainErr *ChainError
+			require.True(t, errors.As(err, &chainErr), "expected a ChainError, got %v", err)
+			assert.Equal(t, 5, chainErr.Line)
+		})
+	}
+}
+
+func TestHashChainAcrossRotatedFiles(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+
+	s, err := signerFromSecret(map[string][]byte{ChainKeyEd25519: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rancher-api-audit.log")
+	writer := newChainedWriter(t, path, s)
+	writeEntries(t, writer, 0, 4)
+	require.NoError(t, writer.Output.Rotate())
+	writeEntries(t, writer, 4, 6)
+
+	files, err := logFiles(path)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	verifier, err := parseEd25519Key(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	require.NoError(t, err)
+	report, err := verifyChain(path, verifier)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), report.Entries)
+	assert.Equal(t, uint64(2), report.Checkpoints)
+
+	// Losing the oldest file is reported through FirstSeq rather than as a broken link.
+	require.NoError(t, os.Remove(files[0]))
+	report, err = verifyChain(path, verifier)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), report.FirstSeq)
+}
@@ -63,22 +63,29 @@ type auditHandler struct {
 }
 
 func (h auditHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
-	if h.auditWriter == nil {
+	if !h.auditWriter.enabled() {
 		h.next.ServeHTTP(rw, req)
 		return
 	}
 
 	user := getUserInfo(req)
 
-	context := context.WithValue(req.Context(), userKey, user)
-	req = req.WithContext(context)
+	req = req.WithContext(context.WithValue(req.Context(), userKey, user))
 
-	auditLog, err := newAuditLog(h.auditWriter, req, h.sanitizingRegex)
+	level := h.auditWriter.levelFor(user, req)
+	if level == LevelNull {
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	auditLog, err := newAuditLog(h.auditWriter, level, req, h.sanitizingRegex)
 	if err != nil {
 		util.ReturnHTTPError(rw, req, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	req = req.WithContext(context.WithValue(req.Context(), annotationsKey{}, auditLog.annotations))
+
 	wr := &wrapWriter{ResponseWriter: rw, auditWriter: h.auditWriter, statusCode: http.StatusOK}
 	h.next.ServeHTTP(wr, req)
 
@@ -2,37 +2,196 @@
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	lumberjack "gopkg.in/natefinch/lumberjack.v2"
 )
 
 type LogWriter struct {
-	Level  Level
+	Level Level
+	// Output is the local log file, nil if entries are only sent to the sinks.
 	Output *lumberjack.Logger
+
+	sinks      []*sinkQueue
+	policyLock sync.RWMutex
+	policy     *policy
+	// writeLock keeps entries in chain order when chain is set.
+	writeLock sync.Mutex
+	chain     *hashChain
 }
 
 func (l *LogWriter) Start(ctx context.Context) {
 	if l == nil {
 		return
 	}
+	for _, sink := range l.sinks {
+		go sink.run(ctx)
+	}
+	if l.Output == nil {
+		return
+	}
 	go func() {
 		<-ctx.Done()
 		l.Output.Close()
 	}()
 }
 
+// enabled returns true if entries are written to the local file or to at least one sink.
+func (l *LogWriter) enabled() bool {
+	return l != nil && (l.Output != nil || len(l.sinks) > 0)
+}
+
+// ConfigureSinks sets up the remote sinks every audit log entry is fanned out to after it is written to the local file.
+func (l *LogWriter) ConfigureSinks(config SinkConfig) error {
+	if l == nil {
+		return nil
+	}
+
+	sinks, err := newSinks(config)
+	if err != nil {
+		return err
+	}
+
+	for _, sink := range sinks {
+		queue, err := newSinkQueue(sink, config.SpoolDir, int64(config.SpoolMaxSize)*1024*1024)
+		if err != nil {
+			return fmt.Errorf("failed to configure audit log sink [%s]: %w", sink.Name(), err)
+		}
+		l.sinks = append(l.sinks, queue)
+	}
+	return nil
+}
+
+// ConfigureHashChain enables tamper evident logging, continuing the chain from the last entry in the existing log.
+func (l *LogWriter) ConfigureHashChain(config ChainConfig, secrets corecontrollers.SecretClient) error {
+	if l == nil || !config.Enabled {
+		return nil
+	}
+
+	s, err := chainSigner(config.KeySecret, secrets)
+	if err != nil {
+		return err
+	}
+
+	interval := config.CheckpointInterval
+	if interval <= 0 {
+		interval = defaultCheckpointInterval
+	}
+	chain := &hashChain{
+		signer:             s,
+		checkpointInterval: uint64(interval),
+	}
+	// without a local file there is nothing to resume from, the chain starts over on every restart.
+	if l.Output != nil {
+		if err := chain.resume(l.Output.Filename); err != nil {
+			return fmt.Errorf("failed to resume audit log hash chain: %w", err)
+		}
+	}
+	l.chain = chain
+	return nil
+}
+
+// write writes an entry to the log file and hands it to the sinks. Remote sinks receive the entry even if the local
+// file can't be written to.
+func (l *LogWriter) write(entry []byte) error {
+	if l.chain == nil {
+		err := l.writeOutput(entry)
+		l.fanOut(entry)
+		return err
+	}
+
+	l.writeLock.Lock()
+	defer l.writeLock.Unlock()
+
+	var err error
+	if brk, newline := l.chain.breakEntry(); brk != nil {
+		if newline {
+			err = l.writeOutput([]byte("\n"))
+		}
+		if brkErr := l.writeOutput(brk); err == nil {
+			err = brkErr
+		}
+		l.fanOut(brk)
+	}
+
+	entry = l.chain.link(entry)
+	if entryErr := l.writeOutput(entry); err == nil {
+		err = entryErr
+	}
+	l.fanOut(entry)
+	if l.chain.checkpointDue() {
+		checkpoint := l.chain.checkpoint(time.Now())
+		if cpErr := l.writeOutput(checkpoint); err == nil {
+			err = cpErr
+		}
+		l.fanOut(checkpoint)
+	}
+	return err
+}
+
+func (l *LogWriter) writeOutput(entry []byte) error {
+	if l.Output == nil {
+		return nil
+	}
+	if _, err := l.Output.Write(entry); err != nil {
+		return fmt.Errorf("failed to write log to output: %w", err)
+	}
+	return nil
+}
+
+func (l *LogWriter) setPolicy(p *policy) {
+	l.policyLock.Lock()
+	defer l.policyLock.Unlock()
+	l.policy = p
+}
+
+// levelFor returns the level a request is logged at, LevelNull if it should not be logged at all.
+func (l *LogWriter) levelFor(user *User, req *http.Request) Level {
+	l.policyLock.RLock()
+	p := l.policy
+	l.policyLock.RUnlock()
+
+	if level, ok := p.levelFor(user, req); ok {
+		return level
+	}
+	return l.Level
+}
+
+// redactionsFor returns the custom redactions applying to a request.
+func (l *LogWriter) redactionsFor(req *http.Request) []*redaction {
+	l.policyLock.RLock()
+	p := l.policy
+	l.policyLock.RUnlock()
+
+	return p.redactionsFor(req)
+}
+
+// fanOut hands an entry to every configured sink without blocking.
+func (l *LogWriter) fanOut(entry []byte) {
+	for _, sink := range l.sinks {
+		sink.enqueue(entry)
+	}
+}
+
+// NewLogWriter returns a writer for the given level, nil if auditing is disabled. Without a path entries are not written
+// to a local file, only to the sinks configured with ConfigureSinks.
 func NewLogWriter(path string, level Level, maxAge, maxBackup, maxSize int) *LogWriter {
-	if path == "" || level == LevelNull {
+	if level == LevelNull {
 		return nil
 	}
 
-	return &LogWriter{
-		Level: level,
-		Output: &lumberjack.Logger{
+	writer := &LogWriter{Level: level}
+	if path != "" {
+		writer.Output = &lumberjack.Logger{
 			Filename:   path,
 			MaxAge:     maxAge,
 			MaxBackups: maxBackup,
 			MaxSize:    maxSize,
-		},
+		}
 	}
+	return writer
 }
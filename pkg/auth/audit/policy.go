@@ -0,0 +1,157 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/requestinfo"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/strings/slices"
+)
+
+const policyHandlerName = "audit-policy"
+
+var auditLevels = map[v3.AuditLevel]Level{
+	v3.AuditLevelNone:            LevelNull,
+	v3.AuditLevelMetadata:        LevelMetadata,
+	v3.AuditLevelRequest:         LevelRequest,
+	v3.AuditLevelRequestResponse: LevelRequestResponse,
+}
+
+// policy is the compiled form of every AuditPolicy in the cluster.
+type policy struct {
+	rules      []v3.AuditPolicyRule
+	redactions []*redaction
+}
+
+// newPolicy orders the rules of the given policies by policy name so the first matching rule is deterministic.
+func newPolicy(policies []*v3.AuditPolicy) *policy {
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	p := &policy{}
+	for _, auditPolicy := range policies {
+		if auditPolicy.DeletionTimestamp != nil {
+			continue
+		}
+		p.rules = append(p.rules, auditPolicy.Spec.Rules...)
+		for i := range auditPolicy.Spec.Redactions {
+			r, err := newRedaction(&auditPolicy.Spec.Redactions[i])
+			if err != nil {
+				logrus.Errorf("auditLog: ignoring redaction %d of AuditPolicy [%s]: %v", i, auditPolicy.Name, err)
+				continue
+			}
+			p.redactions = append(p.redactions, r)
+		}
+	}
+	return p
+}
+
+// levelFor returns the level of the first rule matching the request. The second return value is false if no rule
+// matched, in which case the writer's level applies.
+func (p *policy) levelFor(user *User, req *http.Request) (Level, bool) {
+	if p == nil || len(p.rules) == 0 {
+		return LevelNull, false
+	}
+
+	info := requestinfo.Parse(req)
+	for _, rule := range p.rules {
+		if !ruleMatches(&rule, user, req.RequestURI, info) {
+			continue
+		}
+		level, ok := auditLevels[rule.Level]
+		if !ok {
+			// Unknown levels are rejected by the schema, fall back to the most verbose level rather than losing events.
+			level = LevelRequestResponse
+		}
+		return level, true
+	}
+	return LevelNull, false
+}
+
+// redactionsFor returns the redactions applying to the request.
+func (p *policy) redactionsFor(req *http.Request) []*redaction {
+	if p == nil || len(p.redactions) == 0 {
+		return nil
+	}
+
+	info := requestinfo.Parse(req)
+	var result []*redaction
+	for _, r := range p.redactions {
+		if r.appliesTo(info) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func ruleMatches(rule *v3.AuditPolicyRule, user *User, requestURI string, info requestinfo.Info) bool {
+	if len(rule.Users) > 0 && (user == nil || !slices.Contains(rule.Users, user.Name)) {
+		return false
+	}
+	if len(rule.UserGroups) > 0 && (user == nil || !containsAny(rule.UserGroups, user.Group)) {
+		return false
+	}
+	if len(rule.Verbs) > 0 && !containsFold(rule.Verbs, info.Verb) {
+		return false
+	}
+	if len(rule.RequestURIPrefixes) > 0 && !hasAnyPrefix(requestURI, rule.RequestURIPrefixes) {
+		return false
+	}
+	if len(rule.APIGroups) > 0 && !slices.Contains(rule.APIGroups, info.APIGroup) {
+		return false
+	}
+	if len(rule.Resources) > 0 && !requestinfo.ContainsResource(rule.Resources, info.Resource) {
+		return false
+	}
+	return true
+}
+
+// RegisterPolicyHandler keeps the writer's policy in sync with the AuditPolicies in the cluster.
+func RegisterPolicyHandler(ctx context.Context, writer *LogWriter, auditPolicies mgmtcontrollers.AuditPolicyController) {
+	if writer == nil {
+		return
+	}
+	cache := auditPolicies.Cache()
+	auditPolicies.OnChange(ctx, policyHandlerName, func(_ string, obj *v3.AuditPolicy) (*v3.AuditPolicy, error) {
+		policies, err := cache.List(labels.Everything())
+		if err != nil {
+			return obj, err
+		}
+		writer.setPolicy(newPolicy(policies))
+		return obj, nil
+	})
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, candidate := range candidates {
+		if slices.Contains(values, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
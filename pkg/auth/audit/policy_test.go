@@ -0,0 +1,109 @@
+package audit
+
+import (
+	"net/http"
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPolicyLevelFor(t *testing.T) {
+	p := newPolicy([]*v3.AuditPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-default"},
+			Spec: v3.AuditPolicySpec{Rules: []v3.AuditPolicyRule{
+				{Level: v3.AuditLevelMetadata, Verbs: []string{"create", "update", "delete"}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-secrets"},
+			Spec: v3.AuditPolicySpec{Rules: []v3.AuditPolicyRule{
+				{Level: v3.AuditLevelNone, Users: []string{"u-ui"}, Verbs: []string{"list", "watch"}},
+				{Level: v3.AuditLevelRequestResponse, APIGroups: []string{""}, Resources: []string{"secrets"}},
+				{Level: v3.AuditLevelRequest, UserGroups: []string{"github_org://1"}, RequestURIPrefixes: []string{"/v3/settings"}},
+			}},
+		},
+	})
+
+	tests := []struct {
+		name      string
+		user      *User
+		method    string
+		uri       string
+		wantLevel Level
+		wantMatch bool
+	}{
+		{
+			name:      "steve secret uses singular type",
+			user:      &User{Name: "u-abc12"},
+			method:    http.MethodGet,
+			uri:       "/v1/secret/default/my-secret",
+			wantLevel: LevelRequestResponse,
+			wantMatch: true,
+		},
+		{
+			name:      "proxied kubernetes secret",
+			user:      &User{Name: "u-abc12"},
+			method:    http.MethodGet,
+			uri:       "/k8s/clusters/c-abc12/api/v1/namespaces/default/secrets",
+			wantLevel: LevelRequestResponse,
+			wantMatch: true,
+		},
+		{
+			name:      "rules of earlier policies win",
+			user:      &User{Name: "u-ui"},
+			method:    http.MethodGet,
+			uri:       "/v1/secrets",
+			wantLevel: LevelNull,
+			wantMatch: true,
+		},
+		{
+			name:      "group and uri prefix",
+			user:      &User{Name: "u-abc12", Group: []string{"system:authenticated", "github_org://1"}},
+			method:    http.MethodGet,
+			uri:       "/v3/settings/server-url",
+			wantLevel: LevelRequest,
+			wantMatch: true,
+		},
+		{
+			name:      "falls through to later policy",
+			user:      &User{Name: "u-abc12"},
+			method:    http.MethodPut,
+			uri:       "/v3/settings/server-url",
+			wantLevel: LevelMetadata,
+			wantMatch: true,
+		},
+		{
+			name:   "no rule matches",
+			user:   &User{Name: "u-abc12"},
+			method: http.MethodGet,
+			uri:    "/v3/clusters",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, test.uri, nil)
+			assert.NoError(t, err)
+			req.RequestURI = test.uri
+
+			level, matched := p.levelFor(test.user, req)
+			assert.Equal(t, test.wantMatch, matched)
+			assert.Equal(t, test.wantLevel, level)
+		})
+	}
+}
+
+func TestLogWriterLevelFor(t *testing.T) {
+	writer := &LogWriter{Level: LevelMetadata}
+	req, err := http.NewRequest(http.MethodGet, "/v3/secrets", nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, LevelMetadata, writer.levelFor(&User{}, req))
+
+	writer.setPolicy(newPolicy([]*v3.AuditPolicy{{
+		Spec: v3.AuditPolicySpec{Rules: []v3.AuditPolicyRule{{Level: v3.AuditLevelRequestResponse, Resources: []string{"secrets"}}}},
+	}}))
+	assert.Equal(t, LevelRequestResponse, writer.levelFor(&User{}, req))
+}
@@ -0,0 +1,213 @@
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/requestinfo"
+)
+
+// redaction is the compiled form of an AuditRedactionRule.
+type redaction struct {
+	resources []string
+	headers   []string
+	paths     []jsonPath
+	keys      []*regexp.Regexp
+}
+
+func newRedaction(rule *v3.AuditRedactionRule) (*redaction, error) {
+	r := &redaction{resources: rule.Resources}
+	for _, header := range rule.Headers {
+		r.headers = append(r.headers, http.CanonicalHeaderKey(header))
+	}
+	for _, path := range rule.Paths {
+		compiled, err := parseJSONPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path [%s]: %w", path, err)
+		}
+		r.paths = append(r.paths, compiled)
+	}
+	for _, key := range rule.Keys {
+		compiled, err := regexp.Compile(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key pattern [%s]: %w", key, err)
+		}
+		r.keys = append(r.keys, compiled)
+	}
+	return r, nil
+}
+
+func (r *redaction) appliesTo(info requestinfo.Info) bool {
+	return len(r.resources) == 0 || requestinfo.ContainsResource(r.resources, info.Resource)
+}
+
+func (r *redaction) matchesKey(key string) bool {
+	for _, re := range r.keys {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply redacts the values selected by the rule's paths and keys and reports whether the body changed.
+func (r *redaction) apply(body map[string]interface{}) bool {
+	var changed bool
+	for _, path := range r.paths {
+		changed = path.redact(body) || changed
+	}
+	if len(r.keys) > 0 {
+		changed = r.redactKeys(body) || changed
+	}
+	return changed
+}
+
+func (r *redaction) redactKeys(value interface{}) bool {
+	var changed bool
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for key, v := range val {
+			if r.matchesKey(key) {
+				val[key] = redacted
+				changed = true
+				continue
+			}
+			changed = r.redactKeys(v) || changed
+		}
+	case []interface{}:
+		for _, v := range val {
+			changed = r.redactKeys(v) || changed
+		}
+	}
+	return changed
+}
+
+// jsonPath is a parsed JSONPath expression limited to the selectors needed to address values for redaction.
+type jsonPath []pathSegment
+
+type pathSegment struct {
+	// name is the child key, empty for wildcards and indexes.
+	name string
+	// index is the array index, -1 if the segment selects by name or wildcard.
+	index     int
+	wildcard  bool
+	recursive bool
+}
+
+func parseJSONPath(path string) (jsonPath, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	var segments jsonPath
+	for rest != "" {
+		seg := pathSegment{index: -1}
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			seg.recursive = true
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+		case strings.HasPrefix(rest, "["):
+		default:
+			return nil, fmt.Errorf("unexpected %q", rest)
+		}
+
+		if strings.HasPrefix(rest, "[") {
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated bracket in %q", rest)
+			}
+			selector := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case selector == "*":
+				seg.wildcard = true
+			case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0]:
+				seg.name = selector[1 : len(selector)-1]
+			default:
+				index, err := strconv.Atoi(selector)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("unsupported selector [%s]", selector)
+				}
+				seg.index = index
+			}
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			seg.name = rest[:end]
+			rest = rest[end:]
+			if seg.name == "*" {
+				seg.name = ""
+				seg.wildcard = true
+			} else if seg.name == "" {
+				return nil, fmt.Errorf("empty name in %q", path)
+			}
+		}
+		segments = append(segments, seg)
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("path selects the whole body")
+	}
+	return segments, nil
+}
+
+// redact replaces every value selected by the path and reports whether any value was found.
+func (p jsonPath) redact(value interface{}) bool {
+	if len(p) == 0 {
+		return false
+	}
+	seg, rest := p[0], p[1:]
+
+	var changed bool
+	if seg.recursive {
+		// Descendants are visited before the segment is applied here, so redacting a value never hides a deeper match.
+		for _, child := range children(value) {
+			changed = p.redact(child) || changed
+		}
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for key := range val {
+			if !seg.wildcard && (seg.index >= 0 || key != seg.name) {
+				continue
+			}
+			if len(rest) == 0 {
+				val[key] = redacted
+				changed = true
+				continue
+			}
+			changed = rest.redact(val[key]) || changed
+		}
+	case []interface{}:
+		for i := range val {
+			if !seg.wildcard && seg.index != i {
+				continue
+			}
+			if len(rest) == 0 {
+				val[i] = redacted
+				changed = true
+				continue
+			}
+			changed = rest.redact(val[i]) || changed
+		}
+	}
+	return changed
+}
+
+func children(value interface{}) []interface{} {
+	var result []interface{}
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for _, v := range val {
+			result = append(result, v)
+		}
+	case []interface{}:
+		result = val
+	}
+	return result
+}
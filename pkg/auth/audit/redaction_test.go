@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    jsonPath
+		wantErr bool
+	}{
+		{path: "$.amazonec2Config.secretKey", want: jsonPath{{name: "amazonec2Config", index: -1}, {name: "secretKey", index: -1}}},
+		{path: ".data[*]['api-key']", want: jsonPath{{name: "data", index: -1}, {wildcard: true, index: -1}, {name: "api-key", index: -1}}},
+		{path: "$..token", want: jsonPath{{name: "token", index: -1, recursive: true}}},
+		{path: "$.items[0].*", want: jsonPath{{name: "items", index: -1}, {index: 0}, {wildcard: true, index: -1}}},
+		{path: "$", wantErr: true},
+		{path: "$.a[", wantErr: true},
+		{path: "$.a[-1]", wantErr: true},
+		{path: "$.a..", wantErr: true},
+		{path: "a.b", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			got, err := parseJSONPath(test.path)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestRedactionApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  v3.AuditRedactionRule
+		input string
+		want  string
+	}{
+		{
+			name:  "path",
+			rule:  v3.AuditRedactionRule{Paths: []string{"$.exoscaleConfig.apiSecretKey"}},
+			input: `{"exoscaleConfig":{"apiKey":"key","apiSecretKey":"secret"},"apiSecretKey":"top"}`,
+			want:  `{"exoscaleConfig":{"apiKey":"key","apiSecretKey":"[redacted]"},"apiSecretKey":"top"}`,
+		},
+		{
+			name:  "wildcard and index",
+			rule:  v3.AuditRedactionRule{Paths: []string{"$.data[*].spec.apiKey", "$.data[0].id"}},
+			input: `{"data":[{"id":"a","spec":{"apiKey":"1"}},{"id":"b","spec":{"apiKey":"2"}}]}`,
+			want:  `{"data":[{"id":"[redacted]","spec":{"apiKey":"[redacted]"}},{"id":"b","spec":{"apiKey":"[redacted]"}}]}`,
+		},
+		{
+			name:  "recursive descent",
+			rule:  v3.AuditRedactionRule{Paths: []string{"$..apiKey"}},
+			input: `{"apiKey":{"apiKey":"1"},"list":[{"apiKey":"2"}]}`,
+			want:  `{"apiKey":"[redacted]","list":[{"apiKey":"[redacted]"}]}`,
+		},
+		{
+			name:  "keys",
+			rule:  v3.AuditRedactionRule{Keys: []string{"^(api|access)Key$"}},
+			input: `{"config":{"apiKey":"1","accessKey":{"id":"2"},"apiKeyId":"3"},"list":[{"apiKey":"4"}]}`,
+			want:  `{"config":{"apiKey":"[redacted]","accessKey":"[redacted]","apiKeyId":"3"},"list":[{"apiKey":"[redacted]"}]}`,
+		},
+		{
+			name:  "nothing selected",
+			rule:  v3.AuditRedactionRule{Paths: []string{"$.missing"}, Keys: []string{"missing"}},
+			input: `{"apiKey":"1"}`,
+			want:  `{"apiKey":"1"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := newRedaction(&test.rule)
+			require.NoError(t, err)
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(test.input), &body))
+			assert.Equal(t, test.input != test.want, r.apply(body))
+
+			got, err := json.Marshal(body)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.want, string(got))
+		})
+	}
+}
+
+func TestNewRedactionRejectsInvalidRules(t *testing.T) {
+	_, err := newRedaction(&v3.AuditRedactionRule{Keys: []string{"("}})
+	assert.Error(t, err)
+	_, err = newRedaction(&v3.AuditRedactionRule{Paths: []string{"$.a["}})
+	assert.Error(t, err)
+}
+
+func TestAuditLogCustomRedactions(t *testing.T) {
+	writer := &LogWriter{Level: LevelRequestResponse}
+	writer.setPolicy(newPolicy([]*v3.AuditPolicy{{
+		Spec: v3.AuditPolicySpec{Redactions: []v3.AuditRedactionRule{
+			{Resources: []string{"nodetemplates"}, Headers: []string{"x-api-key"}, Paths: []string{"$.linodeConfig.token"}},
+			{Resources: []string{"clusters"}, Keys: []string{"^region$"}},
+		}},
+	}}))
+
+	req, err := http.NewRequest(http.MethodPost, "/v3/nodetemplates", nil)
+	require.NoError(t, err)
+	req.RequestURI = "/v3/nodetemplates"
+	auditLog, err := newAuditLog(writer, LevelRequestResponse, req, nil)
+	require.NoError(t, err)
+	require.Len(t, auditLog.redactions, 1)
+
+	headers := auditLog.filterOutHeaders(http.Header{"X-Api-Key": {"1"}, "Accept": {"*/*"}, "Cookie": {"c"}}, sensitiveRequestHeader)
+	assert.Equal(t, map[string][]string{"Accept": {"*/*"}}, headers)
+
+	auditLog.keysToRedactRegex, err = constructKeyRedactRegex()
+	require.NoError(t, err)
+	body := auditLog.redactSensitiveData(req.RequestURI, []byte(`{"linodeConfig":{"token":{"value":"1"},"image":"debian"},"region":"eu"}`))
+	assert.JSONEq(t, `{"linodeConfig":{"token":"[redacted]","image":"debian"},"region":"eu"}`, string(body))
+}
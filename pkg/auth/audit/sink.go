@@ -0,0 +1,281 @@
+package audit
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultSinkQueueSize     = 1000
+	defaultSinkBatchSize     = 100
+	defaultSinkFlushInterval = time.Second
+	sinkRetryInitialBackoff  = time.Second
+	sinkRetryMaxBackoff      = time.Minute
+)
+
+// Sink is a destination audit log entries are delivered to in addition to the local log file.
+type Sink interface {
+	// Name uniquely identifies the sink. It is used in log messages and to name the sink's spool file.
+	Name() string
+	// Write delivers a batch of audit log entries, each a single line of JSON terminated by a newline.
+	// An error causes the whole batch to be retried.
+	Write(ctx context.Context, entries [][]byte) error
+	// Close releases any connections held by the sink.
+	Close() error
+}
+
+// SinkConfig holds the settings of the remote sinks audit log entries are fanned out to.
+type SinkConfig struct {
+	// SyslogAddress is the address of an RFC 5424 syslog receiver in the form tcp://host:port or tls://host:port.
+	SyslogAddress string
+	// WebhookURL is an HTTP(S) endpoint batches of entries are POSTed to as a JSON array.
+	WebhookURL string
+	// KafkaBrokers and KafkaTopic configure a Kafka-compatible sink. Brokers with an https:// or tls:// scheme use TLS.
+	KafkaBrokers []string
+	KafkaTopic   string
+	// CACertsFile is an optional PEM bundle used to verify TLS connections to sinks instead of the system roots.
+	CACertsFile string
+	// SpoolDir is the directory entries are spooled to when a sink falls behind.
+	SpoolDir string
+	// SpoolMaxSize is the maximum size in megabytes of each sink's spool.
+	SpoolMaxSize int
+}
+
+// sinkQueue decouples a Sink from the request path. Entries are queued in memory and overflow to a bounded on-disk
+// spool, so a slow or unavailable sink never blocks API requests. Entries are only dropped once the spool is full.
+type sinkQueue struct {
+	sink          Sink
+	entries       chan []byte
+	spool         *spool
+	batchSize     int
+	flushInterval time.Duration
+
+	// spoolLock serializes the decision between queueing in memory and spooling so that entries stay in order.
+	spoolLock sync.Mutex
+	errLock   sync.Mutex
+	lastErr   time.Time
+}
+
+func newSinkQueue(sink Sink, spoolDir string, spoolMaxSize int64) (*sinkQueue, error) {
+	if err := os.MkdirAll(spoolDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log spool directory: %w", err)
+	}
+	s, err := newSpool(filepath.Join(spoolDir, sink.Name()+".spool"), spoolMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &sinkQueue{
+		sink:          sink,
+		entries:       make(chan []byte, defaultSinkQueueSize),
+		spool:         s,
+		batchSize:     defaultSinkBatchSize,
+		flushInterval: defaultSinkFlushInterval,
+	}, nil
+}
+
+// enqueue hands an entry to the sink without blocking. Once anything has been spooled, later entries are spooled as
+// well until the spool has drained, which keeps delivery in order.
+func (q *sinkQueue) enqueue(entry []byte) {
+	q.spoolLock.Lock()
+	defer q.spoolLock.Unlock()
+
+	if q.spool.empty() {
+		select {
+		case q.entries <- entry:
+			return
+		default:
+		}
+	}
+
+	if err := q.spool.push(entry); err != nil {
+		q.warn("Dropping audit log entry for sink [%s]: %v", q.sink.Name(), err)
+	}
+}
+
+// run delivers queued and spooled entries until ctx is cancelled. Anything still in memory at that point is spooled
+// so it is delivered after a restart.
+func (q *sinkQueue) run(ctx context.Context) {
+	defer func() {
+		q.spoolLock.Lock()
+		defer q.spoolLock.Unlock()
+		q.drainToSpool()
+		if err := q.spool.close(); err != nil {
+			logrus.Warnf("Failed to close audit log spool for sink [%s]: %v", q.sink.Name(), err)
+		}
+		if err := q.sink.Close(); err != nil {
+			logrus.Warnf("Failed to close audit log sink [%s]: %v", q.sink.Name(), err)
+		}
+	}()
+
+	ticker := time.NewTicker(q.flushInterval)
+	defer ticker.Stop()
+
+	var batch [][]byte
+	for {
+		select {
+		case <-ctx.Done():
+			q.spoolBatch(batch)
+			return
+		case entry := <-q.entries:
+			batch = append(batch, entry)
+			if len(batch) < q.batchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		if len(batch) > 0 {
+			if !q.deliver(ctx, batch) {
+				q.spoolBatch(batch)
+				return
+			}
+			batch = nil
+		}
+
+		if len(q.entries) == 0 && !q.drainSpool(ctx) {
+			return
+		}
+	}
+}
+
+// drainSpool sends batches from the head of the spool until it is empty. Entries enqueued meanwhile are spooled as
+// well, so they are delivered in order. It stops early if the spool can't be read and returns false if ctx was
+// cancelled.
+func (q *sinkQueue) drainSpool(ctx context.Context) bool {
+	for !q.spool.empty() {
+		spooled, n, err := q.spool.peek(q.batchSize)
+		if err != nil {
+			q.warn("Failed to read audit log spool for sink [%s]: %v", q.sink.Name(), err)
+			return true
+		}
+		if len(spooled) == 0 {
+			return true
+		}
+		if !q.deliver(ctx, spooled) {
+			return false
+		}
+		if err := q.spool.commit(n); err != nil {
+			q.warn("Failed to commit audit log spool for sink [%s]: %v", q.sink.Name(), err)
+			return true
+		}
+	}
+	return true
+}
+
+// deliver writes a batch to the sink, retrying with exponential backoff until it succeeds. It returns false if ctx was
+// cancelled before the batch could be delivered.
+func (q *sinkQueue) deliver(ctx context.Context, batch [][]byte) bool {
+	backoff := sinkRetryInitialBackoff
+	for {
+		err := q.sink.Write(ctx, batch)
+		if err == nil {
+			return true
+		}
+		q.warn("Failed to write audit log entries to sink [%s], retrying in %s: %v", q.sink.Name(), backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > sinkRetryMaxBackoff {
+			backoff = sinkRetryMaxBackoff
+		}
+	}
+}
+
+// spoolBatch puts an undelivered batch, followed by everything still queued in memory, at the end of the spool.
+func (q *sinkQueue) spoolBatch(batch [][]byte) {
+	q.spoolLock.Lock()
+	defer q.spoolLock.Unlock()
+	for _, entry := range batch {
+		if err := q.spool.push(entry); err != nil {
+			q.warn("Dropping audit log entry for sink [%s]: %v", q.sink.Name(), err)
+		}
+	}
+	q.drainToSpool()
+}
+
+// drainToSpool must be called with spoolLock held.
+func (q *sinkQueue) drainToSpool() {
+	for {
+		select {
+		case entry := <-q.entries:
+			if err := q.spool.push(entry); err != nil {
+				q.warn("Dropping audit log entry for sink [%s]: %v", q.sink.Name(), err)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// warn logs at most once every errorDebounceTime per sink so an unavailable sink doesn't flood the rancher logs.
+func (q *sinkQueue) warn(format string, args ...interface{}) {
+	q.errLock.Lock()
+	defer q.errLock.Unlock()
+	if time.Since(q.lastErr) > errorDebounceTime {
+		logrus.Warnf(format, args...)
+		q.lastErr = time.Now()
+	}
+}
+
+// newSinks builds the sinks enabled in the given config.
+func newSinks(config SinkConfig) ([]Sink, error) {
+	tlsConfig, err := sinkTLSConfig(config.CACertsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var sinks []Sink
+	if config.SyslogAddress != "" {
+		sink, err := newSyslogSink(config.SyslogAddress, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if config.WebhookURL != "" {
+		sink, err := newWebhookSink(config.WebhookURL, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(config.KafkaBrokers) > 0 {
+		sink, err := newKafkaSink(config.KafkaBrokers, config.KafkaTopic, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+func sinkTLSConfig(caCertsFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caCertsFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(caCertsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log sink CA certificates: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no valid certificates found in audit log sink CA certificates file")
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
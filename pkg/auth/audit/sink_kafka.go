@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+const kafkaTimeout = 10 * time.Second
+
+// kafkaSink produces each entry as a message on a Kafka topic.
+type kafkaSink struct {
+	writer *kafka.Writer
+}
+
+func newKafkaSink(brokers []string, topic string, tlsConfig *tls.Config) (*kafkaSink, error) {
+	if topic == "" {
+		return nil, errors.New("a topic is required for the kafka audit log sink")
+	}
+
+	dialer := &kafka.Dialer{Timeout: kafkaTimeout}
+	hosts := make([]string, 0, len(brokers))
+	for _, broker := range brokers {
+		if !strings.Contains(broker, "://") {
+			hosts = append(hosts, broker)
+			continue
+		}
+		u, err := url.Parse(broker)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kafka broker [%s]: %w", broker, err)
+		}
+		if u.Scheme == "https" || u.Scheme == "tls" {
+			dialer.TLS = tlsConfig
+		}
+		hosts = append(hosts, u.Host)
+	}
+
+	return &kafkaSink{
+		writer: kafka.NewWriter(kafka.WriterConfig{
+			Brokers:      hosts,
+			Topic:        topic,
+			Dialer:       dialer,
+			MaxAttempts:  1,
+			BatchSize:    defaultSinkBatchSize,
+			WriteTimeout: kafkaTimeout,
+		}),
+	}, nil
+}
+
+func (k *kafkaSink) Name() string {
+	return "kafka"
+}
+
+func (k *kafkaSink) Write(ctx context.Context, entries [][]byte) error {
+	messages := make([]kafka.Message, 0, len(entries))
+	for _, entry := range entries {
+		messages = append(messages, kafka.Message{Value: bytes.TrimSuffix(entry, []byte("\n"))})
+	}
+	if err := k.writer.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("failed to produce audit log messages: %w", err)
+	}
+	return nil
+}
+
+func (k *kafkaSink) Close() error {
+	return k.writer.Close()
+}
@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// syslogPriority is facility 13 (log audit) with severity 6 (informational).
+	syslogPriority = 13*8 + 6
+	syslogAppName  = "rancher"
+	syslogMsgID    = "audit"
+	syslogTimeout  = 10 * time.Second
+)
+
+// syslogSink sends entries as RFC 5424 messages over TCP or TLS using the octet-counting framing of RFC 6587.
+type syslogSink struct {
+	address   string
+	tlsConfig *tls.Config
+	hostname  string
+	conn      net.Conn
+}
+
+func newSyslogSink(address string, tlsConfig *tls.Config) (*syslogSink, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog address [%s]: %w", address, err)
+	}
+
+	sink := &syslogSink{address: u.Host}
+	switch u.Scheme {
+	case "tcp":
+	case "tls":
+		sink.tlsConfig = tlsConfig.Clone()
+		if sink.tlsConfig.ServerName == "" {
+			sink.tlsConfig.ServerName = u.Hostname()
+		}
+	default:
+		return nil, fmt.Errorf("unsupported syslog scheme [%s], must be tcp or tls", u.Scheme)
+	}
+
+	sink.hostname, err = os.Hostname()
+	if err != nil || sink.hostname == "" {
+		sink.hostname = "-"
+	}
+	return sink, nil
+}
+
+func (s *syslogSink) Name() string {
+	return "syslog"
+}
+
+func (s *syslogSink) Write(ctx context.Context, entries [][]byte) error {
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		msg := s.format(entry, time.Now())
+		buf.WriteString(strconv.Itoa(len(msg)))
+		buf.WriteByte(' ')
+		buf.Write(msg)
+	}
+
+	s.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+	if _, err := s.conn.Write(buf.Bytes()); err != nil {
+		// The connection is in an unknown state, reconnect on the next attempt.
+		s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("failed to write to syslog: %w", err)
+	}
+	return nil
+}
+
+// format builds an RFC 5424 message with the audit log entry as its MSG part.
+func (s *syslogSink) format(entry []byte, now time.Time) []byte {
+	header := fmt.Sprintf("<%d>1 %s %s %s %d %s - ", syslogPriority, now.UTC().Format(time.RFC3339Nano), s.hostname, syslogAppName, os.Getpid(), syslogMsgID)
+	return append([]byte(header), bytes.TrimSuffix(entry, []byte("\n"))...)
+}
+
+func (s *syslogSink) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+	if s.tlsConfig == nil {
+		conn, err := dialer.DialContext(ctx, "tcp", s.address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		return conn, nil
+	}
+
+	tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+	conn, err := tlsDialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return conn, nil
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
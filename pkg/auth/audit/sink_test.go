@@ -0,0 +1,246 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSink struct {
+	lock    sync.Mutex
+	fail    bool
+	entries []string
+}
+
+func (f *fakeSink) Name() string { return "fake" }
+
+func (f *fakeSink) Write(_ context.Context, entries [][]byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.fail {
+		return errors.New("sink unavailable")
+	}
+	for _, entry := range entries {
+		f.entries = append(f.entries, strings.TrimSuffix(string(entry), "\n"))
+	}
+	return nil
+}
+
+func (f *fakeSink) Close() error { return nil }
+
+func (f *fakeSink) setFail(fail bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.fail = fail
+}
+
+func (f *fakeSink) received() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]string(nil), f.entries...)
+}
+
+func TestSpool(t *testing.T) {
+	s, err := newSpool(filepath.Join(t.TempDir(), "test.spool"), 16)
+	require.NoError(t, err)
+	defer s.close()
+
+	assert.True(t, s.empty())
+	require.NoError(t, s.push([]byte("one\n")))
+	require.NoError(t, s.push([]byte("two")))
+	require.NoError(t, s.push([]byte("three\n")))
+	assert.ErrorIs(t, s.push([]byte("four\n")), errSpoolFull)
+
+	entries, n, err := s.peek(2)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("one\n"), []byte("two\n")}, entries)
+	require.NoError(t, s.commit(n))
+	assert.False(t, s.empty())
+
+	entries, n, err = s.peek(10)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("three\n")}, entries)
+	require.NoError(t, s.commit(n))
+	assert.True(t, s.empty())
+
+	// A drained spool is truncated and accepts new entries up to its full size again.
+	require.NoError(t, s.push([]byte("fourteen bytes")))
+}
+
+func TestSpoolCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.spool")
+	s, err := newSpool(path, 16)
+	require.NoError(t, err)
+	defer s.close()
+
+	require.NoError(t, s.push([]byte("one\n")))
+	require.NoError(t, s.push([]byte("two\n")))
+	require.NoError(t, s.push([]byte("three\n")))
+	_, n, err := s.peek(2)
+	require.NoError(t, err)
+	require.NoError(t, s.commit(n))
+
+	// Entries that have been read don't count against the size of a partially drained spool.
+	require.NoError(t, s.push([]byte("four\n")))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(16))
+
+	entries, _, err := s.peek(10)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("three\n"), []byte("four\n")}, entries)
+}
+
+func TestSpoolResumesAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.spool")
+	s, err := newSpool(path, 1024)
+	require.NoError(t, err)
+	require.NoError(t, s.push([]byte("one\n")))
+	require.NoError(t, s.push([]byte("two\n")))
+	require.NoError(t, s.push([]byte("three\n")))
+	_, n, err := s.peek(1)
+	require.NoError(t, err)
+	require.NoError(t, s.commit(n))
+	require.NoError(t, s.close())
+
+	// Entries committed before the restart are not read again.
+	s, err = newSpool(path, 1024)
+	require.NoError(t, err)
+	entries, _, err := s.peek(10)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("two\n"), []byte("three\n")}, entries)
+	require.NoError(t, s.close())
+
+	// An offset that doesn't point to the start of an entry is ignored.
+	require.NoError(t, os.WriteFile(path+".offset", []byte("6\n"), 0600))
+	s, err = newSpool(path, 1024)
+	require.NoError(t, err)
+	defer s.close()
+	entries, _, err = s.peek(10)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("one\n"), []byte("two\n"), []byte("three\n")}, entries)
+}
+
+func TestSinkQueueSpoolsWhileSinkIsUnavailable(t *testing.T) {
+	sink := &fakeSink{fail: true}
+	queue, err := newSinkQueue(sink, t.TempDir(), 1024*1024)
+	require.NoError(t, err)
+	queue.entries = make(chan []byte, 2)
+	queue.batchSize = 1
+	// the spool is drained without waiting for further flushes
+	queue.flushInterval = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.run(ctx)
+
+	var want []string
+	for i := 0; i < 10; i++ {
+		entry := strconv.Itoa(i)
+		want = append(want, entry)
+		queue.enqueue([]byte(entry + "\n"))
+	}
+	assert.False(t, queue.spool.empty(), "entries that don't fit in memory should be spooled")
+
+	sink.setFail(false)
+	assert.Eventually(t, func() bool {
+		return len(sink.received()) == len(want)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, want, sink.received())
+	assert.True(t, queue.spool.empty())
+}
+
+func TestLogWriterWithoutLocalFile(t *testing.T) {
+	assert.Nil(t, NewLogWriter("", LevelNull, 30, 30, 100))
+
+	writer := NewLogWriter("", LevelMetadata, 30, 30, 100)
+	require.NotNil(t, writer)
+	assert.Nil(t, writer.Output)
+	assert.False(t, writer.enabled(), "no local file and no sinks")
+
+	queue, err := newSinkQueue(&fakeSink{}, t.TempDir(), 1024*1024)
+	require.NoError(t, err)
+	writer.sinks = append(writer.sinks, queue)
+	assert.True(t, writer.enabled())
+
+	require.NoError(t, writer.write([]byte("entry\n")))
+	assert.Equal(t, []byte("entry\n"), <-queue.entries)
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, contentTypeJSON, req.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&got))
+	}))
+	defer server.Close()
+
+	sink, err := newWebhookSink(server.URL, nil)
+	require.NoError(t, err)
+
+	err = sink.Write(context.Background(), [][]byte{[]byte(`{"auditID":"1"}` + "\n"), []byte(`{"auditID":"2"}` + "\n")})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"auditID": "1"}, {"auditID": "2"}}, got)
+
+	server.Config.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+	assert.Error(t, sink.Write(context.Background(), [][]byte{[]byte(`{}`)}))
+}
+
+func TestSyslogSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(length))
+		msg := make([]byte, n)
+		if _, err := io.ReadFull(reader, msg); err == nil {
+			received <- string(msg)
+		}
+	}()
+
+	sink, err := newSyslogSink("tcp://"+listener.Addr().String(), nil)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Write(context.Background(), [][]byte{[]byte(`{"auditID":"1"}` + "\n")}))
+
+	select {
+	case msg := <-received:
+		assert.True(t, strings.HasPrefix(msg, "<110>1 "), "unexpected header in %q", msg)
+		assert.True(t, strings.HasSuffix(msg, ` rancher `+strconv.Itoa(os.Getpid())+` audit - {"auditID":"1"}`), "unexpected message %q", msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("syslog message was not received")
+	}
+
+	_, err = newSyslogSink("udp://"+listener.Addr().String(), nil)
+	assert.Error(t, err)
+}
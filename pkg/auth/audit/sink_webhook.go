@@ -0,0 +1,79 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const webhookTimeout = 30 * time.Second
+
+// webhookSink POSTs batches of entries to an HTTP endpoint as a JSON array.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookSink(webhookURL string, tlsConfig *tls.Config) (*webhookSink, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit log webhook URL [%s]: %w", webhookURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported audit log webhook scheme [%s], must be http or https", u.Scheme)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &webhookSink{
+		url: webhookURL,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   webhookTimeout,
+		},
+	}, nil
+}
+
+func (w *webhookSink) Name() string {
+	return "webhook"
+}
+
+func (w *webhookSink) Write(ctx context.Context, entries [][]byte) error {
+	var body bytes.Buffer
+	body.WriteByte('[')
+	for i, entry := range entries {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		body.Write(bytes.TrimSuffix(entry, []byte("\n")))
+	}
+	body.WriteByte(']')
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentTypeJSON)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send audit log batch: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit log webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *webhookSink) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,205 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// spoolOffsetSize is the size of the saved offset of a spool, a zero padded decimal number followed by a newline.
+const spoolOffsetSize = 21
+
+// errSpoolFull is returned when an entry would grow the spool past its maximum size.
+var errSpoolFull = errors.New("audit log spool is full")
+
+// spool is a bounded on-disk queue of newline-delimited audit log entries. Entries that can not be handed to a sink
+// right away are appended to the spool and read back in order once the sink catches up. Only the entries that haven't
+// been read count against maxSize. Read entries are dropped from the spool file when it would otherwise grow past
+// maxSize, and the file is truncated once every entry in it has been read. The offset of the committed entries is
+// saved next to the spool file, so entries delivered before a restart aren't delivered again.
+type spool struct {
+	lock       sync.Mutex
+	file       *os.File
+	offsetFile *os.File
+	maxSize    int64
+	size       int64
+	readOffset int64
+}
+
+func newSpool(path string, maxSize int64) (*spool, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log spool: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat audit log spool: %w", err)
+	}
+
+	offsetFile, err := os.OpenFile(path+".offset", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open audit log spool offset: %w", err)
+	}
+
+	s := &spool{
+		file:       file,
+		offsetFile: offsetFile,
+		maxSize:    maxSize,
+		size:       info.Size(),
+	}
+	s.readOffset = s.loadOffset()
+	return s, nil
+}
+
+// loadOffset returns the saved offset of the committed entries. The whole spool is delivered again if the offset is
+// missing or doesn't point to the start of an entry, e.g. because rancher stopped while the spool was being compacted.
+func (s *spool) loadOffset() int64 {
+	data, err := io.ReadAll(io.NewSectionReader(s.offsetFile, 0, spoolOffsetSize))
+	if err != nil {
+		return 0
+	}
+	offset, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || offset <= 0 || offset > s.size {
+		return 0
+	}
+	previous := make([]byte, 1)
+	if _, err := s.file.ReadAt(previous, offset-1); err != nil || previous[0] != '\n' {
+		return 0
+	}
+	return offset
+}
+
+// saveOffset saves the offset of the committed entries. The offset is written with a fixed width so that it replaces
+// the previous one in place. The caller must hold the lock.
+func (s *spool) saveOffset() error {
+	if _, err := s.offsetFile.WriteAt([]byte(fmt.Sprintf("%0*d\n", spoolOffsetSize-1, s.readOffset)), 0); err != nil {
+		return fmt.Errorf("failed to save audit log spool offset: %w", err)
+	}
+	return nil
+}
+
+// push appends a single entry to the end of the spool.
+func (s *spool) push(entry []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !bytes.HasSuffix(entry, []byte("\n")) {
+		entry = append(entry, '\n')
+	}
+	if s.size-s.readOffset+int64(len(entry)) > s.maxSize {
+		return errSpoolFull
+	}
+	if s.size+int64(len(entry)) > s.maxSize {
+		if err := s.compact(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.WriteAt(entry, s.size)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write to audit log spool: %w", err)
+	}
+	return nil
+}
+
+// empty returns true if every entry written to the spool has been read and committed.
+func (s *spool) empty() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.readOffset >= s.size
+}
+
+// peek returns up to max entries from the head of the spool without removing them, along with the number of bytes they
+// occupy. The entries must be removed with commit once they have been delivered.
+func (s *spool) peek(max int) ([][]byte, int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	reader := bufio.NewReader(io.NewSectionReader(s.file, s.readOffset, s.size-s.readOffset))
+	var (
+		entries [][]byte
+		read    int64
+	)
+	for len(entries) < max {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, 0, fmt.Errorf("failed to read audit log spool: %w", err)
+		}
+		read += int64(len(line))
+		entries = append(entries, line)
+	}
+	return entries, read, nil
+}
+
+// commit removes n bytes worth of entries previously returned by peek. The spool file is truncated once it has been
+// fully drained.
+func (s *spool) commit(n int64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.readOffset += n
+	if s.readOffset < s.size {
+		return s.saveOffset()
+	}
+
+	s.readOffset, s.size = 0, 0
+	if err := s.file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate audit log spool: %w", err)
+	}
+	return s.saveOffset()
+}
+
+// compact moves the entries that haven't been read to the start of the spool file and truncates it after them. The
+// caller must hold the lock.
+func (s *spool) compact() error {
+	remaining := s.size - s.readOffset
+	// the entries are moved towards the start of the file, so copying them front to back never overwrites unread data
+	buf := make([]byte, 32*1024)
+	for copied := int64(0); copied < remaining; {
+		n, err := s.file.ReadAt(buf[:min64(int64(len(buf)), remaining-copied)], s.readOffset+copied)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to compact audit log spool: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("failed to compact audit log spool: %w", io.ErrUnexpectedEOF)
+		}
+		if _, err := s.file.WriteAt(buf[:n], copied); err != nil {
+			return fmt.Errorf("failed to compact audit log spool: %w", err)
+		}
+		copied += int64(n)
+	}
+	if err := s.file.Truncate(remaining); err != nil {
+		return fmt.Errorf("failed to compact audit log spool: %w", err)
+	}
+	s.readOffset, s.size = 0, remaining
+	return s.saveOffset()
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (s *spool) close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.offsetFile.Close(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/urfave/cli"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// RegisterVerifyCommand registers the audit-log-verify command, which checks the hash chain of an audit log set.
+func RegisterVerifyCommand() {
+	reexec.Register("/usr/bin/audit-log-verify", verifyCommand)
+	reexec.Register("audit-log-verify", verifyCommand)
+}
+
+func verifyCommand() {
+	app := cli.NewApp()
+	app.Description = "Verify the hash chain and checkpoint signatures of a Rancher API audit log and its rotated backups"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "path",
+			Value: "/var/log/auditlog/rancher-api-audit.log",
+			Usage: "Path of the current audit log, rotated backups next to it are verified first",
+		},
+		cli.StringFlag{
+			Name:  "hmac-key-file",
+			Usage: "File holding the HMAC key checkpoints were signed with",
+		},
+		cli.StringFlag{
+			Name:  "public-key-file",
+			Usage: "PEM file holding the ed25519 public key matching the checkpoint signing key",
+		},
+		cli.StringFlag{
+			Name:  "key-secret",
+			Usage: "namespace/name of the secret holding the checkpoint signing key, read using the current kubeconfig",
+		},
+	}
+
+	app.Action = func(c *cli.Context) error {
+		s, err := verifySigner(c)
+		if err != nil {
+			return err
+		}
+
+		report, err := verifyChain(c.String("path"), s)
+		var chainErr *ChainError
+		if errors.As(err, &chainErr) {
+			fmt.Fprintf(os.Stdout, "Verified %d entries before the first broken link.\n", report.Entries)
+			return fmt.Errorf("broken link at %w", chainErr)
+		} else if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stdout, "Verified %d entries and %d checkpoints starting at sequence %d.\n", report.Entries, report.Checkpoints, report.FirstSeq)
+		if report.FirstSeq > 1 {
+			fmt.Fprintf(os.Stdout, "Entries before sequence %d are no longer part of the log set.\n", report.FirstSeq)
+		}
+		for _, brk := range report.Breaks {
+			fmt.Fprintf(os.Stdout, "A new chain was started at %s:%d: %s.\n", brk.File, brk.Line, brk.Reason)
+		}
+		if report.Unsigned > 0 {
+			fmt.Fprintf(os.Stdout, "%d entries were written after the last checkpoint, their truncation can not be detected.\n", report.Unsigned)
+		}
+		return nil
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func verifySigner(c *cli.Context) (signer, error) {
+	switch {
+	case c.String("hmac-key-file") != "":
+		key, err := os.ReadFile(c.String("hmac-key-file"))
+		if err != nil {
+			return nil, err
+		}
+		return &hmacSigner{key: key}, nil
+	case c.String("public-key-file") != "":
+		key, err := os.ReadFile(c.String("public-key-file"))
+		if err != nil {
+			return nil, err
+		}
+		return parseEd25519Key(key)
+	case c.String("key-secret") != "":
+		namespace, name, ok := strings.Cut(c.String("key-secret"), "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid key secret [%s], must be namespace/name", c.String("key-secret"))
+		}
+		loader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+		conf, err := loader.ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get kubeconfig: %w", err)
+		}
+		client, err := kubernetes.NewForConfig(conf)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get kubernetes client: %w", err)
+		}
+		secret, err := client.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return signerFromSecret(secret.Data)
+	}
+	return nil, errors.New("one of --hmac-key-file, --public-key-file or --key-secret is required")
+}
@@ -0,0 +1,298 @@
+// Package lockout protects the logins with a username and password against brute-force attacks.
+//
+// Each Rancher replica counts the failed logins of a username and of a source IP in memory. After a few failed
+// logins, the next login has to wait for an exponentially growing backoff. Once a threshold is reached, the username
+// or source IP is locked out by a LoginLockout, which is shared by all replicas.
+package lockout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rancher/norman/httperror"
+	"github.com/rancher/rancher/pkg/auth/audit"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// freeFailures is the number of failed logins before the backoff starts, to allow for typos.
+	freeFailures = 3
+	// maxTrackedFailures bounds the memory used to count the failed logins of random usernames and addresses.
+	maxTrackedFailures = 10000
+	purgeInterval      = time.Hour
+
+	// AuditAnnotationLockout is the audit log annotation of login requests that caused or were rejected by a lockout
+	// or backoff. Its value is the name of the LoginLockout.
+	AuditAnnotationLockout = "auth.cattle.io/login-lockout"
+	// AuditAnnotationEvent is the audit log annotation of the event: EventLocked, EventRejected or EventBackoff.
+	AuditAnnotationEvent = "auth.cattle.io/login-lockout-event"
+
+	EventLocked   = "locked"
+	EventRejected = "rejected"
+	EventBackoff  = "backoff"
+)
+
+// TooManyFailedLogins is returned for logins of locked out usernames and source IPs.
+var TooManyFailedLogins = httperror.ErrorCode{Code: "TooManyFailedLogins", Status: http.StatusTooManyRequests}
+
+// key is a username or a source IP the failed logins are counted for.
+type key struct {
+	username string
+	sourceIP string
+}
+
+func (k key) String() string {
+	if k.username != "" {
+		return "username " + k.username
+	}
+	return "source IP " + k.sourceIP
+}
+
+// lockoutName returns the name of the LoginLockout of the key. Usernames and addresses aren't valid object names,
+// so they are hashed.
+func (k key) lockoutName() string {
+	prefix, value := "username-", k.username
+	if k.username == "" {
+		prefix, value = "sourceip-", k.sourceIP
+	}
+	sum := sha256.Sum256([]byte(value))
+	return prefix + hex.EncodeToString(sum[:16])
+}
+
+func (k key) threshold() int {
+	if k.username != "" {
+		return settings.AuthLoginLockoutUserThreshold.GetInt()
+	}
+	return settings.AuthLoginLockoutSourceIPThreshold.GetInt()
+}
+
+func keys(username, sourceIP string) []key {
+	var result []key
+	if username = normalizeUsername(username); username != "" {
+		result = append(result, key{username: username})
+	}
+	if sourceIP != "" {
+		result = append(result, key{sourceIP: sourceIP})
+	}
+	return result
+}
+
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+type failures struct {
+	count int
+	last  time.Time
+}
+
+// Limiter rejects the logins of usernames and source IPs with too many failed logins.
+type Limiter struct {
+	lockouts      v3.LoginLockoutInterface
+	lockoutLister v3.LoginLockoutLister
+
+	mu       sync.Mutex
+	failures *cache.LRUExpireCache
+	now      func() time.Time
+}
+
+// NewLimiter creates the Limiter using the clients defined in mgmt and starts purging expired lockouts.
+func NewLimiter(ctx context.Context, mgmt *config.ScaledContext) *Limiter {
+	l := &Limiter{
+		lockouts:      mgmt.Management.LoginLockouts(""),
+		lockoutLister: mgmt.Management.LoginLockouts("").Controller().Lister(),
+		failures:      cache.NewLRUExpireCache(maxTrackedFailures),
+		now:           time.Now,
+	}
+	go wait.Until(l.purge, purgeInterval, ctx.Done())
+	return l
+}
+
+// Check returns an error if the username or source IP is locked out, or has to wait for the backoff of its failed
+// logins.
+func (l *Limiter) Check(ctx context.Context, username, sourceIP string) error {
+	now := l.now()
+	for _, k := range keys(username, sourceIP) {
+		lockout, err := l.lockoutLister.Get("", k.lockoutName())
+		if err == nil && now.Before(lockout.Spec.LockedUntil.Time) {
+			audit.AddAnnotation(ctx, AuditAnnotationLockout, lockout.Name)
+			audit.AddAnnotation(ctx, AuditAnnotationEvent, EventRejected)
+			return httperror.NewAPIError(TooManyFailedLogins, "too many failed logins, try again later")
+		}
+
+		if until := l.backoffUntil(k); now.Before(until) {
+			audit.AddAnnotation(ctx, AuditAnnotationEvent, EventBackoff)
+			return httperror.NewAPIError(TooManyFailedLogins,
+				fmt.Sprintf("too many failed logins, try again in %v", until.Sub(now).Round(time.Second)))
+		}
+	}
+	return nil
+}
+
+// Failure records a failed login and locks out the username or source IP once its threshold is reached.
+func (l *Limiter) Failure(ctx context.Context, username, sourceIP string) {
+	for _, k := range keys(username, sourceIP) {
+		count := l.recordFailure(k)
+		if threshold := k.threshold(); threshold <= 0 || count < threshold {
+			continue
+		}
+		// the failed logins are counted again once the lockout expires
+		l.failures.Remove(k)
+		if err := l.lock(ctx, k, count); err != nil {
+			logrus.Errorf("[lockout] failed to lock out logins of %v: %v", k, err)
+		}
+	}
+}
+
+// Success forgets the failed logins of the username. The failed logins of the source IP are kept, an attacker could
+// reset them with their own account otherwise.
+func (l *Limiter) Success(username string) {
+	if username = normalizeUsername(username); username != "" {
+		l.failures.Remove(key{username: username})
+	}
+}
+
+func (l *Limiter) recordFailure(k key) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	f := failures{}
+	if value, ok := l.failures.Get(k); ok {
+		f = value.(failures)
+	}
+	f.count++
+	f.last = now
+	l.failures.Add(k, f, lockoutDuration(1))
+	return f.count
+}
+
+// backoffUntil returns the time before which the next login of the key is rejected.
+func (l *Limiter) backoffUntil(k key) time.Time {
+	value, ok := l.failures.Get(k)
+	if !ok {
+		return time.Time{}
+	}
+	f := value.(failures)
+	base := time.Duration(settings.AuthLoginBackoffBaseSeconds.GetInt()) * time.Second
+	if f.count <= freeFailures || base <= 0 {
+		return time.Time{}
+	}
+	return f.last.Add(double(base, f.count-freeFailures-1, lockoutDuration(1)))
+}
+
+func (l *Limiter) lock(ctx context.Context, k key, failures int) error {
+	name := k.lockoutName()
+	lockout, err := l.lockouts.Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lockout = &v3.LoginLockout{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+	} else if err != nil {
+		return err
+	}
+
+	lockout = lockout.DeepCopy()
+	lockout.Spec.Username = k.username
+	lockout.Spec.SourceIP = k.sourceIP
+	lockout.Spec.Failures = failures
+	lockout.Spec.Count++
+	lockout.Spec.LockedUntil = metav1.NewTime(l.now().Add(lockoutDuration(lockout.Spec.Count)))
+	if lockout.ResourceVersion == "" {
+		_, err = l.lockouts.Create(lockout)
+	} else {
+		_, err = l.lockouts.Update(lockout)
+	}
+	if err != nil {
+		return err
+	}
+
+	logrus.Warnf("[lockout] locked out logins of %v until %v after %d failed logins", k, lockout.Spec.LockedUntil.UTC().Format(time.RFC3339), failures)
+	audit.AddAnnotation(ctx, AuditAnnotationLockout, name)
+	audit.AddAnnotation(ctx, AuditAnnotationEvent, EventLocked)
+	return nil
+}
+
+// purge deletes the lockouts that expired longer than the maximum lockout duration ago, the next lockout of their
+// username or source IP starts with the initial duration again.
+func (l *Limiter) purge() {
+	lockouts, err := l.lockoutLister.List("", labels.Everything())
+	if err != nil {
+		logrus.Errorf("[lockout] failed to list login lockouts: %v", err)
+		return
+	}
+	maxDuration := time.Duration(settings.AuthLoginLockoutMaxMinutes.GetInt()) * time.Minute
+	for _, lockout := range lockouts {
+		if l.now().Before(lockout.Spec.LockedUntil.Add(maxDuration)) {
+			continue
+		}
+		if err := l.lockouts.Delete(lockout.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			logrus.Errorf("[lockout] failed to delete login lockout %s: %v", lockout.Name, err)
+		}
+	}
+}
+
+// Unlocker removes the lockouts of usernames.
+type Unlocker struct {
+	lockouts v3.LoginLockoutInterface
+}
+
+// NewUnlocker creates the Unlocker using the clients defined in mgmt.
+func NewUnlocker(mgmt *config.ScaledContext) *Unlocker {
+	return &Unlocker{
+		lockouts: mgmt.Management.LoginLockouts(""),
+	}
+}
+
+// Unlock deletes the lockout of the username.
+func (u *Unlocker) Unlock(username string) error {
+	username = normalizeUsername(username)
+	if username == "" {
+		return nil
+	}
+	err := u.lockouts.Delete(key{username: username}.lockoutName(), &metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// lockoutDuration returns the duration of the given consecutive lockout.
+func lockoutDuration(count int) time.Duration {
+	base := time.Duration(settings.AuthLoginLockoutMinutes.GetInt()) * time.Minute
+	maxDuration := time.Duration(settings.AuthLoginLockoutMaxMinutes.GetInt()) * time.Minute
+	if base <= 0 {
+		base = 15 * time.Minute
+	}
+	if maxDuration < base {
+		maxDuration = base
+	}
+	return double(base, count-1, maxDuration)
+}
+
+// double returns d doubled n times, but at most maxDuration.
+func double(d time.Duration, n int, maxDuration time.Duration) time.Duration {
+	for i := 0; i < n && d < maxDuration; i++ {
+		d *= 2
+	}
+	if d > maxDuration {
+		return maxDuration
+	}
+	return d
+}
@@ -0,0 +1,166 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rancher/norman/httperror"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3/fakes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+func newTestLimiter(now *time.Time) (*Limiter, map[string]*v3.LoginLockout) {
+	store := map[string]*v3.LoginLockout{}
+	notFound := func(name string) error {
+		return apierrors.NewNotFound(schema.GroupResource{Group: "management.cattle.io", Resource: "loginlockouts"}, name)
+	}
+	get := func(name string) (*v3.LoginLockout, error) {
+		if lockout, ok := store[name]; ok {
+			return lockout.DeepCopy(), nil
+		}
+		return nil, notFound(name)
+	}
+	save := func(lockout *v3.LoginLockout) (*v3.LoginLockout, error) {
+		lockout = lockout.DeepCopy()
+		lockout.ResourceVersion = "1"
+		store[lockout.Name] = lockout
+		return lockout, nil
+	}
+
+	l := &Limiter{
+		lockouts: &fakes.LoginLockoutInterfaceMock{
+			GetFunc: func(name string, opts metav1.GetOptions) (*v3.LoginLockout, error) {
+				return get(name)
+			},
+			CreateFunc: save,
+			UpdateFunc: save,
+			DeleteFunc: func(name string, options *metav1.DeleteOptions) error {
+				if _, ok := store[name]; !ok {
+					return notFound(name)
+				}
+				delete(store, name)
+				return nil
+			},
+		},
+		lockoutLister: &fakes.LoginLockoutListerMock{
+			GetFunc: func(namespace string, name string) (*v3.LoginLockout, error) {
+				return get(name)
+			},
+			ListFunc: func(namespace string, selector labels.Selector) ([]*v3.LoginLockout, error) {
+				var result []*v3.LoginLockout
+				for _, lockout := range store {
+					result = append(result, lockout)
+				}
+				return result, nil
+			},
+		},
+		failures: cache.NewLRUExpireCache(maxTrackedFailures),
+		now: func() time.Time {
+			return *now
+		},
+	}
+	return l, store
+}
+
+func assertTooManyFailedLogins(t *testing.T, err error) {
+	t.Helper()
+	require.Error(t, err)
+	apiError, ok := err.(*httperror.APIError)
+	require.True(t, ok)
+	assert.Equal(t, TooManyFailedLogins, apiError.Code)
+}
+
+func TestBackoff(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	l, _ := newTestLimiter(&now)
+
+	for i := 0; i < freeFailures; i++ {
+		require.NoError(t, l.Check(ctx, "admin", ""))
+		l.Failure(ctx, "admin", "")
+	}
+	require.NoError(t, l.Check(ctx, "admin", ""))
+
+	// the backoff doubles with each further failed login
+	l.Failure(ctx, "admin", "")
+	assertTooManyFailedLogins(t, l.Check(ctx, "ADMIN ", ""))
+	now = now.Add(time.Second)
+	require.NoError(t, l.Check(ctx, "admin", ""))
+	l.Failure(ctx, "admin", "")
+	now = now.Add(time.Second)
+	assertTooManyFailedLogins(t, l.Check(ctx, "admin", ""))
+	now = now.Add(time.Second)
+	require.NoError(t, l.Check(ctx, "admin", ""))
+
+	// other usernames aren't affected
+	require.NoError(t, l.Check(ctx, "other", ""))
+
+	l.Success("admin")
+	require.NoError(t, l.Check(ctx, "admin", ""))
+}
+
+func TestLockout(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	l, store := newTestLimiter(&now)
+
+	fail := func(username, sourceIP string, count int) {
+		for i := 0; i < count; i++ {
+			l.Failure(ctx, username, sourceIP)
+		}
+	}
+
+	fail("admin", "10.0.0.1", 10)
+	require.Len(t, store, 1)
+	lockout := store[key{username: "admin"}.lockoutName()]
+	require.NotNil(t, lockout)
+	assert.Equal(t, v3.LoginLockoutSpec{
+		Username:    "admin",
+		Failures:    10,
+		Count:       1,
+		LockedUntil: metav1.NewTime(now.Add(15 * time.Minute)),
+	}, lockout.Spec)
+
+	// the lockout applies from any address, the backoff of the failures was reset
+	assertTooManyFailedLogins(t, l.Check(ctx, "admin", "10.0.0.2"))
+	now = now.Add(15 * time.Minute)
+	require.NoError(t, l.Check(ctx, "admin", "10.0.0.2"))
+
+	// the duration of consecutive lockouts doubles
+	fail("admin", "10.0.0.2", 10)
+	assert.Equal(t, 2, store[lockout.Name].Spec.Count)
+	assert.Equal(t, now.Add(30*time.Minute), store[lockout.Name].Spec.LockedUntil.Time)
+
+	unlocker := &Unlocker{lockouts: l.lockouts}
+	require.NoError(t, unlocker.Unlock("Admin"))
+	require.NoError(t, l.Check(ctx, "admin", "10.0.0.3"))
+	require.NoError(t, unlocker.Unlock("admin"))
+
+	// the failures of all usernames count for the source IP
+	fail("user1", "10.0.0.1", 20)
+	fail("user2", "10.0.0.1", 20)
+	require.Contains(t, store, key{sourceIP: "10.0.0.1"}.lockoutName())
+	assertTooManyFailedLogins(t, l.Check(ctx, "someone", "10.0.0.1"))
+	require.NoError(t, l.Check(ctx, "someone", "10.0.0.4"))
+
+	// lockouts are purged once they expired longer than the maximum duration ago
+	l.purge()
+	assert.Len(t, store, 3)
+	now = now.Add(48 * time.Hour)
+	l.purge()
+	assert.Empty(t, store)
+}
+
+func TestLockoutDuration(t *testing.T) {
+	assert.Equal(t, 15*time.Minute, lockoutDuration(1))
+	assert.Equal(t, 60*time.Minute, lockoutDuration(3))
+	assert.Equal(t, 24*time.Hour, lockoutDuration(100))
+}
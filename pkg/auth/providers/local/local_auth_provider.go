@@ -31,13 +31,15 @@
 )
 
 type Provider struct {
-	userLister   v3.UserLister
-	groupLister  v3.GroupLister
-	userIndexer  cache.Indexer
-	gmIndexer    cache.Indexer
-	groupIndexer cache.Indexer
-	tokenMGR     *tokens.Manager
-	invalidHash  []byte
+	userLister     v3.UserLister
+	groupLister    v3.GroupLister
+	userIndexer    cache.Indexer
+	gmIndexer      cache.Indexer
+	groupIndexer   cache.Indexer
+	tokenMGR       *tokens.Manager
+	mfa            *MFA
+	passwordPolicy *PasswordPolicy
+	invalidHash    []byte
 }
 
 func Configure(ctx context.Context, mgmtCtx *config.ScaledContext, tokenMGR *tokens.Manager) common.AuthProvider {
@@ -56,13 +58,15 @@ func Configure(ctx context.Context, mgmtCtx *config.ScaledContext, tokenMGR *tok
 	invalidHash, _ := bcrypt.GenerateFromPassword([]byte("invalid"), bcrypt.DefaultCost)
 
 	l := &Provider{
-		userIndexer:  informer.GetIndexer(),
-		gmIndexer:    gmInformer.GetIndexer(),
-		groupLister:  mgmtCtx.Management.Groups("").Controller().Lister(),
-		groupIndexer: gInformer.GetIndexer(),
-		userLister:   mgmtCtx.Management.Users("").Controller().Lister(),
-		tokenMGR:     tokenMGR,
-		invalidHash:  invalidHash,
+		userIndexer:    informer.GetIndexer(),
+		gmIndexer:      gmInformer.GetIndexer(),
+		groupLister:    mgmtCtx.Management.Groups("").Controller().Lister(),
+		groupIndexer:   gInformer.GetIndexer(),
+		userLister:     mgmtCtx.Management.Users("").Controller().Lister(),
+		tokenMGR:       tokenMGR,
+		mfa:            NewMFA(mgmtCtx),
+		passwordPolicy: NewPasswordPolicy(mgmtCtx),
+		invalidHash:    invalidHash,
 	}
 	return l
 }
@@ -124,15 +128,24 @@ func (l *Provider) AuthenticateUser(ctx context.Context, input interface{}) (v3.
 		return v3.Principal{}, nil, "", authFailedError
 	}
 
-	principalID := getLocalPrincipalID(user)
-	userPrincipal := l.toPrincipal("user", user.DisplayName, user.Username, principalID, nil)
-	userPrincipal.Me = true
-
 	groupPrincipals, err := l.getGroupPrincipals(user)
 	if err != nil {
 		return v3.Principal{}, nil, "", errors.Wrapf(err, "failed to get groups for %v", user.Name)
 	}
 
+	if err := l.mfa.authenticate(user, groupPrincipals, localInput.TOTPCode, authFailedError); err != nil {
+		logrus.Debugf("Multi-factor authentication failed for User [%s]: %v", username, err)
+		return v3.Principal{}, nil, "", err
+	}
+
+	if err := l.passwordPolicy.expire(user); err != nil {
+		return v3.Principal{}, nil, "", errors.Wrapf(err, "failed to check the password age of %v", user.Name)
+	}
+
+	principalID := getLocalPrincipalID(user)
+	userPrincipal := l.toPrincipal("user", user.DisplayName, user.Username, principalID, nil)
+	userPrincipal.Me = true
+
 	return userPrincipal, groupPrincipals, "", nil
 }
 
@@ -0,0 +1,331 @@
+package local
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rancher/norman/httperror"
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/rbac"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/slice"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	mfaSecretPrefix = "mfa-"
+	totpIssuer      = "Rancher"
+
+	totpSecretKey    = "totpSecret"
+	recoveryCodesKey = "recoveryCodes"
+	lastStepKey      = "lastStep"
+	activatedAtKey   = "activatedAt"
+)
+
+var (
+	// MFARequired is returned by the login of users with multi-factor authentication that didn't provide a code.
+	MFARequired = httperror.ErrorCode{Code: "MFARequired", Status: 401}
+	// MFAEnrollmentRequired is returned by the login of users that have to enroll in multi-factor authentication. The
+	// login succeeds once it is repeated with a code of an enrollment created in an existing session of the user or
+	// issued by an administrator.
+	MFAEnrollmentRequired = httperror.ErrorCode{Code: "MFAEnrollmentRequired", Status: 401}
+)
+
+// MFA manages the TOTP multi-factor authentication of local users. The TOTP secret and the hashed recovery codes of
+// each user are stored in a secret in the cattle-system namespace.
+type MFA struct {
+	secrets             v1.SecretInterface
+	grbLister           v3.GlobalRoleBindingLister
+	grLister            v3.GlobalRoleLister
+	userAttributeLister v3.UserAttributeLister
+	now                 func() time.Time
+}
+
+// NewMFA creates the MFA using the clients defined in mgmtCtx.
+func NewMFA(mgmtCtx *config.ScaledContext) *MFA {
+	return &MFA{
+		secrets:             mgmtCtx.Core.Secrets(namespace.System),
+		grbLister:           mgmtCtx.Management.GlobalRoleBindings("").Controller().Lister(),
+		grLister:            mgmtCtx.Management.GlobalRoles("").Controller().Lister(),
+		userAttributeLister: mgmtCtx.Management.UserAttributes("").Controller().Lister(),
+		now:                 time.Now,
+	}
+}
+
+func mfaSecretName(user *v3.User) string {
+	return mfaSecretPrefix + user.Name
+}
+
+func (m *MFA) getSecret(user *v3.User) (*corev1.Secret, error) {
+	secret, err := m.secrets.Get(mfaSecretName(user), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return secret, err
+}
+
+func activated(secret *corev1.Secret) bool {
+	return secret != nil && len(secret.Data[activatedAtKey]) > 0
+}
+
+// Enabled returns true if the user activated multi-factor authentication.
+func (m *MFA) Enabled(user *v3.User) (bool, error) {
+	secret, err := m.getSecret(user)
+	if err != nil {
+		return false, err
+	}
+	return activated(secret), nil
+}
+
+// Required returns true if the user has to log in with multi-factor authentication, that is if the
+// local-auth-mfa-required-for-admins setting is enabled and the user or one of its groups is bound to an admin global
+// role. The groups are the given group principals of the login and the groups of the user from other providers.
+func (m *MFA) Required(user *v3.User, groupPrincipals []v3.Principal) (bool, error) {
+	if !strings.EqualFold(settings.LocalAuthMFARequiredForAdmins.Get(), "true") {
+		return false, nil
+	}
+
+	groups, err := m.groupPrincipalNames(user, groupPrincipals)
+	if err != nil {
+		return false, err
+	}
+	grbs, err := m.grbLister.List("", labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	for _, grb := range grbs {
+		if grb.UserName != user.Name && (grb.GroupPrincipalName == "" || !groups[grb.GroupPrincipalName]) {
+			continue
+		}
+		if grb.GlobalRoleName == rbac.GlobalAdmin || grb.GlobalRoleName == rbac.GlobalRestrictedAdmin {
+			return true, nil
+		}
+		gr, err := m.grLister.Get("", grb.GlobalRoleName)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		for _, rule := range gr.Rules {
+			// admin roles have all resources and all verbs allowed
+			if slice.ContainsString(rule.Resources, "*") && slice.ContainsString(rule.APIGroups, "*") && slice.ContainsString(rule.Verbs, "*") {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// groupPrincipalNames returns the names of the given group principals and of the group principals recorded in the
+// attributes of the user.
+func (m *MFA) groupPrincipalNames(user *v3.User, groupPrincipals []v3.Principal) (map[string]bool, error) {
+	groups := map[string]bool{}
+	for _, principal := range groupPrincipals {
+		groups[principal.Name] = true
+	}
+	attribs, err := m.userAttributeLister.Get("", user.Name)
+	if apierrors.IsNotFound(err) {
+		return groups, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, principals := range attribs.GroupPrincipals {
+		for _, principal := range principals.Items {
+			groups[principal.Name] = true
+		}
+	}
+	return groups, nil
+}
+
+// Enroll replaces the pending enrollment of the user with a new TOTP secret and recovery codes. The enrollment is
+// activated by the first valid code.
+func (m *MFA) Enroll(user *v3.User) (*v32.EnrollTOTPOutput, error) {
+	existing, err := m.getSecret(user)
+	if err != nil {
+		return nil, err
+	}
+	if activated(existing) {
+		return nil, httperror.NewAPIError(httperror.InvalidState, "multi-factor authentication is already enabled")
+	}
+
+	totpSecret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	data := map[string][]byte{
+		totpSecretKey:    []byte(totpSecret),
+		recoveryCodesKey: []byte(strings.Join(hashes, "\n")),
+	}
+
+	if existing != nil {
+		existing = existing.DeepCopy()
+		existing.Data = data
+		_, err = m.secrets.Update(existing)
+	} else {
+		_, err = m.secrets.Create(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      mfaSecretName(user),
+				Namespace: namespace.System,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: v3.UserGroupVersionKind.GroupVersion().String(),
+					Kind:       v3.UserGroupVersionKind.Kind,
+					Name:       user.Name,
+					UID:        user.UID,
+				}},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	accountName := user.Username
+	if accountName == "" {
+		accountName = user.Name
+	}
+	return &v32.EnrollTOTPOutput{
+		Secret:        totpSecret,
+		URL:           totpURL(totpIssuer, accountName, totpSecret),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// Activate activates the pending enrollment of the user if the code was generated from its TOTP secret.
+func (m *MFA) Activate(user *v3.User, code string) error {
+	secret, err := m.getSecret(user)
+	if err != nil {
+		return err
+	}
+	if secret == nil {
+		return httperror.NewAPIError(httperror.InvalidState, "multi-factor authentication enrollment not found")
+	}
+	if activated(secret) {
+		return httperror.NewAPIError(httperror.InvalidState, "multi-factor authentication is already enabled")
+	}
+
+	step, ok := validateTOTP(string(secret.Data[totpSecretKey]), code, m.now(), 0)
+	if !ok {
+		return httperror.NewAPIError(httperror.InvalidBodyContent, "invalid code")
+	}
+	secret = secret.DeepCopy()
+	secret.Data[lastStepKey] = []byte(strconv.FormatInt(step, 10))
+	secret.Data[activatedAtKey] = []byte(m.now().UTC().Format(time.RFC3339))
+	_, err = m.secrets.Update(secret)
+	return err
+}
+
+// Disable disables multi-factor authentication of the user if the code is valid.
+func (m *MFA) Disable(user *v3.User, code string) error {
+	secret, err := m.getSecret(user)
+	if err != nil {
+		return err
+	}
+	if !activated(secret) {
+		return httperror.NewAPIError(httperror.InvalidState, "multi-factor authentication is not enabled")
+	}
+	if ok, err := m.verify(secret, code); err != nil {
+		return err
+	} else if !ok {
+		return httperror.NewAPIError(httperror.InvalidBodyContent, "invalid code")
+	}
+	return m.Reset(user)
+}
+
+// Reset removes the multi-factor authentication of the user, e.g. after the user lost the authenticator and the
+// recovery codes.
+func (m *MFA) Reset(user *v3.User) error {
+	err := m.secrets.Delete(mfaSecretName(user), &metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// verify returns true if the code is a TOTP code of the secret that wasn't used before or an unused recovery code.
+// Used codes are recorded in the secret.
+func (m *MFA) verify(secret *corev1.Secret, code string) (bool, error) {
+	secret = secret.DeepCopy()
+	lastStep, _ := strconv.ParseInt(string(secret.Data[lastStepKey]), 10, 64)
+	if step, ok := validateTOTP(string(secret.Data[totpSecretKey]), code, m.now(), lastStep); ok {
+		secret.Data[lastStepKey] = []byte(strconv.FormatInt(step, 10))
+		// the update fails with a conflict if the code is used concurrently
+		_, err := m.secrets.Update(secret)
+		return err == nil, ignoreConflict(err)
+	}
+
+	hash := hashRecoveryCode(code)
+	hashes := strings.Split(string(secret.Data[recoveryCodesKey]), "\n")
+	for i, h := range hashes {
+		if h != hash {
+			continue
+		}
+		secret.Data[recoveryCodesKey] = []byte(strings.Join(append(hashes[:i:i], hashes[i+1:]...), "\n"))
+		_, err := m.secrets.Update(secret)
+		return err == nil, ignoreConflict(err)
+	}
+	return false, nil
+}
+
+func ignoreConflict(err error) error {
+	if apierrors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
+
+// authenticate is the second login step of users that passed the password check. Users that didn't enable
+// multi-factor authentication and aren't required to skip it. Users that are required but didn't activate their
+// enrollment log in with a code of their pending enrollment. The login never creates an enrollment, the password alone
+// must not be enough to register an authenticator.
+func (m *MFA) authenticate(user *v3.User, groupPrincipals []v3.Principal, code string, authFailedError error) error {
+	secret, err := m.getSecret(user)
+	if err != nil {
+		return err
+	}
+
+	if activated(secret) {
+		if code == "" {
+			return httperror.NewAPIError(MFARequired, "multi-factor authentication code required")
+		}
+		if ok, err := m.verify(secret, code); err != nil {
+			return err
+		} else if !ok {
+			return authFailedError
+		}
+		return nil
+	}
+
+	required, err := m.Required(user, groupPrincipals)
+	if err != nil || !required {
+		return err
+	}
+	if secret != nil && code != "" {
+		if err := m.Activate(user, code); err != nil {
+			if httperror.IsAPIError(err) {
+				return authFailedError
+			}
+			return err
+		}
+		return nil
+	}
+
+	return httperror.NewAPIError(MFAEnrollmentRequired, "multi-factor authentication enrollment required, enroll in an existing session or ask an administrator to issue an enrollment")
+}
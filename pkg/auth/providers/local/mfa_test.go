@@ -0,0 +1,115 @@
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rancher/norman/httperror"
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corefakes "github.com/rancher/rancher/pkg/generated/norman/core/v1/fakes"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3/fakes"
+	"github.com/rancher/rancher/pkg/rbac"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func newTestMFA(grbs []*v3.GlobalRoleBinding, attribs *v3.UserAttribute) (*MFA, map[string]*corev1.Secret) {
+	secrets := map[string]*corev1.Secret{}
+	return &MFA{
+		secrets: &corefakes.SecretInterfaceMock{
+			GetFunc: func(name string, opts metav1.GetOptions) (*corev1.Secret, error) {
+				if secret, ok := secrets[name]; ok {
+					return secret.DeepCopy(), nil
+				}
+				return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+			},
+			CreateFunc: func(secret *corev1.Secret) (*corev1.Secret, error) {
+				secrets[secret.Name] = secret.DeepCopy()
+				return secret, nil
+			},
+			UpdateFunc: func(secret *corev1.Secret) (*corev1.Secret, error) {
+				secrets[secret.Name] = secret.DeepCopy()
+				return secret, nil
+			},
+		},
+		grbLister: &fakes.GlobalRoleBindingListerMock{
+			ListFunc: func(namespace string, selector labels.Selector) ([]*v3.GlobalRoleBinding, error) {
+				return grbs, nil
+			},
+		},
+		grLister: &fakes.GlobalRoleListerMock{
+			GetFunc: func(namespace string, name string) (*v3.GlobalRole, error) {
+				return nil, apierrors.NewNotFound(v3.GlobalRoleGroupVersionResource.GroupResource(), name)
+			},
+		},
+		userAttributeLister: &fakes.UserAttributeListerMock{
+			GetFunc: func(namespace string, name string) (*v3.UserAttribute, error) {
+				if attribs == nil {
+					return nil, apierrors.NewNotFound(v3.UserAttributeGroupVersionResource.GroupResource(), name)
+				}
+				return attribs, nil
+			},
+		},
+		now: func() time.Time { return time.Unix(1111111109, 0) },
+	}, secrets
+}
+
+func TestMFARequiredByGroupBinding(t *testing.T) {
+	setSetting(t, settings.LocalAuthMFARequiredForAdmins, "true")
+	user := &v3.User{ObjectMeta: metav1.ObjectMeta{Name: "u-1"}}
+	grbs := []*v3.GlobalRoleBinding{
+		{GlobalRoleName: rbac.GlobalAdmin, UserName: "u-2"},
+		{GlobalRoleName: rbac.GlobalAdmin, GroupPrincipalName: "github_team://1"},
+		{GlobalRoleName: rbac.GlobalRestrictedAdmin, GroupPrincipalName: "local://g-admins"},
+	}
+
+	m, _ := newTestMFA(grbs, nil)
+	required, err := m.Required(user, nil)
+	require.NoError(t, err)
+	assert.False(t, required)
+
+	required, err = m.Required(user, []v3.Principal{{ObjectMeta: metav1.ObjectMeta{Name: "local://g-admins"}}})
+	require.NoError(t, err)
+	assert.True(t, required, "bound through a local group of the login")
+
+	m, _ = newTestMFA(grbs, &v3.UserAttribute{GroupPrincipals: map[string]v32.Principals{
+		"github": {Items: []v32.Principal{{ObjectMeta: metav1.ObjectMeta{Name: "github_team://1"}}}},
+	}})
+	required, err = m.Required(user, nil)
+	require.NoError(t, err)
+	assert.True(t, required, "bound through a group of another provider")
+}
+
+func TestMFAAuthenticateDoesNotEnroll(t *testing.T) {
+	setSetting(t, settings.LocalAuthMFARequiredForAdmins, "true")
+	user := &v3.User{ObjectMeta: metav1.ObjectMeta{Name: "u-1"}, Username: "admin"}
+	m, secrets := newTestMFA([]*v3.GlobalRoleBinding{{GlobalRoleName: rbac.GlobalAdmin, UserName: "u-1"}}, nil)
+	authFailedError := httperror.NewAPIError(httperror.Unauthorized, "authentication failed")
+
+	// a password alone doesn't create an enrollment
+	for _, code := range []string{"", "123456"} {
+		err := m.authenticate(user, nil, code, authFailedError)
+		require.Error(t, err)
+		apiErr, ok := err.(*httperror.APIError)
+		require.True(t, ok, "expected an API error, got %v", err)
+		assert.Equal(t, MFAEnrollmentRequired, apiErr.Code)
+	}
+	assert.Empty(t, secrets)
+
+	// the login activates an enrollment created in an existing session or issued by an administrator
+	enrollment, err := m.Enroll(user)
+	require.NoError(t, err)
+	key, err := base32NoPadding.DecodeString(enrollment.Secret)
+	require.NoError(t, err)
+	code := totpCode(key, m.now().Unix()/totpPeriod)
+	require.NoError(t, m.authenticate(user, nil, code, authFailedError))
+	enabled, err := m.Enabled(user)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+}
@@ -0,0 +1,305 @@
+package local
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/rancher/norman/httperror"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	passwordHistorySecretPrefix = "password-history-"
+
+	passwordHashesKey    = "hashes"
+	passwordChangedAtKey = "changedAt"
+)
+
+// characterClasses are the character classes the password-required-character-classes setting can require.
+var characterClasses = map[string]func(rune) bool{
+	"lowercase": unicode.IsLower,
+	"uppercase": unicode.IsUpper,
+	"digit":     unicode.IsDigit,
+	"symbol": func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+	},
+}
+
+var (
+	//go:embed common-passwords.txt
+	commonPasswordsList string
+	commonPasswords     map[string]bool
+	commonPasswordsOnce sync.Once
+)
+
+// PasswordPolicy enforces the password settings beyond the minimum length on the passwords of local users. The
+// hashes of the previous passwords of each user and the time of the last change are stored in a secret in the
+// cattle-system namespace.
+type PasswordPolicy struct {
+	secrets         v1.SecretInterface
+	users           v3.UserInterface
+	configMapLister v1.ConfigMapLister
+	now             func() time.Time
+}
+
+// NewPasswordPolicy creates the PasswordPolicy using the clients defined in mgmtCtx.
+func NewPasswordPolicy(mgmtCtx *config.ScaledContext) *PasswordPolicy {
+	return &PasswordPolicy{
+		secrets:         mgmtCtx.Core.Secrets(namespace.System),
+		users:           mgmtCtx.Management.Users(""),
+		configMapLister: mgmtCtx.Core.ConfigMaps("").Controller().Lister(),
+		now:             time.Now,
+	}
+}
+
+func passwordHistorySecretName(user *v3.User) string {
+	return passwordHistorySecretPrefix + user.Name
+}
+
+func (p *PasswordPolicy) getSecret(user *v3.User) (*corev1.Secret, error) {
+	secret, err := p.secrets.Get(passwordHistorySecretName(user), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return secret, err
+}
+
+// Validate checks that the new password of the user contains the required character classes, isn't denied and wasn't
+// used recently. The user is nil for users that don't exist yet.
+func (p *PasswordPolicy) Validate(user *v3.User, password string) error {
+	if err := validateCharacterClasses(password, settings.PasswordRequiredCharacterClasses.Get()); err != nil {
+		return err
+	}
+
+	denied, err := p.denied(password)
+	if err != nil {
+		return err
+	}
+	if denied {
+		return httperror.NewAPIError(httperror.InvalidBodyContent, "Password is too common")
+	}
+
+	if user == nil {
+		return nil
+	}
+	hashes, err := p.history(user)
+	if err != nil {
+		return err
+	}
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return httperror.NewAPIError(httperror.InvalidBodyContent, "Password was used recently")
+		}
+	}
+	return nil
+}
+
+func validateCharacterClasses(password, required string) error {
+	for _, class := range strings.Split(required, ",") {
+		class = strings.ToLower(strings.TrimSpace(class))
+		if class == "" {
+			continue
+		}
+		contains, ok := characterClasses[class]
+		if !ok {
+			logrus.Warnf("Ignoring unknown character class %q in setting %s", class, settings.PasswordRequiredCharacterClasses.Name)
+			continue
+		}
+		if strings.IndexFunc(password, contains) < 0 {
+			return httperror.NewAPIError(httperror.InvalidBodyContent, "Password must contain a "+class+" character")
+		}
+	}
+	return nil
+}
+
+// denied returns true if the password is in the bundled list of common passwords and the list is enabled, or in the
+// deny-list ConfigMap. Passwords are compared case-insensitively.
+func (p *PasswordPolicy) denied(password string) (bool, error) {
+	password = strings.ToLower(password)
+
+	if strings.EqualFold(settings.PasswordDenyCommonPasswords.Get(), "true") {
+		commonPasswordsOnce.Do(func() {
+			commonPasswords = parsePasswordList(commonPasswordsList)
+		})
+		if commonPasswords[password] {
+			return true, nil
+		}
+	}
+
+	name := settings.PasswordDenyListConfigMap.Get()
+	if name == "" {
+		return false, nil
+	}
+	configMap, err := p.configMapLister.Get(namespace.System, name)
+	if apierrors.IsNotFound(err) {
+		logrus.Warnf("Password deny-list ConfigMap %s/%s not found", namespace.System, name)
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	for _, list := range configMap.Data {
+		if parsePasswordList(list)[password] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parsePasswordList returns the lowercased passwords of a list with one password per line. Empty lines and lines
+// starting with # are skipped.
+func parsePasswordList(list string) map[string]bool {
+	passwords := map[string]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = true
+	}
+	return passwords
+}
+
+// history returns the hashes of the passwords of the user that can't be reused, the current one first.
+func (p *PasswordPolicy) history(user *v3.User) ([]string, error) {
+	count := settings.PasswordHistoryCount.GetInt()
+	if count <= 0 {
+		return nil, nil
+	}
+	secret, err := p.getSecret(user)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes []string
+	if user.Password != "" {
+		hashes = append(hashes, user.Password)
+	}
+	if secret != nil {
+		for _, hash := range strings.Split(string(secret.Data[passwordHashesKey]), "\n") {
+			if hash != "" && hash != user.Password {
+				hashes = append(hashes, hash)
+			}
+		}
+	}
+	if len(hashes) > count {
+		hashes = hashes[:count]
+	}
+	return hashes, nil
+}
+
+// Record records that the password of the user changed, adding the hash of its current password to the history.
+func (p *PasswordPolicy) Record(userName string) error {
+	user, err := p.users.Get(userName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	return p.RecordPassword(user, user.Password)
+}
+
+// RecordPassword records that the password of the user changes to the password with the given hash. It is called
+// before the password is updated, so that a password is never changed without recording the time of the change.
+func (p *PasswordPolicy) RecordPassword(user *v3.User, passwordHash string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := p.getSecret(user)
+		if err != nil {
+			return err
+		}
+
+		hashes := []string{passwordHash}
+		if existing != nil {
+			for _, hash := range strings.Split(string(existing.Data[passwordHashesKey]), "\n") {
+				if hash != "" && hash != passwordHash {
+					hashes = append(hashes, hash)
+				}
+			}
+		}
+		count := settings.PasswordHistoryCount.GetInt()
+		if count < 1 {
+			count = 1
+		}
+		if len(hashes) > count {
+			hashes = hashes[:count]
+		}
+		data := map[string][]byte{
+			passwordHashesKey:    []byte(strings.Join(hashes, "\n")),
+			passwordChangedAtKey: []byte(p.now().UTC().Format(time.RFC3339)),
+		}
+
+		if existing != nil {
+			existing = existing.DeepCopy()
+			existing.Data = data
+			_, err = p.secrets.Update(existing)
+			return err
+		}
+		_, err = p.secrets.Create(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      passwordHistorySecretName(user),
+				Namespace: namespace.System,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: v3.UserGroupVersionKind.GroupVersion().String(),
+					Kind:       v3.UserGroupVersionKind.Kind,
+					Name:       user.Name,
+					UID:        user.UID,
+				}},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		})
+		if apierrors.IsAlreadyExists(err) {
+			return apierrors.NewConflict(corev1.Resource("secrets"), passwordHistorySecretName(user), err)
+		}
+		return err
+	})
+}
+
+// expire requires the user to change the password if it is older than the password-max-age-days setting. Passwords
+// that were set before the policy recorded their changes are as old as the user.
+func (p *PasswordPolicy) expire(user *v3.User) error {
+	maxAgeDays := settings.PasswordMaxAgeDays.GetInt()
+	if maxAgeDays <= 0 || user.MustChangePassword {
+		return nil
+	}
+
+	changedAt := user.CreationTimestamp.Time
+	secret, err := p.getSecret(user)
+	if err != nil {
+		return err
+	}
+	if secret != nil {
+		if t, err := time.Parse(time.RFC3339, string(secret.Data[passwordChangedAtKey])); err == nil {
+			changedAt = t
+		}
+	}
+	if p.now().Before(changedAt.Add(time.Duration(maxAgeDays) * 24 * time.Hour)) {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := p.users.Get(user.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if current.MustChangePassword {
+			return nil
+		}
+		current = current.DeepCopy()
+		current.MustChangePassword = true
+		_, err = p.users.Update(current)
+		return err
+	})
+}
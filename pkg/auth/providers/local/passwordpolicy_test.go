@@ -0,0 +1,175 @@
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rancher/norman/httperror"
+	corefakes "github.com/rancher/rancher/pkg/generated/norman/core/v1/fakes"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3/fakes"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestPasswordPolicy(now time.Time, user *v3.User) (*PasswordPolicy, map[string]*corev1.Secret) {
+	secrets := map[string]*corev1.Secret{}
+	return &PasswordPolicy{
+		secrets: &corefakes.SecretInterfaceMock{
+			GetFunc: func(name string, opts metav1.GetOptions) (*corev1.Secret, error) {
+				if secret, ok := secrets[name]; ok {
+					return secret.DeepCopy(), nil
+				}
+				return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+			},
+			CreateFunc: func(secret *corev1.Secret) (*corev1.Secret, error) {
+				secrets[secret.Name] = secret.DeepCopy()
+				return secret, nil
+			},
+			UpdateFunc: func(secret *corev1.Secret) (*corev1.Secret, error) {
+				secrets[secret.Name] = secret.DeepCopy()
+				return secret, nil
+			},
+		},
+		users: &fakes.UserInterfaceMock{
+			GetFunc: func(name string, opts metav1.GetOptions) (*v3.User, error) {
+				return user.DeepCopy(), nil
+			},
+			UpdateFunc: func(u *v3.User) (*v3.User, error) {
+				*user = *u.DeepCopy()
+				return u, nil
+			},
+		},
+		configMapLister: &corefakes.ConfigMapListerMock{
+			GetFunc: func(namespace string, name string) (*corev1.ConfigMap, error) {
+				if name != "denied-passwords" {
+					return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), name)
+				}
+				return &corev1.ConfigMap{Data: map[string]string{"passwords": "# banned\nCorrectHorseBatteryStaple\n"}}, nil
+			},
+		},
+		now: func() time.Time { return now },
+	}, secrets
+}
+
+func setSetting(t *testing.T, setting settings.Setting, value string) {
+	previous := setting.Get()
+	require.NoError(t, setting.Set(value))
+	t.Cleanup(func() {
+		_ = setting.Set(previous)
+	})
+}
+
+func hash(t *testing.T, password string) string {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hashed)
+}
+
+func assertInvalid(t *testing.T, err error) {
+	require.Error(t, err)
+	apiErr, ok := err.(*httperror.APIError)
+	require.True(t, ok, "expected an API error, got %v", err)
+	assert.Equal(t, httperror.InvalidBodyContent, apiErr.Code)
+}
+
+func TestPasswordPolicyCharacterClasses(t *testing.T) {
+	setSetting(t, settings.PasswordRequiredCharacterClasses, "lowercase, Uppercase,digit,symbol,unknown")
+	p, _ := newTestPasswordPolicy(time.Now(), &v3.User{})
+
+	assertInvalid(t, p.Validate(nil, "alllowercase"))
+	assertInvalid(t, p.Validate(nil, "MixedCaseOnly"))
+	assertInvalid(t, p.Validate(nil, "MixedCase1234"))
+	assertInvalid(t, p.Validate(nil, "Mixed Case 1234"))
+	assert.NoError(t, p.Validate(nil, "Mixed-Case-1234"))
+}
+
+func TestPasswordPolicyDenyList(t *testing.T) {
+	p, _ := newTestPasswordPolicy(time.Now(), &v3.User{})
+
+	// the bundled list is disabled by default
+	assert.NoError(t, p.Validate(nil, "Password1234"))
+
+	setSetting(t, settings.PasswordDenyCommonPasswords, "true")
+	assertInvalid(t, p.Validate(nil, "Password1234"))
+	assert.NoError(t, p.Validate(nil, "correcthorsebatterystaple"))
+
+	setSetting(t, settings.PasswordDenyListConfigMap, "denied-passwords")
+	assertInvalid(t, p.Validate(nil, "correcthorsebatterystaple"))
+
+	// a missing ConfigMap doesn't deny any password
+	setSetting(t, settings.PasswordDenyListConfigMap, "missing")
+	assert.NoError(t, p.Validate(nil, "correcthorsebatterystaple"))
+}
+
+func TestPasswordPolicyHistory(t *testing.T) {
+	user := &v3.User{ObjectMeta: metav1.ObjectMeta{Name: "u-abc"}}
+	p, secrets := newTestPasswordPolicy(time.Now(), user)
+
+	// without history the current password can be reused
+	user.Password = hash(t, "first-password")
+	require.NoError(t, p.Record(user.Name))
+	assert.NoError(t, p.Validate(user, "first-password"))
+
+	setSetting(t, settings.PasswordHistoryCount, "2")
+	for _, password := range []string{"second-password", "third-password"} {
+		user.Password = hash(t, password)
+		require.NoError(t, p.Record(user.Name))
+	}
+	assert.Contains(t, secrets, "password-history-u-abc")
+	assertInvalid(t, p.Validate(user, "third-password"))
+	assertInvalid(t, p.Validate(user, "second-password"))
+	assert.NoError(t, p.Validate(user, "first-password"))
+	// new users have no history
+	assert.NoError(t, p.Validate(nil, "third-password"))
+}
+
+func TestPasswordPolicyExpire(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	user := &v3.User{ObjectMeta: metav1.ObjectMeta{Name: "u-abc", CreationTimestamp: metav1.NewTime(now.AddDate(0, 0, -100))}}
+	p, _ := newTestPasswordPolicy(now, user)
+
+	require.NoError(t, p.expire(user))
+	assert.False(t, user.MustChangePassword, "passwords don't expire by default")
+
+	setSetting(t, settings.PasswordMaxAgeDays, "90")
+	p.now = func() time.Time { return now.AddDate(0, 0, -20) }
+	require.NoError(t, p.Record(user.Name))
+	p.now = func() time.Time { return now }
+	require.NoError(t, p.expire(user))
+	assert.False(t, user.MustChangePassword, "the password was changed 20 days ago")
+
+	p.now = func() time.Time { return now.AddDate(0, 0, 71) }
+	require.NoError(t, p.expire(user))
+	assert.True(t, user.MustChangePassword)
+}
+
+func TestPasswordPolicyRecordPasswordBeforeUpdate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	user := &v3.User{ObjectMeta: metav1.ObjectMeta{Name: "u-abc", CreationTimestamp: metav1.NewTime(now.AddDate(0, 0, -100))}}
+	user.Password = hash(t, "old-password")
+	p, _ := newTestPasswordPolicy(now, user)
+	setSetting(t, settings.PasswordMaxAgeDays, "90")
+	setSetting(t, settings.PasswordHistoryCount, "2")
+
+	// the new password is recorded while the user still has the old one
+	require.NoError(t, p.RecordPassword(user, hash(t, "new-password")))
+	assertInvalid(t, p.Validate(user, "new-password"))
+	require.NoError(t, p.expire(user))
+	assert.False(t, user.MustChangePassword, "the change time is recorded")
+}
+
+func TestPasswordPolicyExpireWithoutRecord(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	user := &v3.User{ObjectMeta: metav1.ObjectMeta{Name: "u-abc", CreationTimestamp: metav1.NewTime(now.AddDate(0, 0, -100))}}
+	p, _ := newTestPasswordPolicy(now, user)
+	setSetting(t, settings.PasswordMaxAgeDays, "90")
+
+	require.NoError(t, p.expire(user.DeepCopy()))
+	assert.True(t, user.MustChangePassword, "passwords without record are as old as the user")
+}
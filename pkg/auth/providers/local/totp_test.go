@@ -0,0 +1,83 @@
+package local
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// test vectors of RFC 6238 for SHA1, truncated to 6 digits
+	secret := []byte("12345678901234567890")
+	tests := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range tests {
+		assert.Equal(t, want, totpCode(secret, unix/totpPeriod), unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret := base32NoPadding.EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111109, 0)
+	step := now.Unix() / totpPeriod
+
+	got, ok := validateTOTP(secret, "081804", now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, step, got)
+
+	// codes of the previous and the next period are accepted
+	_, ok = validateTOTP(secret, "081804", now.Add(totpPeriod*time.Second), 0)
+	assert.True(t, ok)
+	_, ok = validateTOTP(secret, "081804", now.Add(-totpPeriod*time.Second), 0)
+	assert.True(t, ok)
+	_, ok = validateTOTP(secret, "081804", now.Add(2*totpPeriod*time.Second), 0)
+	assert.False(t, ok)
+
+	// used codes are rejected
+	_, ok = validateTOTP(secret, "081804", now, step)
+	assert.False(t, ok)
+
+	_, ok = validateTOTP(secret, "000000", now, 0)
+	assert.False(t, ok)
+	_, ok = validateTOTP(secret, "", now, 0)
+	assert.False(t, ok)
+	_, ok = validateTOTP("not base32!", "081804", now, 0)
+	assert.False(t, ok)
+}
+
+func TestTOTPURL(t *testing.T) {
+	u, err := url.Parse(totpURL("Rancher", "admin", "SECRET"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Rancher:admin", u.Path)
+	assert.Equal(t, "SECRET", u.Query().Get("secret"))
+	assert.Equal(t, "Rancher", u.Query().Get("issuer"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := generateRecoveryCodes()
+	require.NoError(t, err)
+	assert.Len(t, codes, recoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Len(t, code, 19)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	// the hash ignores case, surrounding spaces and dashes
+	assert.Equal(t, hashRecoveryCode(codes[0]), hashRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))+" "))
+	assert.NotEqual(t, hashRecoveryCode(codes[0]), hashRecoveryCode(codes[1]))
+}
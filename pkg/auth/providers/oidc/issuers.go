@@ -0,0 +1,201 @@
+package oidc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rancher/norman/httperror"
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+)
+
+const (
+	defaultUsernameClaim    = "email"
+	defaultDisplayNameClaim = "name"
+)
+
+// issuerNameRegexp matches the names of additional issuers, which are part of the names of their client secrets.
+var issuerNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// issuer is an issuer users can log in with: either the issuer of the OIDCConfig, or one of its additional issuers.
+type issuer struct {
+	// name is the name of an additional issuer, empty for the issuer of the config.
+	name string
+	// config holds the client settings of the issuer.
+	config  *v32.OIDCConfig
+	mapping v32.OIDCClaimMapping
+}
+
+// getIssuers returns the issuer of the config followed by its additional issuers. The configs of additional issuers
+// are copies of the config with the client settings of the issuer.
+func getIssuers(config *v32.OIDCConfig) []issuer {
+	issuers := []issuer{{config: config, mapping: config.ClaimMapping}}
+	for _, additional := range config.AdditionalIssuers {
+		issuerConfig := config.DeepCopy()
+		issuerConfig.ClientID = additional.ClientID
+		issuerConfig.ClientSecret = additional.ClientSecret
+		issuerConfig.Scopes = additional.Scopes
+		issuerConfig.AuthEndpoint = additional.AuthEndpoint
+		issuerConfig.Issuer = additional.Issuer
+		issuerConfig.Certificate = ""
+		issuerConfig.PrivateKey = ""
+		issuerConfig.ClaimMapping = additional.ClaimMapping
+		issuerConfig.AdditionalIssuers = nil
+
+		mapping := additional.ClaimMapping
+		if mapping.UserPrefix == "" {
+			mapping.UserPrefix = additional.Name + ":"
+		}
+		if mapping.GroupPrefix == "" {
+			mapping.GroupPrefix = additional.Name + ":"
+		}
+		issuers = append(issuers, issuer{name: additional.Name, config: issuerConfig, mapping: mapping})
+	}
+	return issuers
+}
+
+// getIssuer returns the issuer with the name, or the issuer of the config if the name is empty.
+func getIssuer(config *v32.OIDCConfig, name string) (*issuer, error) {
+	for _, iss := range getIssuers(config) {
+		if iss.name == name {
+			return &iss, nil
+		}
+	}
+	return nil, httperror.NewAPIError(httperror.InvalidBodyContent, fmt.Sprintf("unknown issuer %s", name))
+}
+
+// getIssuerOfPrincipal returns the issuer of a user principal, the issuer with the longest user prefix the subject of
+// the principal starts with.
+func getIssuerOfPrincipal(config *v32.OIDCConfig, providerName, principalID string) *issuer {
+	subject := strings.TrimPrefix(principalID, providerName+"_"+UserType+"://")
+	issuers := getIssuers(config)
+	result := &issuers[0]
+	for i := range issuers {
+		prefix := issuers[i].mapping.UserPrefix
+		if prefix != "" && strings.HasPrefix(subject, prefix) && len(prefix) > len(result.mapping.UserPrefix) {
+			result = &issuers[i]
+		}
+	}
+	return result
+}
+
+// validateIssuers checks that the names of the additional issuers are unique DNS labels, and that no user prefix starts
+// with another so that the issuer of a user principal is the one with the longest matching prefix. The issuer of the
+// config may have no user prefix, which keeps the principal IDs of its existing users when issuers are added.
+func validateIssuers(config *v32.OIDCConfig) error {
+	names := map[string]bool{}
+	for _, additional := range config.AdditionalIssuers {
+		if len(additional.Name) > 63 || !issuerNameRegexp.MatchString(additional.Name) {
+			return httperror.NewAPIError(httperror.InvalidBodyContent, fmt.Sprintf("invalid issuer name %q, names must be lowercase DNS labels", additional.Name))
+		}
+		if names[additional.Name] {
+			return httperror.NewAPIError(httperror.InvalidBodyContent, fmt.Sprintf("duplicate issuer name %s", additional.Name))
+		}
+		names[additional.Name] = true
+	}
+	issuers := getIssuers(config)
+	for i := range issuers {
+		for j := range issuers {
+			if i != j && issuers[j].mapping.UserPrefix != "" && strings.HasPrefix(issuers[i].mapping.UserPrefix, issuers[j].mapping.UserPrefix) {
+				return httperror.NewAPIError(httperror.InvalidBodyContent, fmt.Sprintf("user prefix %q of issuer %s starts with user prefix %q of issuer %s",
+					issuers[i].mapping.UserPrefix, issuerDisplayName(issuers[i]), issuers[j].mapping.UserPrefix, issuerDisplayName(issuers[j])))
+			}
+		}
+	}
+	return nil
+}
+
+// validateUserPrefixes checks that the user prefixes of the issuers of the stored config don't change, they are part of
+// the principal IDs of existing users, which would no longer match the principals of their logins.
+func validateUserPrefixes(stored, config *v32.OIDCConfig) error {
+	prefixes := map[string]string{}
+	for _, iss := range getIssuers(config) {
+		prefixes[iss.name] = iss.mapping.UserPrefix
+	}
+	for _, iss := range getIssuers(stored) {
+		if prefix, ok := prefixes[iss.name]; ok && prefix != iss.mapping.UserPrefix {
+			return httperror.NewAPIError(httperror.InvalidBodyContent, fmt.Sprintf("the user prefix of issuer %s can't be changed from %q to %q, users of the provider exist",
+				issuerDisplayName(iss), iss.mapping.UserPrefix, prefix))
+		}
+	}
+	return nil
+}
+
+func issuerDisplayName(iss issuer) string {
+	if iss.name == "" {
+		return iss.config.Issuer
+	}
+	return iss.name
+}
+
+// claimValues returns the values of a claim expression: the path of a claim with dots between the names of nested
+// claims, or alternatives separated by |, of which the first with values is used. Claims with a single value and
+// lists of values are supported.
+func claimValues(claims map[string]interface{}, expression string) []string {
+	for _, path := range strings.Split(expression, "|") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		value, ok := lookupClaim(claims, path)
+		if !ok {
+			continue
+		}
+		var values []string
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				if s := claimString(item); s != "" {
+					values = append(values, s)
+				}
+			}
+		default:
+			if s := claimString(v); s != "" {
+				values = append(values, s)
+			}
+		}
+		if len(values) > 0 {
+			return values
+		}
+	}
+	return nil
+}
+
+// claimValue returns the first value of a claim expression.
+func claimValue(claims map[string]interface{}, expression string) string {
+	if values := claimValues(claims, expression); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// lookupClaim returns the claim at the path. Names of claims may contain dots themselves, e.g. namespaced claims
+// like https://example.com/groups, so the longest name that matches is used at each level.
+func lookupClaim(claims map[string]interface{}, path string) (interface{}, bool) {
+	if value, ok := claims[path]; ok {
+		return value, true
+	}
+	for i := strings.LastIndex(path, "."); i > 0; i = strings.LastIndex(path[:i], ".") {
+		nested, ok := claims[path[:i]].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := lookupClaim(nested, path[i+1:]); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func claimString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
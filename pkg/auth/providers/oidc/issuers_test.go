@@ -0,0 +1,178 @@
+package oidc
+
+import (
+	"encoding/json"
+	"testing"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimValues(t *testing.T) {
+	var claims map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"email": "alice@example.com",
+		"preferred_username": "",
+		"groups": ["admins", "devs"],
+		"realm_access": {"roles": ["operator"]},
+		"https://example.com/claims": {"team": "platform"},
+		"resource.access": {"rancher": {"roles": ["viewer", 42]}},
+		"level": 3
+	}`), &claims))
+
+	tests := map[string][]string{
+		"email":                            {"alice@example.com"},
+		"preferred_username|email":         {"alice@example.com"},
+		"missing | groups":                 {"admins", "devs"},
+		"realm_access.roles":               {"operator"},
+		"https://example.com/claims.team":  {"platform"},
+		"resource.access.rancher.roles":    {"viewer", "42"},
+		"level":                            {"3"},
+		"realm_access":                     nil,
+		"realm_access.missing":             nil,
+		"":                                 nil,
+		"groups.admins":                    nil,
+		"https://example.com/claims.other": nil,
+	}
+	for expression, want := range tests {
+		assert.Equal(t, want, claimValues(claims, expression), expression)
+	}
+	assert.Equal(t, "admins", claimValue(claims, "groups"))
+	assert.Equal(t, "", claimValue(claims, "missing"))
+}
+
+func TestGetIssuers(t *testing.T) {
+	config := &v32.OIDCConfig{
+		ClientID:    "rancher",
+		Issuer:      "https://corp.example.com",
+		Certificate: "cert",
+		AdditionalIssuers: []v32.OIDCIssuerConfig{
+			{
+				Name:     "contractors",
+				ClientID: "rancher-contractors",
+				Issuer:   "https://contractors.example.com",
+			},
+			{
+				Name:         "partners",
+				ClientID:     "rancher-partners",
+				Issuer:       "https://partners.example.com",
+				ClaimMapping: v32.OIDCClaimMapping{UserPrefix: "partner-", GroupPrefix: "partner-", GroupsClaim: "roles"},
+			},
+		},
+	}
+
+	issuers := getIssuers(config)
+	require.Len(t, issuers, 3)
+	assert.Equal(t, "", issuers[0].name)
+	assert.Equal(t, "https://corp.example.com", issuers[0].config.Issuer)
+	assert.Equal(t, v32.OIDCClaimMapping{}, issuers[0].mapping)
+
+	assert.Equal(t, "contractors", issuers[1].name)
+	assert.Equal(t, "rancher-contractors", issuers[1].config.ClientID)
+	assert.Equal(t, "https://contractors.example.com", issuers[1].config.Issuer)
+	assert.Empty(t, issuers[1].config.Certificate)
+	assert.Equal(t, "contractors:", issuers[1].mapping.UserPrefix)
+	assert.Equal(t, "contractors:", issuers[1].mapping.GroupPrefix)
+
+	assert.Equal(t, "partner-", issuers[2].mapping.UserPrefix)
+	assert.Equal(t, "roles", issuers[2].mapping.GroupsClaim)
+
+	iss, err := getIssuer(config, "partners")
+	require.NoError(t, err)
+	assert.Equal(t, "rancher-partners", iss.config.ClientID)
+	_, err = getIssuer(config, "unknown")
+	assert.Error(t, err)
+
+	assert.Equal(t, "", getIssuerOfPrincipal(config, Name, "oidc_user://1234").name)
+	assert.Equal(t, "contractors", getIssuerOfPrincipal(config, Name, "oidc_user://contractors:1234").name)
+	assert.Equal(t, "partners", getIssuerOfPrincipal(config, Name, "oidc_user://partner-1234").name)
+}
+
+func TestValidateIssuers(t *testing.T) {
+	assert.NoError(t, validateIssuers(&v32.OIDCConfig{}))
+	valid := &v32.OIDCConfig{
+		ClaimMapping:      v32.OIDCClaimMapping{UserPrefix: "corp:"},
+		AdditionalIssuers: []v32.OIDCIssuerConfig{{Name: "contractors"}, {Name: "partners-eu"}},
+	}
+	assert.NoError(t, validateIssuers(valid))
+
+	for _, names := range [][]string{{""}, {"Contractors"}, {"contractors", "contractors"}, {"partners_eu"}} {
+		config := &v32.OIDCConfig{ClaimMapping: v32.OIDCClaimMapping{UserPrefix: "corp:"}}
+		for _, name := range names {
+			config.AdditionalIssuers = append(config.AdditionalIssuers, v32.OIDCIssuerConfig{Name: name})
+		}
+		assert.Error(t, validateIssuers(config), names)
+	}
+
+	// existing users of the issuer of the config keep their principal IDs when issuers are added
+	noPrimaryPrefix := &v32.OIDCConfig{AdditionalIssuers: []v32.OIDCIssuerConfig{{Name: "contractors"}}}
+	assert.NoError(t, validateIssuers(noPrimaryPrefix))
+
+	for _, prefixes := range [][]string{{"corp:", "corp:"}, {"corp:", "corp:eu:"}, {"contractors:eu:", ""}, {"", "contractors:eu:"}} {
+		config := &v32.OIDCConfig{
+			ClaimMapping: v32.OIDCClaimMapping{UserPrefix: prefixes[0]},
+			AdditionalIssuers: []v32.OIDCIssuerConfig{
+				{Name: "contractors"},
+				{Name: "partners", ClaimMapping: v32.OIDCClaimMapping{UserPrefix: prefixes[1]}},
+			},
+		}
+		assert.Error(t, validateIssuers(config), prefixes)
+	}
+}
+
+func TestValidateUserPrefixes(t *testing.T) {
+	stored := &v32.OIDCConfig{
+		ClaimMapping:      v32.OIDCClaimMapping{UserPrefix: "corp:"},
+		AdditionalIssuers: []v32.OIDCIssuerConfig{{Name: "contractors"}},
+	}
+
+	config := stored.DeepCopy()
+	config.ClaimMapping.GroupPrefix = "corp:"
+	config.AdditionalIssuers = append(config.AdditionalIssuers, v32.OIDCIssuerConfig{Name: "partners"})
+	assert.NoError(t, validateUserPrefixes(stored, config))
+
+	// removed issuers can't log in anymore, their prefixes don't matter
+	config = stored.DeepCopy()
+	config.AdditionalIssuers = nil
+	assert.NoError(t, validateUserPrefixes(stored, config))
+
+	config = stored.DeepCopy()
+	config.ClaimMapping.UserPrefix = ""
+	assert.Error(t, validateUserPrefixes(stored, config))
+
+	config = stored.DeepCopy()
+	config.AdditionalIssuers[0].ClaimMapping.UserPrefix = "contractors-"
+	assert.Error(t, validateUserPrefixes(stored, config))
+
+	// setting the default prefix explicitly doesn't change it
+	config = stored.DeepCopy()
+	config.AdditionalIssuers[0].ClaimMapping.UserPrefix = "contractors:"
+	assert.NoError(t, validateUserPrefixes(stored, config))
+}
+
+func TestGetGroups(t *testing.T) {
+	o := &OpenIDCProvider{Name: Name}
+	claims := map[string]interface{}{
+		"roles": []interface{}{"admin"},
+	}
+	claimInfo := ClaimInfo{
+		Groups:        []string{"devs"},
+		FullGroupPath: []string{"/org/devs"},
+	}
+
+	groups := o.getGroups(claims, claimInfo, v32.OIDCClaimMapping{})
+	require.Len(t, groups, 2)
+	assert.Equal(t, "oidc_group://org", groups[0].Name)
+	assert.Equal(t, "oidc_group://devs", groups[1].Name)
+	assert.True(t, groups[1].MemberOf)
+
+	groups = o.getGroups(claims, ClaimInfo{Groups: []string{"devs"}}, v32.OIDCClaimMapping{GroupPrefix: "corp:"})
+	require.Len(t, groups, 1)
+	assert.Equal(t, "oidc_group://corp:devs", groups[0].Name)
+	assert.Equal(t, "corp:devs", groups[0].DisplayName)
+
+	groups = o.getGroups(claims, claimInfo, v32.OIDCClaimMapping{GroupsClaim: "roles", GroupPrefix: "partners:"})
+	require.Len(t, groups, 1)
+	assert.Equal(t, "oidc_group://partners:admin", groups[0].Name)
+}
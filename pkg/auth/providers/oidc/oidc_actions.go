@@ -5,6 +5,7 @@
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/rancher/norman/api/handler"
@@ -13,6 +14,7 @@
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/auth/providers/common"
 	managementschema "github.com/rancher/rancher/pkg/schemas/management.cattle.io/v3"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 func (o *OpenIDCProvider) Formatter(apiContext *types.APIContext, resource *types.RawResource) {
@@ -80,6 +82,12 @@ func (o *OpenIDCProvider) TestAndApply(actionName string, action *types.Action,
 		return errors.Wrap(err, "[generic oidc]: server error while authenticating")
 	}
 	oidcConfig.Issuer = issuerURL.String()
+	if err := validateIssuers(&oidcConfig); err != nil {
+		return err
+	}
+	if err := o.validateUserPrefixChange(&oidcConfig); err != nil {
+		return err
+	}
 
 	//call provider
 	userPrincipal, groupPrincipals, providerToken, claimInfo, err := o.LoginUser(request.Request.Context(), oidcLogin, &oidcConfig)
@@ -91,7 +99,7 @@ func (o *OpenIDCProvider) TestAndApply(actionName string, action *types.Action,
 	}
 	//setting a bool for group search flag
 	//this only needs updated when an auth provider is enabled or edited
-	if claimInfo.Groups == nil && claimInfo.FullGroupPath == nil {
+	if claimInfo.Groups == nil && claimInfo.FullGroupPath == nil && len(groupPrincipals) == 0 {
 		falseBool := false
 		oidcConfig.GroupSearchEnabled = &falseBool
 	} else {
@@ -112,3 +120,40 @@ func (o *OpenIDCProvider) TestAndApply(actionName string, action *types.Action,
 
 	return o.TokenMGR.CreateTokenAndSetCookie(user.Name, userPrincipal, groupPrincipals, providerToken, 0, "Token via OIDC Configuration", request, userExtraInfo)
 }
+
+// validateUserPrefixChange rejects changes of the user prefixes of issuers once users with principals of the provider
+// exist.
+func (o *OpenIDCProvider) validateUserPrefixChange(config *v32.OIDCConfig) error {
+	stored, err := o.GetOIDCConfig()
+	if err != nil {
+		return err
+	}
+	if validateUserPrefixes(stored, config) == nil {
+		return nil
+	}
+	usersExist, err := o.hasUsers()
+	if err != nil {
+		return errors.Wrap(err, "[generic oidc]: failed to list users")
+	}
+	if !usersExist {
+		return nil
+	}
+	return validateUserPrefixes(stored, config)
+}
+
+// hasUsers returns whether a user has a principal of the provider.
+func (o *OpenIDCProvider) hasUsers() (bool, error) {
+	users, err := o.UserLister.List("", labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	prefix := o.Name + "_" + UserType + "://"
+	for _, user := range users {
+		for _, principalID := range user.PrincipalIDs {
+			if strings.HasPrefix(principalID, prefix) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
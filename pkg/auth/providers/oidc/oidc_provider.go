@@ -41,6 +41,7 @@ type OpenIDCProvider struct {
 	Secrets     corev1.SecretInterface
 	UserMGR     user.Manager
 	TokenMGR    *tokens.Manager
+	UserLister  v3.UserLister
 }
 
 type ClaimInfo struct {
@@ -64,6 +65,7 @@ func Configure(ctx context.Context, mgmtCtx *config.ScaledContext, userMGR user.
 		Secrets:     mgmtCtx.Core.Secrets(""),
 		UserMGR:     userMGR,
 		TokenMGR:    tokenMGR,
+		UserLister:  mgmtCtx.Management.Users("").Controller().Lister(),
 	}
 }
 
@@ -97,13 +99,21 @@ func (o *OpenIDCProvider) LoginUser(ctx context.Context, oauthLoginInfo *v32.OID
 			return userPrincipal, nil, "", userClaimInfo, err
 		}
 	}
-	userInfo, oauth2Token, err := o.getUserInfo(&ctx, config, oauthLoginInfo.Code, &userClaimInfo, "")
+	iss, err := getIssuer(config, oauthLoginInfo.Issuer)
 	if err != nil {
+		return userPrincipal, nil, "", userClaimInfo, err
+	}
+	userInfo, oauth2Token, err := o.getUserInfo(&ctx, iss.config, oauthLoginInfo.Code, &userClaimInfo, "")
+	if err != nil {
+		return userPrincipal, groupPrincipals, "", userClaimInfo, err
+	}
+	claims := map[string]interface{}{}
+	if err := userInfo.Claims(&claims); err != nil {
 		return userPrincipal, groupPrincipals, "", userClaimInfo, err
 	}
-	userPrincipal = o.userToPrincipal(userInfo, userClaimInfo)
+	userPrincipal = o.userToPrincipal(userInfo, claims, iss.mapping)
 	userPrincipal.Me = true
-	groupPrincipals = o.getGroupsFromClaimInfo(userClaimInfo)
+	groupPrincipals = o.getGroups(claims, userClaimInfo, iss.mapping)
 
 	logrus.Debugf("[generic oidc] loginuser: checking user's access to rancher")
 	allowed, err := o.UserMGR.CheckAccess(config.AccessMode, config.AllowedPrincipalIDs, userPrincipal.Name, groupPrincipals)
@@ -181,6 +191,24 @@ func (o *OpenIDCProvider) GetPrincipal(principalID string, token v3.Token) (v3.P
 func (o *OpenIDCProvider) TransformToAuthProvider(authConfig map[string]interface{}) (map[string]interface{}, error) {
 	p := common.TransformToAuthProvider(authConfig)
 	p[publicclient.OIDCProviderFieldRedirectURL] = o.getRedirectURL(authConfig)
+
+	issuerRedirectURLs := map[string]interface{}{}
+	additionalIssuers, _ := authConfig[client.OIDCConfigFieldAdditionalIssuers].([]interface{})
+	for _, additional := range additionalIssuers {
+		issuerConfig, ok := additional.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		redirectConfig := map[string]interface{}{
+			"authEndpoint": issuerConfig["authEndpoint"],
+			"clientId":     issuerConfig["clientId"],
+			"rancherUrl":   authConfig["rancherUrl"],
+		}
+		issuerRedirectURLs[convert.ToString(issuerConfig["name"])] = o.getRedirectURL(redirectConfig)
+	}
+	if len(issuerRedirectURLs) > 0 {
+		p[publicclient.OIDCProviderFieldIssuerRedirectURLs] = issuerRedirectURLs
+	}
 	return p, nil
 }
 
@@ -208,12 +236,18 @@ func (o *OpenIDCProvider) RefetchGroupPrincipals(principalID string, secret stri
 		logrus.Errorf("[generic oidc] refetchGroupPrincipals: error getting user by principalID: %v", err)
 		return groupPrincipals, err
 	}
-	//do not need userInfo or oauth2Token since we are only processing groups
-	_, _, err = o.getUserInfo(&o.CTX, config, secret, &claimInfo, user.Name)
+	// the refresh token is only valid for the issuer the user logged in with
+	iss := getIssuerOfPrincipal(config, o.Name, principalID)
+	//do not need oauth2Token since we are only processing groups
+	userInfo, _, err := o.getUserInfo(&o.CTX, iss.config, secret, &claimInfo, user.Name)
 	if err != nil {
 		return groupPrincipals, err
 	}
-	return o.getGroupsFromClaimInfo(claimInfo), nil
+	claims := map[string]interface{}{}
+	if err := userInfo.Claims(&claims); err != nil {
+		return groupPrincipals, err
+	}
+	return o.getGroups(claims, claimInfo, iss.mapping), nil
 }
 
 func (o *OpenIDCProvider) CanAccessWithGroupProviders(userPrincipalID string, groupPrincipals []v3.Principal) (bool, error) {
@@ -229,15 +263,24 @@ func (o *OpenIDCProvider) CanAccessWithGroupProviders(userPrincipalID string, gr
 	return allowed, nil
 }
 
-func (o *OpenIDCProvider) userToPrincipal(userInfo *oidc.UserInfo, claimInfo ClaimInfo) v3.Principal {
-	displayName := claimInfo.Name
+func (o *OpenIDCProvider) userToPrincipal(userInfo *oidc.UserInfo, claims map[string]interface{}, mapping v32.OIDCClaimMapping) v3.Principal {
+	usernameClaim := mapping.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = defaultUsernameClaim
+	}
+	displayNameClaim := mapping.DisplayNameClaim
+	if displayNameClaim == "" {
+		displayNameClaim = defaultDisplayNameClaim
+	}
+	loginName := claimValue(claims, usernameClaim)
+	displayName := claimValue(claims, displayNameClaim)
 	if displayName == "" {
-		displayName = userInfo.Email
+		displayName = loginName
 	}
 	p := v3.Principal{
-		ObjectMeta:    metav1.ObjectMeta{Name: o.Name + "_" + UserType + "://" + userInfo.Subject},
+		ObjectMeta:    metav1.ObjectMeta{Name: o.Name + "_" + UserType + "://" + mapping.UserPrefix + userInfo.Subject},
 		DisplayName:   displayName,
-		LoginName:     userInfo.Email,
+		LoginName:     loginName,
 		Provider:      o.Name,
 		PrincipalType: UserType,
 		Me:            false,
@@ -299,6 +342,17 @@ func (o *OpenIDCProvider) saveOIDCConfig(config *v32.OIDCConfig) error {
 	}
 	config.ClientSecret = common.GetFullSecretName(config.Type, secretField)
 
+	for i, additional := range config.AdditionalIssuers {
+		if additional.ClientSecret == "" || strings.HasPrefix(additional.ClientSecret, common.SecretsNamespace+":") {
+			continue
+		}
+		issuerSecretField := secretField + "-" + strings.ToLower(additional.Name)
+		if err := common.CreateOrUpdateSecrets(o.Secrets, additional.ClientSecret, issuerSecretField, strings.ToLower(config.Type)); err != nil {
+			return err
+		}
+		config.AdditionalIssuers[i].ClientSecret = common.GetFullSecretName(config.Type, issuerSecretField)
+	}
+
 	logrus.Debugf("[generic oidc] saveOIDCConfig: updating config")
 	_, err = o.AuthConfigs.ObjectClient().Update(config.ObjectMeta.Name, config)
 	return err
@@ -338,6 +392,18 @@ func (o *OpenIDCProvider) GetOIDCConfig() (*v32.OIDCConfig, error) {
 			storedOidcConfig.ClientSecret = string(v)
 		}
 	}
+	for i, additional := range storedOidcConfig.AdditionalIssuers {
+		if additional.ClientSecret == "" {
+			continue
+		}
+		data, err := common.ReadFromSecretData(o.Secrets, additional.ClientSecret)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range data {
+			storedOidcConfig.AdditionalIssuers[i].ClientSecret = string(v)
+		}
+	}
 
 	return storedOidcConfig, nil
 }
@@ -433,26 +499,28 @@ func ConfigToOauthConfig(endpoint oauth2.Endpoint, config *v32.OIDCConfig) oauth
 	}
 }
 
-func (o *OpenIDCProvider) getGroupsFromClaimInfo(claimInfo ClaimInfo) []v3.Principal {
-	var groupPrincipals []v3.Principal
-
-	if claimInfo.FullGroupPath != nil {
+// getGroups returns the group principals of the groups claim of the mapping, or of the full_group_path or groups
+// claims if the mapping has no groups claim.
+func (o *OpenIDCProvider) getGroups(claims map[string]interface{}, claimInfo ClaimInfo, mapping v32.OIDCClaimMapping) []v3.Principal {
+	var groups []string
+	if mapping.GroupsClaim != "" {
+		groups = claimValues(claims, mapping.GroupsClaim)
+	} else if claimInfo.FullGroupPath != nil {
 		for _, groupPath := range claimInfo.FullGroupPath {
-			groupsFromPath := strings.Split(groupPath, "/")
-			for _, group := range groupsFromPath {
-				if group != "" {
-					groupPrincipal := o.groupToPrincipal(group)
-					groupPrincipal.MemberOf = true
-					groupPrincipals = append(groupPrincipals, groupPrincipal)
-				}
-			}
+			groups = append(groups, strings.Split(groupPath, "/")...)
 		}
 	} else {
-		for _, group := range claimInfo.Groups {
-			groupPrincipal := o.groupToPrincipal(group)
-			groupPrincipal.MemberOf = true
-			groupPrincipals = append(groupPrincipals, groupPrincipal)
+		groups = claimInfo.Groups
+	}
+
+	var groupPrincipals []v3.Principal
+	for _, group := range groups {
+		if group == "" {
+			continue
 		}
+		groupPrincipal := o.groupToPrincipal(mapping.GroupPrefix + group)
+		groupPrincipal.MemberOf = true
+		groupPrincipals = append(groupPrincipals, groupPrincipal)
 	}
 	return groupPrincipals
 }
@@ -13,6 +13,7 @@
 	"github.com/rancher/norman/httperror"
 	"github.com/rancher/norman/types"
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/lockout"
 	"github.com/rancher/rancher/pkg/auth/providers"
 	"github.com/rancher/rancher/pkg/auth/providers/activedirectory"
 	"github.com/rancher/rancher/pkg/auth/providers/azure"
@@ -51,6 +52,7 @@ func newLoginHandler(ctx context.Context, mgmt *config.ScaledContext) *loginHand
 		tokenMGR:      tokens.NewManager(ctx, mgmt),
 		clusterLister: mgmt.Management.Clusters("").Controller().Lister(),
 		secretLister:  mgmt.Core.Secrets("").Controller().Lister(),
+		limiter:       lockout.NewLimiter(ctx, mgmt),
 	}
 }
 
@@ -60,6 +62,7 @@ type loginHandler struct {
 	tokenMGR      *tokens.Manager
 	clusterLister v3.ClusterLister
 	secretLister  v1.SecretLister
+	limiter       *lockout.Limiter
 }
 
 func (h *loginHandler) login(actionName string, action *types.Action, request *types.APIContext) error {
@@ -195,8 +198,24 @@ func (h *loginHandler) createLoginToken(request *types.APIContext) (v3.Token, st
 		return v3.Token{}, "", "saml", err
 	}
 
+	// logins with a username and password are protected against guessing the password
+	basicLogin, isBasicLogin := input.(*v32.BasicLogin)
+	sourceIP := util.SourceIP(request.Request)
+	if isBasicLogin {
+		if err := h.limiter.Check(request.Request.Context(), basicLogin.Username, sourceIP); err != nil {
+			return v3.Token{}, "", "", err
+		}
+	}
+
 	ctx := context.WithValue(request.Request.Context(), util.RequestKey, request.Request)
 	userPrincipal, groupPrincipals, providerToken, err = providers.AuthenticateUser(ctx, input, providerName)
+	if isBasicLogin {
+		if err == nil {
+			h.limiter.Success(basicLogin.Username)
+		} else if isFailedLogin(err) {
+			h.limiter.Failure(request.Request.Context(), basicLogin.Username, sourceIP)
+		}
+	}
 	if err != nil {
 		return v3.Token{}, "", "", err
 	}
@@ -233,6 +252,16 @@ func (h *loginHandler) createLoginToken(request *types.APIContext) (v3.Token, st
 	return rToken, unhashedTokenKey, responseType, err
 }
 
+// isFailedLogin returns true if the login failed because of invalid credentials. Logins of users that have yet to
+// provide their multi-factor authentication code aren't failed.
+func isFailedLogin(err error) bool {
+	apiError, ok := err.(*httperror.APIError)
+	if !ok || apiError.Code.Status != http.StatusUnauthorized {
+		return false
+	}
+	return apiError.Code != local.MFARequired && apiError.Code != local.MFAEnrollmentRequired
+}
+
 // createClusterAuthTokenIfNeeded checks if local cluster auth endpoint is enabled. If it is, a cluster auth token
 // is created.
 func (h *loginHandler) createClusterAuthTokenIfNeeded(token *v3.Token, tokenValue string) error {
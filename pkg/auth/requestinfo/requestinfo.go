@@ -0,0 +1,176 @@
+// Package requestinfo derives the verb and resource targeted by requests to the Rancher APIs.
+package requestinfo
+
+import (
+	"net/http"
+	"strings"
+)
+
+const managementGroup = "management.cattle.io"
+
+// Info describes the resource a request targets, as far as it can be derived from its URI.
+type Info struct {
+	Verb     string
+	APIGroup string
+	Resource string
+	// Namespace is the namespace in the request path.
+	Namespace string
+	// NamespaceOrName is x of Steve paths of the form /v1/<type>/<x>, which is a namespace for namespaced types and
+	// the name of an object for cluster scoped types.
+	NamespaceOrName string
+	// Project is the project ID (<cluster>:<project>) of Norman project scoped requests.
+	Project string
+}
+
+// Parse derives the verb, API group, resource, namespace and project of a request made to the Norman (/v3), Steve
+// (/v1) or proxied kubernetes (/k8s/clusters/<id>) APIs. Fields that can't be determined are left empty.
+func Parse(req *http.Request) Info {
+	parts := splitPath(req.URL.Path)
+
+	var (
+		info  Info
+		named bool
+	)
+	switch {
+	case len(parts) >= 2 && parts[0] == "v3":
+		info.APIGroup = managementGroup
+		info.Resource, info.Project, named = parseNormanPath(parts[1:])
+	case len(parts) >= 2 && parts[0] == "v1":
+		info.APIGroup, info.Resource, info.Namespace, info.NamespaceOrName, named = parseStevePath(parts[1:])
+	case len(parts) >= 5 && parts[0] == "k8s" && parts[1] == "clusters" && parts[3] == "v1":
+		// Steve of a downstream cluster.
+		info.APIGroup, info.Resource, info.Namespace, info.NamespaceOrName, named = parseStevePath(parts[4:])
+	case len(parts) >= 3 && parts[0] == "k8s" && parts[1] == "clusters":
+		info.APIGroup, info.Resource, info.Namespace, named = parseKubernetesPath(parts[3:])
+	}
+
+	info.Verb = verbFor(req, named)
+	return info
+}
+
+// parseNormanPath handles /v3/<type>[/<id>] as well as cluster and project scoped /v3/<scope>/<id>/<type>[/<id>].
+func parseNormanPath(parts []string) (string, string, bool) {
+	switch {
+	case len(parts) >= 3 && (parts[0] == "project" || parts[0] == "projects"):
+		return parts[2], parts[1], len(parts) > 3
+	case len(parts) >= 3 && (parts[0] == "cluster" || parts[0] == "clusters"):
+		return parts[2], "", len(parts) > 3
+	case len(parts) == 2 && parts[0] == "projects":
+		return parts[0], parts[1], true
+	}
+	return parts[0], "", len(parts) > 1
+}
+
+// parseStevePath handles <type>[/<namespace>][/<name>] paths of the Steve API. It returns the group, resource,
+// namespace, and the namespace or name of <type>/<x> paths.
+func parseStevePath(parts []string) (string, string, string, string, bool) {
+	group, resource := splitSteveType(parts[0])
+	switch {
+	case len(parts) > 2:
+		return group, resource, parts[1], "", true
+	case len(parts) == 2:
+		// /v1/<type>/<namespace> can't be told apart from /v1/<type>/<name> without knowing whether the type is
+		// namespaced, so it is treated as a request for a single object.
+		return group, resource, "", parts[1], true
+	}
+	return group, resource, "", "", false
+}
+
+// splitSteveType splits a Steve type such as management.cattle.io.setting or apps.deployment into group and resource.
+func splitSteveType(steveType string) (string, string) {
+	i := strings.LastIndex(steveType, ".")
+	if i < 0 {
+		return "", steveType
+	}
+	return steveType[:i], steveType[i+1:]
+}
+
+// parseKubernetesPath handles api/v1/... and apis/<group>/<version>/... paths of the proxied kubernetes API.
+func parseKubernetesPath(parts []string) (string, string, string, bool) {
+	var group string
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		group = parts[1]
+		parts = parts[3:]
+	default:
+		return "", "", "", false
+	}
+
+	var namespace string
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		namespace = parts[1]
+		parts = parts[2:]
+	} else if len(parts) == 2 && parts[0] == "namespaces" {
+		return group, "namespaces", parts[1], true
+	}
+	if len(parts) == 0 {
+		return group, "", namespace, false
+	}
+	return group, parts[0], namespace, len(parts) > 1
+}
+
+func verbFor(req *http.Request, named bool) string {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		if req.URL.Query().Get("watch") == "true" {
+			return "watch"
+		}
+		if named {
+			return "get"
+		}
+		return "list"
+	case http.MethodPost:
+		// Norman actions are POST requests, other methods ignore the action parameter.
+		if action := req.URL.Query().Get("action"); action != "" {
+			return action
+		}
+		return "create"
+	case http.MethodPut:
+		return "update"
+	case http.MethodPatch:
+		return "patch"
+	case http.MethodDelete:
+		return "delete"
+	}
+	return strings.ToLower(req.Method)
+}
+
+func splitPath(path string) []string {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// ContainsResource matches resource names case-insensitively, treating singular and plural forms as equal since Steve
+// uses singular type names while Norman and kubernetes use plural ones.
+func ContainsResource(resources []string, resource string) bool {
+	if resource == "" {
+		return false
+	}
+	resource = singular(resource)
+	for _, r := range resources {
+		if singular(r) == resource {
+			return true
+		}
+	}
+	return false
+}
+
+func singular(resource string) string {
+	resource = strings.ToLower(resource)
+	switch {
+	case strings.HasSuffix(resource, "ies"):
+		return strings.TrimSuffix(resource, "ies") + "y"
+	case strings.HasSuffix(resource, "sses"), strings.HasSuffix(resource, "ches"), strings.HasSuffix(resource, "shes"), strings.HasSuffix(resource, "xes"):
+		return strings.TrimSuffix(resource, "es")
+	case strings.HasSuffix(resource, "s") && !strings.HasSuffix(resource, "ss"):
+		return strings.TrimSuffix(resource, "s")
+	}
+	return resource
+}
@@ -0,0 +1,50 @@
+package requestinfo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		method string
+		uri    string
+		want   Info
+	}{
+		{http.MethodGet, "/v3/settings", Info{Verb: "list", APIGroup: "management.cattle.io", Resource: "settings"}},
+		{http.MethodPut, "/v3/settings/server-url", Info{Verb: "update", APIGroup: "management.cattle.io", Resource: "settings"}},
+		{http.MethodPost, "/v3/clusters/c-abc12?action=generateKubeconfig", Info{Verb: "generateKubeconfig", APIGroup: "management.cattle.io", Resource: "clusters"}},
+		{http.MethodDelete, "/v3/clusters/c-abc12?action=get", Info{Verb: "delete", APIGroup: "management.cattle.io", Resource: "clusters"}},
+		{http.MethodGet, "/v1/management.cattle.io.clusters?action=create", Info{Verb: "list", APIGroup: "management.cattle.io", Resource: "clusters"}},
+		{http.MethodGet, "/v3/cluster/c-abc12/namespaces", Info{Verb: "list", APIGroup: "management.cattle.io", Resource: "namespaces"}},
+		{http.MethodGet, "/v3/project/c-abc12:p-xyz/secrets/p-xyz:my-secret", Info{Verb: "get", APIGroup: "management.cattle.io", Resource: "secrets", Project: "c-abc12:p-xyz"}},
+		{http.MethodDelete, "/v3/projects/c-abc12:p-xyz", Info{Verb: "delete", APIGroup: "management.cattle.io", Resource: "projects", Project: "c-abc12:p-xyz"}},
+		{http.MethodGet, "/v1/management.cattle.io.settings", Info{Verb: "list", APIGroup: "management.cattle.io", Resource: "settings"}},
+		{http.MethodDelete, "/v1/secrets/default/my-secret", Info{Verb: "delete", Resource: "secrets", Namespace: "default"}},
+		{http.MethodGet, "/v1/catalog.cattle.io.apps/team-a", Info{Verb: "get", APIGroup: "catalog.cattle.io", Resource: "apps", NamespaceOrName: "team-a"}},
+		{http.MethodGet, "/k8s/clusters/c-abc12/v1/catalog.cattle.io.apps/team-a/my-app", Info{Verb: "get", APIGroup: "catalog.cattle.io", Resource: "apps", Namespace: "team-a"}},
+		{http.MethodGet, "/v1/apps.deployments?watch=true", Info{Verb: "watch", APIGroup: "apps", Resource: "deployments"}},
+		{http.MethodGet, "/k8s/clusters/c-abc12/api/v1/namespaces/default/secrets/my-secret", Info{Verb: "get", Resource: "secrets", Namespace: "default"}},
+		{http.MethodPatch, "/k8s/clusters/local/apis/apps/v1/namespaces/default/deployments/web", Info{Verb: "patch", APIGroup: "apps", Resource: "deployments", Namespace: "default"}},
+		{http.MethodGet, "/k8s/clusters/local/api/v1/namespaces/default", Info{Verb: "get", Resource: "namespaces", Namespace: "default"}},
+		{http.MethodGet, "/k8s/clusters/local/api/v1/nodes", Info{Verb: "list", Resource: "nodes"}},
+		{http.MethodGet, "/healthz", Info{Verb: "list"}},
+	}
+	for _, test := range tests {
+		t.Run(test.method+" "+test.uri, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, test.uri, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, Parse(req))
+		})
+	}
+}
+
+func TestContainsResource(t *testing.T) {
+	assert.True(t, ContainsResource([]string{"secrets"}, "secret"))
+	assert.True(t, ContainsResource([]string{"Policies"}, "policy"))
+	assert.True(t, ContainsResource([]string{"ingress"}, "ingresses"))
+	assert.False(t, ContainsResource([]string{"secrets"}, "configmaps"))
+	assert.False(t, ContainsResource([]string{"secrets"}, ""))
+}
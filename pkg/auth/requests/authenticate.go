@@ -17,6 +17,7 @@
 	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/client-go/tools/cache"
 )
@@ -69,6 +70,10 @@ func NewAuthenticator(ctx context.Context, clusterRouter ClusterRouter, mgmtCtx
 		userLister:          mgmtCtx.Management.Users("").Controller().Lister(),
 		clusterRouter:       clusterRouter,
 		userAuthRefresher:   providerrefresh.NewUserAuthRefresher(ctx, mgmtCtx),
+		scaledContext:       mgmtCtx,
+		namespaceProjects:   newNamespaceProjectCache(),
+		namespacedResources: newNamespacedResourcesCache(),
+		usage:               newUsageRecorder(mgmtCtx.Management.Tokens("")),
 	}
 }
 
@@ -81,6 +86,10 @@ type tokenAuthenticator struct {
 	userLister          v3.UserLister
 	clusterRouter       ClusterRouter
 	userAuthRefresher   providerrefresh.UserAuthRefresher
+	scaledContext       *config.ScaledContext
+	namespaceProjects   *utilcache.LRUExpireCache
+	namespacedResources *utilcache.LRUExpireCache
+	usage               *usageRecorder
 }
 
 const (
@@ -111,6 +120,9 @@ func (a *tokenAuthenticator) Authenticate(req *http.Request) (*AuthenticatorResp
 	if token.ClusterName != "" && token.ClusterName != a.clusterRouter(req) {
 		return nil, errors.Wrapf(ErrMustAuthenticate, "clusterID does not match")
 	}
+	if err := a.checkScopes(token, req); err != nil {
+		return nil, err
+	}
 
 	attribs, err := a.userAttributeLister.Get("", token.UserID)
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -153,6 +165,8 @@ func (a *tokenAuthenticator) Authenticate(req *http.Request) (*AuthenticatorResp
 		go a.userAuthRefresher.TriggerUserRefresh(token.UserID, false)
 	}
 
+	a.usage.record(token, req)
+
 	authResp.IsAuthed = true
 	authResp.User = token.UserID
 	authResp.UserPrincipal = token.UserPrincipal.Name
@@ -0,0 +1,194 @@
+package requests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	apiv3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/requestinfo"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/strings/slices"
+)
+
+const (
+	projectIDAnnotation = "field.cattle.io/projectId"
+	localCluster        = "local"
+	namespaceCacheSize  = 1000
+	namespaceCacheTTL   = 30 * time.Second
+	discoveryCacheSize  = 100
+	discoveryCacheTTL   = 10 * time.Minute
+)
+
+// namespaceProjectFunc returns the project ID of a namespace in a cluster, empty if the namespace belongs to no project.
+type namespaceProjectFunc func(clusterID, namespace string) (string, error)
+
+// namespacedFunc returns true if a resource of a cluster is namespaced.
+type namespacedFunc func(clusterID, group, resource string) (bool, error)
+
+// userContextGetter is implemented by the cluster manager set as the scaled context's client getter.
+type userContextGetter interface {
+	UserContextNoControllers(clusterName string) (*config.UserContext, error)
+}
+
+// checkScopes rejects requests not allowed by any of the token's scopes.
+func (a *tokenAuthenticator) checkScopes(token *v3.Token, req *http.Request) error {
+	if len(token.Scopes) == 0 {
+		return nil
+	}
+
+	info := requestinfo.Parse(req)
+	for i := range token.Scopes {
+		allowed, err := scopeAllows(&token.Scopes[i], info, a.clusterRouter(req), a.namespaceProject, a.namespaced)
+		if err != nil {
+			return errors.Wrapf(ErrMustAuthenticate, "failed to evaluate token scopes: %v", err)
+		}
+		if allowed {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrMustAuthenticate, "token scopes do not allow %s of %s", info.Verb, info.Resource)
+}
+
+func scopeAllows(scope *apiv3.TokenScope, info requestinfo.Info, clusterID string, namespaceProject namespaceProjectFunc, namespaced namespacedFunc) (bool, error) {
+	if len(scope.Verbs) > 0 && !containsFold(scope.Verbs, info.Verb) {
+		return false, nil
+	}
+	if len(scope.APIGroups) > 0 && !slices.Contains(scope.APIGroups, info.APIGroup) {
+		return false, nil
+	}
+	if len(scope.Resources) > 0 && !requestinfo.ContainsResource(scope.Resources, info.Resource) {
+		return false, nil
+	}
+	if len(scope.Projects) == 0 {
+		return true, nil
+	}
+
+	if clusterID == "" {
+		clusterID = localCluster
+	}
+	project := info.Project
+	if project == "" {
+		namespace := info.Namespace
+		if namespace == "" && info.NamespaceOrName != "" {
+			// the x of /v1/<type>/<x> is only a namespace if the type is namespaced, otherwise it is the name of a
+			// cluster scoped object that belongs to no project
+			isNamespaced, err := namespaced(clusterID, info.APIGroup, info.Resource)
+			if err != nil || !isNamespaced {
+				return false, err
+			}
+			namespace = info.NamespaceOrName
+		}
+		if namespace == "" {
+			return false, nil
+		}
+		var err error
+		project, err = namespaceProject(clusterID, namespace)
+		if err != nil || project == "" {
+			return false, err
+		}
+	}
+	return slices.Contains(scope.Projects, project), nil
+}
+
+// namespaceProject looks up the project of a namespace, caching results briefly since scoped tokens are typically used
+// for bursts of requests against the same namespaces.
+func (a *tokenAuthenticator) namespaceProject(clusterID, namespace string) (string, error) {
+	key := clusterID + "/" + namespace
+	if project, ok := a.namespaceProjects.Get(key); ok {
+		return project.(string), nil
+	}
+
+	client, err := a.clusterClient(clusterID)
+	if err != nil {
+		return "", err
+	}
+	ns, err := client.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
+	if err != nil {
+		logrus.Debugf("Failed to get namespace [%s] of cluster [%s] for token scope check: %v", namespace, clusterID, err)
+		// Namespaces that can't be found belong to no project.
+		return "", nil
+	}
+
+	project := ns.Annotations[projectIDAnnotation]
+	a.namespaceProjects.Add(key, project, namespaceCacheTTL)
+	return project, nil
+}
+
+// namespaced looks up whether a resource is namespaced in the discovery information of the cluster, which is cached
+// per cluster.
+func (a *tokenAuthenticator) namespaced(clusterID, group, resource string) (bool, error) {
+	var resources map[string]bool
+	if cached, ok := a.namespacedResources.Get(clusterID); ok {
+		resources = cached.(map[string]bool)
+	} else {
+		client, err := a.clusterClient(clusterID)
+		if err != nil {
+			return false, err
+		}
+		lists, err := client.Discovery().ServerPreferredResources()
+		if err != nil && len(lists) == 0 {
+			return false, err
+		}
+		resources = map[string]bool{}
+		for _, list := range lists {
+			gv, err := schema.ParseGroupVersion(list.GroupVersion)
+			if err != nil {
+				continue
+			}
+			for _, r := range list.APIResources {
+				resources[gv.Group+"/"+r.Name] = r.Namespaced
+			}
+		}
+		a.namespacedResources.Add(clusterID, resources, discoveryCacheTTL)
+	}
+
+	for key, namespaced := range resources {
+		i := strings.Index(key, "/")
+		if key[:i] == group && requestinfo.ContainsResource([]string{key[i+1:]}, resource) {
+			return namespaced, nil
+		}
+	}
+	return false, nil
+}
+
+func (a *tokenAuthenticator) clusterClient(clusterID string) (kubernetes.Interface, error) {
+	if clusterID == localCluster {
+		return a.scaledContext.K8sClient, nil
+	}
+	getter, ok := a.scaledContext.ClientGetter.(userContextGetter)
+	if !ok {
+		return nil, fmt.Errorf("no client available for cluster [%s]", clusterID)
+	}
+	userContext, err := getter.UserContextNoControllers(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return userContext.K8sClient, nil
+}
+
+func newNamespaceProjectCache() *utilcache.LRUExpireCache {
+	return utilcache.NewLRUExpireCache(namespaceCacheSize)
+}
+
+func newNamespacedResourcesCache() *utilcache.LRUExpireCache {
+	return utilcache.NewLRUExpireCache(discoveryCacheSize)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
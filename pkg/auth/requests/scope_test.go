@@ -0,0 +1,84 @@
+package requests
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/requestinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopeAllows(t *testing.T) {
+	namespaces := map[string]string{
+		"local/team-a":  "local:p-team",
+		"c-abc12/web":   "c-abc12:p-xyz",
+		"c-abc12/other": "c-abc12:p-other",
+	}
+	namespaceProject := func(clusterID, namespace string) (string, error) {
+		if namespace == "broken" {
+			return "", errors.New("unavailable")
+		}
+		return namespaces[clusterID+"/"+namespace], nil
+	}
+
+	namespaced := func(clusterID, group, resource string) (bool, error) {
+		return resource != "clusters" && resource != "namespaces", nil
+	}
+
+	appsInProject := v3.TokenScope{
+		Verbs:     []string{"get", "list"},
+		APIGroups: []string{"catalog.cattle.io"},
+		Resources: []string{"apps"},
+		Projects:  []string{"c-abc12:p-xyz", "local:p-team"},
+	}
+	readSettings := v3.TokenScope{Verbs: []string{"GET", "list"}, Resources: []string{"settings"}}
+
+	tests := []struct {
+		name    string
+		scope   v3.TokenScope
+		method  string
+		uri     string
+		want    bool
+		wantErr bool
+	}{
+		{name: "steve list in project namespace", scope: appsInProject, method: http.MethodGet, uri: "/k8s/clusters/c-abc12/v1/catalog.cattle.io.apps/web", want: true},
+		{name: "steve get in local project namespace", scope: appsInProject, method: http.MethodGet, uri: "/v1/catalog.cattle.io.apps/team-a/my-app", want: true},
+		{name: "proxied kubernetes in project namespace", scope: appsInProject, method: http.MethodGet, uri: "/k8s/clusters/c-abc12/apis/catalog.cattle.io/v1/namespaces/web/apps", want: true},
+		{name: "namespace of another project", scope: appsInProject, method: http.MethodGet, uri: "/k8s/clusters/c-abc12/apis/catalog.cattle.io/v1/namespaces/other/apps", want: false},
+		{name: "namespace without project", scope: appsInProject, method: http.MethodGet, uri: "/v1/catalog.cattle.io.apps/kube-system", want: false},
+		{name: "all namespaces", scope: appsInProject, method: http.MethodGet, uri: "/v1/catalog.cattle.io.apps", want: false},
+		{name: "verb not allowed", scope: appsInProject, method: http.MethodDelete, uri: "/v1/catalog.cattle.io.apps/team-a/my-app", want: false},
+		{name: "resource not allowed", scope: appsInProject, method: http.MethodGet, uri: "/v1/secrets/team-a/my-secret", want: false},
+		{name: "norman project path", scope: v3.TokenScope{Projects: []string{"c-abc12:p-xyz"}}, method: http.MethodGet, uri: "/v3/project/c-abc12:p-xyz/workloads", want: true},
+		{name: "norman other project", scope: v3.TokenScope{Projects: []string{"c-abc12:p-xyz"}}, method: http.MethodGet, uri: "/v3/project/c-abc12:p-other/workloads", want: false},
+		{name: "unscoped by project", scope: readSettings, method: http.MethodGet, uri: "/v3/settings/server-url", want: true},
+		{name: "cluster scoped object named like a project namespace", scope: v3.TokenScope{Projects: []string{"local:p-team"}}, method: http.MethodGet, uri: "/v1/management.cattle.io.clusters/team-a", want: false},
+		{name: "lookup failure", scope: appsInProject, method: http.MethodGet, uri: "/v1/catalog.cattle.io.apps/broken", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, test.uri, nil)
+			require.NoError(t, err)
+
+			allowed, err := scopeAllows(&test.scope, requestinfo.Parse(req), clusterFromPath(test.uri), namespaceProject, namespaced)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, allowed)
+		})
+	}
+}
+
+func clusterFromPath(uri string) string {
+	parts := strings.Split(uri, "/")
+	if len(parts) > 3 && parts[1] == "k8s" && parts[2] == "clusters" {
+		return parts[3]
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package requests
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rancher/rancher/pkg/auth/util"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// lastUsedUpdateInterval throttles how often the last use of a token is written, a busy token would otherwise cause
+// a write for every request.
+const lastUsedUpdateInterval = time.Minute
+
+// usageRecorder records the last use of tokens on the token objects.
+type usageRecorder struct {
+	tokens v3.TokenInterface
+
+	lock sync.Mutex
+	// recorded holds the time usage was last written per token, covering the time until the write reaches the cache.
+	recorded map[string]time.Time
+}
+
+func newUsageRecorder(tokens v3.TokenInterface) *usageRecorder {
+	return &usageRecorder{
+		tokens:   tokens,
+		recorded: map[string]time.Time{},
+	}
+}
+
+// record updates the token's last use in the background unless it was updated within lastUsedUpdateInterval.
+func (u *usageRecorder) record(token *v3.Token, req *http.Request) {
+	now := time.Now()
+	if lastUsed, err := time.Parse(time.RFC3339, token.LastUsedAt); err == nil && now.Sub(lastUsed) < lastUsedUpdateInterval {
+		return
+	}
+	if !u.shouldRecord(token.Name, now) {
+		return
+	}
+
+	go func() {
+		patch, err := json.Marshal(map[string]string{
+			"lastUsedAt":   now.UTC().Format(time.RFC3339),
+			"lastUsedFrom": util.SourceIP(req),
+		})
+		if err != nil {
+			return
+		}
+		if _, err := u.tokens.ObjectClient().Patch(token.Name, token, types.MergePatchType, patch); err != nil {
+			logrus.Debugf("Failed to record last use of token %s: %v", token.Name, err)
+		}
+	}()
+}
+
+func (u *usageRecorder) shouldRecord(tokenName string, now time.Time) bool {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if last, ok := u.recorded[tokenName]; ok && now.Sub(last) < lastUsedUpdateInterval {
+		return false
+	}
+	// Entries older than the interval no longer throttle anything.
+	for name, last := range u.recorded {
+		if now.Sub(last) >= lastUsedUpdateInterval {
+			delete(u.recorded, name)
+		}
+	}
+	u.recorded[tokenName] = now
+	return true
+}
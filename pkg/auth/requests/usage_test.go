@@ -0,0 +1,22 @@
+package requests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageRecorderThrottles(t *testing.T) {
+	u := newUsageRecorder(nil)
+	now := time.Now()
+
+	assert.True(t, u.shouldRecord("token-a", now))
+	assert.False(t, u.shouldRecord("token-a", now.Add(30*time.Second)))
+	assert.True(t, u.shouldRecord("token-b", now.Add(30*time.Second)))
+	assert.True(t, u.shouldRecord("token-a", now.Add(lastUsedUpdateInterval)))
+	// Entries are only pruned once their interval passed.
+	assert.Len(t, u.recorded, 2)
+	assert.True(t, u.shouldRecord("token-c", now.Add(2*lastUsedUpdateInterval)))
+	assert.Len(t, u.recorded, 1)
+}
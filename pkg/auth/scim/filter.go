@@ -0,0 +1,51 @@
+package scim
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var filterRegexp = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// filter is a SCIM filter of the form `attribute eq "value"`, the form identity providers use to look up resources
+// before they create them.
+type filter struct {
+	attribute string
+	value     string
+}
+
+// parseFilter parses the filter query parameter, an empty filter matches all resources.
+func parseFilter(s string) (*filter, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	match := filterRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return nil, badRequest("invalidFilter", "unsupported filter %q, only `attribute eq \"value\"` is supported", s)
+	}
+	var value string
+	if err := json.Unmarshal([]byte(match[2]), &value); err != nil {
+		return nil, badRequest("invalidFilter", "invalid filter value %s", match[2])
+	}
+	return &filter{
+		attribute: strings.ToLower(match[1]),
+		value:     value,
+	}, nil
+}
+
+// matches returns true if the filter matches the attributes of a resource, keyed by their lowercased names. IDs are
+// case-exact, the other attributes are compared case-insensitively.
+func (f *filter) matches(attributes map[string]string) bool {
+	if f == nil {
+		return true
+	}
+	value, ok := attributes[f.attribute]
+	if !ok {
+		return false
+	}
+	if f.attribute == "id" || f.attribute == "externalid" {
+		return value == f.value
+	}
+	return strings.EqualFold(value, f.value)
+}
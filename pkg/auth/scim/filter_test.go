@@ -0,0 +1,71 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		want    *filter
+		wantErr bool
+	}{
+		{
+			name:   "empty",
+			filter: "",
+		},
+		{
+			name:   "eq",
+			filter: `userName eq "alice@example.com"`,
+			want:   &filter{attribute: "username", value: "alice@example.com"},
+		},
+		{
+			name:   "case-insensitive operator",
+			filter: `displayName EQ "Team \"A\""`,
+			want:   &filter{attribute: "displayname", value: `Team "A"`},
+		},
+		{
+			name:    "unsupported operator",
+			filter:  `userName co "alice"`,
+			wantErr: true,
+		},
+		{
+			name:    "logical expression",
+			filter:  `userName eq "alice" and active eq "true"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.filter)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, "invalidFilter", err.(*apiError).scimType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	attributes := map[string]string{
+		"id":         "u-abc",
+		"username":   "Alice@example.com",
+		"externalid": "Ext-1",
+	}
+
+	var all *filter
+	assert.True(t, all.matches(attributes))
+	assert.True(t, (&filter{attribute: "username", value: "alice@EXAMPLE.com"}).matches(attributes))
+	assert.True(t, (&filter{attribute: "externalid", value: "Ext-1"}).matches(attributes))
+	assert.False(t, (&filter{attribute: "externalid", value: "ext-1"}).matches(attributes))
+	assert.False(t, (&filter{attribute: "id", value: "U-ABC"}).matches(attributes))
+	assert.False(t, (&filter{attribute: "displayname", value: "Alice"}).matches(attributes))
+}
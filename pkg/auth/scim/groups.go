@@ -0,0 +1,357 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	apimgmtv3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+)
+
+var memberFilterRegexp = regexp.MustCompile(`^members\[\s*value\s+(?i:eq)\s+"([^"]*)"\s*\]$`)
+
+func (h *Handler) listGroups(provider string, req *http.Request) (int, interface{}, error) {
+	groups, err := h.providerGroups(provider)
+	if err != nil {
+		return 0, nil, err
+	}
+	excludeMembers := strings.Contains(strings.ToLower(req.URL.Query().Get("excludedAttributes")), "members")
+
+	resources := make([]resource, 0, len(groups))
+	for _, group := range groups {
+		scimGroup := h.toSCIMGroup(provider, group)
+		if excludeMembers {
+			scimGroup.Members = nil
+		}
+		resources = append(resources, resource{
+			id: group.Name,
+			attributes: map[string]string{
+				"id":          group.Name,
+				"displayname": group.Spec.DisplayName,
+				"externalid":  group.Spec.ExternalID,
+			},
+			value: scimGroup,
+		})
+	}
+	return listResponse(req, resources)
+}
+
+func (h *Handler) getGroup(provider string, req *http.Request) (int, interface{}, error) {
+	group, err := h.getProviderGroup(provider, mux.Vars(req)["id"])
+	if err != nil {
+		return 0, nil, err
+	}
+	scimGroup := h.toSCIMGroup(provider, group)
+	if strings.Contains(strings.ToLower(req.URL.Query().Get("excludedAttributes")), "members") {
+		scimGroup.Members = nil
+	}
+	return http.StatusOK, scimGroup, nil
+}
+
+func (h *Handler) createGroup(provider string, req *http.Request) (int, interface{}, error) {
+	var input Group
+	if err := decode(req, &input); err != nil {
+		return 0, nil, err
+	}
+	if err := h.validateGroup(provider, &input, ""); err != nil {
+		return 0, nil, err
+	}
+
+	group, err := h.groups.Create(&apimgmtv3.SCIMGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "scimgroup-",
+			Labels:       map[string]string{providerLabel: provider},
+		},
+		Spec: groupSpec(provider, &input),
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := h.syncMembers(provider, nil, group.Spec.Members); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, h.toSCIMGroup(provider, group), nil
+}
+
+func (h *Handler) replaceGroup(provider string, req *http.Request) (int, interface{}, error) {
+	group, err := h.getProviderGroup(provider, mux.Vars(req)["id"])
+	if err != nil {
+		return 0, nil, err
+	}
+	var input Group
+	if err := decode(req, &input); err != nil {
+		return 0, nil, err
+	}
+	return h.saveGroup(provider, group, &input)
+}
+
+func (h *Handler) patchGroup(provider string, req *http.Request) (int, interface{}, error) {
+	group, err := h.getProviderGroup(provider, mux.Vars(req)["id"])
+	if err != nil {
+		return 0, nil, err
+	}
+	var patch PatchRequest
+	if err := decode(req, &patch); err != nil {
+		return 0, nil, err
+	}
+
+	scimGroup := h.toSCIMGroup(provider, group)
+	for _, op := range patch.Operations {
+		if err := patchGroup(&scimGroup, op); err != nil {
+			return 0, nil, err
+		}
+	}
+	return h.saveGroup(provider, group, &scimGroup)
+}
+
+func (h *Handler) saveGroup(provider string, group *apimgmtv3.SCIMGroup, input *Group) (int, interface{}, error) {
+	if err := h.validateGroup(provider, input, group.Name); err != nil {
+		return 0, nil, err
+	}
+
+	oldMembers := group.Spec.Members
+	var updated *apimgmtv3.SCIMGroup
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := h.groups.Get(group.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current = current.DeepCopy()
+		oldMembers = current.Spec.Members
+		current.Spec = groupSpec(provider, input)
+		updated, err = h.groups.Update(current)
+		return err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	// the principal and display name of the group may have changed, so the attributes of all members are updated
+	if err := h.syncMembers(provider, oldMembers, updated.Spec.Members); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, h.toSCIMGroup(provider, updated), nil
+}
+
+func (h *Handler) deleteGroup(provider string, req *http.Request) (int, interface{}, error) {
+	group, err := h.getProviderGroup(provider, mux.Vars(req)["id"])
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := h.groups.Delete(group.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return 0, nil, err
+	}
+	if err := h.syncMembers(provider, group.Spec.Members, nil); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+// providerGroups returns the SCIMGroups of the auth provider.
+func (h *Handler) providerGroups(provider string) ([]*apimgmtv3.SCIMGroup, error) {
+	return h.groupCache.List(labels.SelectorFromSet(labels.Set{providerLabel: provider}))
+}
+
+// getProviderGroup returns the SCIMGroup if it belongs to the auth provider.
+func (h *Handler) getProviderGroup(provider, id string) (*apimgmtv3.SCIMGroup, error) {
+	group, err := h.groupCache.Get(id)
+	if apierrors.IsNotFound(err) || (err == nil && group.Spec.Provider != provider) {
+		return nil, notFound(groupResourceType, id)
+	}
+	return group, err
+}
+
+// updateGroupMembers updates the members of the SCIMGroup with the result of update.
+func (h *Handler) updateGroupMembers(name string, update func(members []string) []string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		group, err := h.groups.Get(name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		group = group.DeepCopy()
+		group.Spec.Members = update(group.Spec.Members)
+		_, err = h.groups.Update(group)
+		return err
+	})
+}
+
+// validateGroup checks that the display name is set and unique among the groups of the auth provider, and that all
+// members are users of the auth provider.
+func (h *Handler) validateGroup(provider string, input *Group, id string) error {
+	if strings.TrimSpace(input.DisplayName) == "" {
+		return badRequest("invalidValue", "displayName is required")
+	}
+	groups, err := h.providerGroups(provider)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		if group.Name != id && strings.EqualFold(group.Spec.DisplayName, input.DisplayName) {
+			return conflict("group %s already exists", input.DisplayName)
+		}
+	}
+	for _, member := range input.Members {
+		if _, err := h.getProviderUser(provider, member.Value); err != nil {
+			if _, ok := err.(*apiError); ok {
+				return badRequest("invalidValue", "member %s is not a user of %s", member.Value, provider)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// syncMembers updates the UserAttributes of the users that were or are members of a group.
+func (h *Handler) syncMembers(provider string, oldMembers, newMembers []string) error {
+	seen := map[string]bool{}
+	for _, name := range append(append([]string{}, oldMembers...), newMembers...) {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		u, err := h.userLister.Get("", name)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := h.syncUserAttribute(provider, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) toSCIMGroup(provider string, group *apimgmtv3.SCIMGroup) Group {
+	scimGroup := Group{
+		Schemas:     []string{groupSchema},
+		ID:          group.Name,
+		ExternalID:  group.Spec.ExternalID,
+		DisplayName: group.Spec.DisplayName,
+		Meta: &Meta{
+			ResourceType: groupResourceType,
+			Created:      group.CreationTimestamp.UTC().Format(time.RFC3339),
+			Location:     location(provider, groupResourceType, group.Name),
+		},
+	}
+	for _, member := range group.Spec.Members {
+		ref := Reference{Value: member}
+		if u, err := h.userLister.Get("", member); err == nil {
+			ref.Display = u.Annotations[userNameAnnotation]
+		}
+		scimGroup.Members = append(scimGroup.Members, ref)
+	}
+	return scimGroup
+}
+
+func groupSpec(provider string, input *Group) apimgmtv3.SCIMGroupSpec {
+	spec := apimgmtv3.SCIMGroupSpec{
+		Provider:    provider,
+		DisplayName: input.DisplayName,
+		ExternalID:  input.ExternalID,
+		PrincipalID: principalID(provider, "group", input.ExternalID, input.DisplayName),
+	}
+	for _, member := range input.Members {
+		if !containsString(spec.Members, member.Value) {
+			spec.Members = append(spec.Members, member.Value)
+		}
+	}
+	return spec
+}
+
+// patchGroup applies a patch operation to the group.
+func patchGroup(g *Group, op PatchOperation) error {
+	operation := strings.ToLower(op.Op)
+	if operation != "add" && operation != "replace" && operation != "remove" {
+		return badRequest("invalidSyntax", "unsupported patch operation %q", op.Op)
+	}
+
+	if op.Path == "" {
+		if operation == "remove" {
+			return badRequest("noTarget", "remove operations require a path")
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return badRequest("invalidValue", "the value of a patch operation without path must be an object")
+		}
+		for path, value := range values {
+			if err := patchGroupAttribute(g, operation, path, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return patchGroupAttribute(g, operation, op.Path, op.Value)
+}
+
+func patchGroupAttribute(g *Group, operation, path string, value json.RawMessage) error {
+	path = strings.TrimPrefix(path, groupSchema+":")
+	if match := memberFilterRegexp.FindStringSubmatch(path); match != nil {
+		if operation != "remove" {
+			return badRequest("invalidPath", "unsupported path %q", path)
+		}
+		g.Members = removeMembers(g.Members, []Reference{{Value: match[1]}})
+		return nil
+	}
+
+	var err error
+	switch strings.ToLower(path) {
+	case "members":
+		var members []Reference
+		if len(value) > 0 {
+			if err := json.Unmarshal(value, &members); err != nil {
+				return badRequest("invalidValue", "invalid members %s", value)
+			}
+		}
+		switch {
+		case operation == "add":
+			g.Members = append(g.Members, members...)
+		case operation == "replace":
+			g.Members = members
+		case len(members) == 0:
+			g.Members = nil
+		default:
+			g.Members = removeMembers(g.Members, members)
+		}
+	case "displayname":
+		if operation == "remove" {
+			return badRequest("mutability", "displayName is required")
+		}
+		g.DisplayName, err = parseString(value)
+	case "externalid":
+		if operation == "remove" {
+			g.ExternalID = ""
+		} else {
+			g.ExternalID, err = parseString(value)
+		}
+	default:
+		return badRequest("invalidPath", "unsupported path %q", path)
+	}
+	return err
+}
+
+func removeMembers(members, removed []Reference) []Reference {
+	var result []Reference
+	for _, member := range members {
+		keep := true
+		for _, r := range removed {
+			if member.Value == r.Value {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			result = append(result, member)
+		}
+	}
+	return result
+}
@@ -0,0 +1,100 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchUser(t *testing.T) {
+	active := true
+	u := User{UserName: "alice", DisplayName: "Alice", Active: &active}
+
+	ops := []PatchOperation{
+		{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)},
+		{Op: "replace", Path: "name.givenName", Value: json.RawMessage(`"Alice"`)},
+		{Op: "add", Value: json.RawMessage(`{"externalId": "ext-1", "emails": [{"value": "alice@example.com"}]}`)},
+		{Op: "replace", Path: "urn:ietf:params:scim:schemas:core:2.0:User:userName", Value: json.RawMessage(`"alice@example.com"`)},
+	}
+	for _, op := range ops {
+		require.NoError(t, patchUser(&u, op))
+	}
+
+	assert.False(t, *u.Active)
+	assert.Equal(t, "Alice", u.Name.GivenName)
+	assert.Equal(t, "ext-1", u.ExternalID)
+	assert.Equal(t, "alice@example.com", u.UserName)
+
+	err := patchUser(&u, PatchOperation{Op: "move", Path: "userName"})
+	require.Error(t, err)
+	err = patchUser(&u, PatchOperation{Op: "remove"})
+	require.Error(t, err)
+	assert.Equal(t, "noTarget", err.(*apiError).scimType)
+}
+
+func TestPatchGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      PatchOperation
+		want    []Reference
+		wantErr bool
+	}{
+		{
+			name: "add members",
+			op:   PatchOperation{Op: "add", Path: "members", Value: json.RawMessage(`[{"value": "u-c"}]`)},
+			want: []Reference{{Value: "u-a"}, {Value: "u-b"}, {Value: "u-c"}},
+		},
+		{
+			name: "remove member by filter",
+			op:   PatchOperation{Op: "Remove", Path: `members[value eq "u-a"]`},
+			want: []Reference{{Value: "u-b"}},
+		},
+		{
+			name: "remove members by value",
+			op:   PatchOperation{Op: "remove", Path: "members", Value: json.RawMessage(`[{"value": "u-b"}]`)},
+			want: []Reference{{Value: "u-a"}},
+		},
+		{
+			name: "remove all members",
+			op:   PatchOperation{Op: "remove", Path: "members"},
+		},
+		{
+			name: "replace members",
+			op:   PatchOperation{Op: "replace", Path: "members", Value: json.RawMessage(`[{"value": "u-d"}]`)},
+			want: []Reference{{Value: "u-d"}},
+		},
+		{
+			name:    "add member by filter",
+			op:      PatchOperation{Op: "add", Path: `members[value eq "u-a"]`},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported path",
+			op:      PatchOperation{Op: "replace", Path: "owners", Value: json.RawMessage(`[]`)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Group{DisplayName: "team", Members: []Reference{{Value: "u-a"}, {Value: "u-b"}}}
+			err := patchGroup(&g, tt.op)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, g.Members)
+		})
+	}
+}
+
+func TestPatchGroupWithoutPath(t *testing.T) {
+	g := Group{DisplayName: "team"}
+	err := patchGroup(&g, PatchOperation{Op: "replace", Value: json.RawMessage(`{"displayName": "admins", "externalId": "ext-1"}`)})
+	require.NoError(t, err)
+	assert.Equal(t, "admins", g.DisplayName)
+	assert.Equal(t, "ext-1", g.ExternalID)
+}
@@ -0,0 +1,277 @@
+// Package scim implements a SCIM 2.0 (RFC 7643, RFC 7644) server that lets identity providers provision the users and
+// groups of an auth provider ahead of their first login, and deprovision them.
+//
+// The base URL of the SCIM API of an auth provider is <server-url>/v1-scim/<provider>. The identity provider
+// authenticates with the bearer token stored in the token key of the scim-<provider> secret in the cattle-global-data
+// namespace. The API is disabled for auth providers without this secret.
+//
+// SCIM users are Rancher Users with the principal <provider>_user://<id>, where id is the externalId of the SCIM user
+// or its userName if it has no externalId. SCIM groups are SCIMGroups with the principal <provider>_group://<id>,
+// where id is the externalId or the displayName of the group. The group principals of the groups of a user are
+// stored in its UserAttribute, so they are in effect before the user logs in.
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rancher/rancher/pkg/auth/providers"
+	"github.com/rancher/rancher/pkg/auth/providers/local"
+	"github.com/rancher/rancher/pkg/auth/tokens"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/rancher/pkg/user"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Endpoint is the path prefix of the SCIM API.
+	Endpoint = "/v1-scim"
+
+	tokenSecretPrefix = "scim-"
+	tokenSecretKey    = "token"
+
+	// attributesKey is the key of the SCIM group principals and extras in the UserAttributes of SCIM users.
+	attributesKey = "scim"
+
+	providerLabel        = "scim.cattle.io/provider"
+	userNameAnnotation   = "scim.cattle.io/user-name"
+	externalIDAnnotation = "scim.cattle.io/external-id"
+	principalAnnotation  = "scim.cattle.io/principal-id"
+	// adoptedAnnotation marks Users that existed before they were provisioned, they are released instead of deleted
+	// when they are deprovisioned.
+	adoptedAnnotation = "scim.cattle.io/adopted"
+
+	contentType     = "application/scim+json"
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// Handler serves the SCIM API.
+type Handler struct {
+	router *mux.Router
+
+	users          v3.UserInterface
+	userLister     v3.UserLister
+	userManager    user.Manager
+	groups         mgmtcontrollers.SCIMGroupClient
+	groupCache     mgmtcontrollers.SCIMGroupCache
+	secretLister   v1.SecretLister
+	userAttributes userAttributeUpdater
+}
+
+// userAttributeUpdater updates the group principals and extras of a user for an auth provider.
+type userAttributeUpdater interface {
+	UserAttributeCreateOrUpdate(userID, provider string, groupPrincipals []v3.Principal, userExtraInfo map[string][]string) error
+}
+
+// NewHandler creates the Handler using the clients defined in mgmt.
+func NewHandler(ctx context.Context, mgmt *config.ScaledContext) *Handler {
+	h := &Handler{
+		users:          mgmt.Management.Users(""),
+		userLister:     mgmt.Management.Users("").Controller().Lister(),
+		userManager:    mgmt.UserManager,
+		groups:         mgmt.Wrangler.Mgmt.SCIMGroup(),
+		groupCache:     mgmt.Wrangler.Mgmt.SCIMGroup().Cache(),
+		secretLister:   mgmt.Core.Secrets("").Controller().Lister(),
+		userAttributes: tokens.NewManager(ctx, mgmt),
+	}
+
+	router := mux.NewRouter()
+	router.UseEncodedPath()
+	api := router.PathPrefix(Endpoint + "/{provider}").Subrouter()
+	api.Use(h.authenticate)
+	api.Path("/ServiceProviderConfig").Methods(http.MethodGet).Handler(h.handle(h.serviceProviderConfig))
+	api.Path("/Users").Methods(http.MethodGet).Handler(h.handle(h.listUsers))
+	api.Path("/Users").Methods(http.MethodPost).Handler(h.handle(h.createUser))
+	api.Path("/Users/{id}").Methods(http.MethodGet).Handler(h.handle(h.getUser))
+	api.Path("/Users/{id}").Methods(http.MethodPut).Handler(h.handle(h.replaceUser))
+	api.Path("/Users/{id}").Methods(http.MethodPatch).Handler(h.handle(h.patchUser))
+	api.Path("/Users/{id}").Methods(http.MethodDelete).Handler(h.handle(h.deleteUser))
+	api.Path("/Groups").Methods(http.MethodGet).Handler(h.handle(h.listGroups))
+	api.Path("/Groups").Methods(http.MethodPost).Handler(h.handle(h.createGroup))
+	api.Path("/Groups/{id}").Methods(http.MethodGet).Handler(h.handle(h.getGroup))
+	api.Path("/Groups/{id}").Methods(http.MethodPut).Handler(h.handle(h.replaceGroup))
+	api.Path("/Groups/{id}").Methods(http.MethodPatch).Handler(h.handle(h.patchGroup))
+	api.Path("/Groups/{id}").Methods(http.MethodDelete).Handler(h.handle(h.deleteGroup))
+	h.router = router
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	h.router.ServeHTTP(rw, req)
+}
+
+// authenticate rejects requests for unknown auth providers and requests without the bearer token of the provider.
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		provider := mux.Vars(req)["provider"]
+		if provider == local.Name || !providers.ProviderNames[provider] {
+			writeError(rw, notFound("auth provider", provider))
+			return
+		}
+
+		token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		secret, err := h.secretLister.Get(namespace.GlobalNamespace, tokenSecretPrefix+provider)
+		if err != nil || len(secret.Data[tokenSecretKey]) == 0 || token == "" ||
+			subtle.ConstantTimeCompare([]byte(token), secret.Data[tokenSecretKey]) != 1 {
+			writeError(rw, &apiError{status: http.StatusUnauthorized, detail: "invalid bearer token"})
+			return
+		}
+
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// handlerFunc serves a SCIM request for the auth provider and returns the status and the body of the response.
+type handlerFunc func(provider string, req *http.Request) (int, interface{}, error)
+
+func (h *Handler) handle(f handlerFunc) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		status, body, err := f(mux.Vars(req)["provider"], req)
+		if err != nil {
+			writeError(rw, err)
+			return
+		}
+		rw.Header().Set("Content-Type", contentType)
+		rw.WriteHeader(status)
+		if body != nil {
+			if err := json.NewEncoder(rw).Encode(body); err != nil {
+				logrus.Errorf("[scim] failed to write response: %v", err)
+			}
+		}
+	})
+}
+
+func writeError(rw http.ResponseWriter, err error) {
+	e, ok := err.(*apiError)
+	if !ok {
+		logrus.Errorf("[scim] %v", err)
+		e = &apiError{status: http.StatusInternalServerError, detail: "internal server error"}
+	}
+	rw.Header().Set("Content-Type", contentType)
+	rw.WriteHeader(e.status)
+	_ = json.NewEncoder(rw).Encode(Error{
+		Schemas:  []string{errorSchema},
+		Status:   strconv.Itoa(e.status),
+		ScimType: e.scimType,
+		Detail:   e.detail,
+	})
+}
+
+func decode(req *http.Request, into interface{}) error {
+	if err := json.NewDecoder(req.Body).Decode(into); err != nil {
+		return badRequest("invalidSyntax", "invalid request body: %v", err)
+	}
+	return nil
+}
+
+func (h *Handler) serviceProviderConfig(provider string, req *http.Request) (int, interface{}, error) {
+	return http.StatusOK, map[string]interface{}{
+		"schemas":        []string{serviceProviderConfigSchema},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": maxPageSize},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the bearer token of the auth provider",
+			"primary":     true,
+		}},
+	}, nil
+}
+
+// resource is a SCIM resource with the attributes the filters can select it by.
+type resource struct {
+	id         string
+	attributes map[string]string
+	value      interface{}
+}
+
+// listResponse returns the page of the resources that match the filter of the request, sorted by ID.
+func listResponse(req *http.Request, resources []resource) (int, interface{}, error) {
+	query := req.URL.Query()
+	f, err := parseFilter(query.Get("filter"))
+	if err != nil {
+		return 0, nil, err
+	}
+	startIndex, err := intParam(query.Get("startIndex"), 1)
+	if err != nil {
+		return 0, nil, err
+	}
+	count, err := intParam(query.Get("count"), defaultPageSize)
+	if err != nil {
+		return 0, nil, err
+	}
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if count > maxPageSize {
+		count = maxPageSize
+	}
+
+	var matches []resource
+	for _, r := range resources {
+		if f.matches(r.attributes) {
+			matches = append(matches, r)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].id < matches[j].id
+	})
+
+	page := []interface{}{}
+	for i := startIndex - 1; i < len(matches) && len(page) < count; i++ {
+		page = append(page, matches[i].value)
+	}
+	return http.StatusOK, ListResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: len(matches),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}, nil
+}
+
+func intParam(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil || i < 0 {
+		return 0, badRequest("invalidValue", "invalid number %q", value)
+	}
+	return i, nil
+}
+
+// principalID returns the ID of the principal of a SCIM resource, kind is either user or group.
+func principalID(provider, kind, externalID, name string) string {
+	id := externalID
+	if id == "" {
+		id = name
+	}
+	return provider + "_" + kind + "://" + id
+}
+
+// location returns the URL of a SCIM resource, or an empty string if the server URL isn't set.
+func location(provider, resourceType, id string) string {
+	serverURL := settings.ServerURL.Get()
+	if serverURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(serverURL, "/") + Endpoint + "/" + provider + "/" + resourceType + "s/" + id
+}
@@ -0,0 +1,447 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	apimgmtv3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/providers/common"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+)
+
+func (h *Handler) listUsers(provider string, req *http.Request) (int, interface{}, error) {
+	users, err := h.userLister.List("", labels.SelectorFromSet(labels.Set{providerLabel: provider}))
+	if err != nil {
+		return 0, nil, err
+	}
+	groups, err := h.providerGroups(provider)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	resources := make([]resource, 0, len(users))
+	for _, u := range users {
+		scimUser := toSCIMUser(provider, u, groups)
+		resources = append(resources, resource{
+			id: u.Name,
+			attributes: map[string]string{
+				"id":          u.Name,
+				"username":    scimUser.UserName,
+				"externalid":  scimUser.ExternalID,
+				"displayname": scimUser.DisplayName,
+			},
+			value: scimUser,
+		})
+	}
+	return listResponse(req, resources)
+}
+
+func (h *Handler) getUser(provider string, req *http.Request) (int, interface{}, error) {
+	u, err := h.getProviderUser(provider, mux.Vars(req)["id"])
+	if err != nil {
+		return 0, nil, err
+	}
+	groups, err := h.providerGroups(provider)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toSCIMUser(provider, u, groups), nil
+}
+
+// createUser creates the Rancher User of the principal of the SCIM user, or adopts the User if the principal already
+// logged in. Users with principals of other providers, e.g. local users, are never adopted.
+func (h *Handler) createUser(provider string, req *http.Request) (int, interface{}, error) {
+	var input User
+	if err := decode(req, &input); err != nil {
+		return 0, nil, err
+	}
+	if err := validateUser(&input); err != nil {
+		return 0, nil, err
+	}
+	if err := h.checkUserNameUnique(provider, input.UserName, ""); err != nil {
+		return 0, nil, err
+	}
+
+	principal := principalID(provider, "user", input.ExternalID, input.UserName)
+	existing, err := h.userManager.GetUserByPrincipalID(principal)
+	if err != nil {
+		return 0, nil, err
+	}
+	if existing != nil {
+		if existing.Labels[providerLabel] == provider {
+			return 0, nil, conflict("user %s is already provisioned", input.UserName)
+		}
+		if !adoptable(provider, existing) {
+			return 0, nil, conflict("user %s exists with principals of other providers", input.UserName)
+		}
+	}
+
+	u, err := h.userManager.EnsureUser(principal, displayName(&input))
+	if err != nil {
+		return 0, nil, err
+	}
+	if u.Labels[providerLabel] == provider {
+		return 0, nil, conflict("user %s is already provisioned", input.UserName)
+	}
+
+	u, err = h.updateUser(provider, u.Name, &input, existing != nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, toSCIMUser(provider, u, nil), nil
+}
+
+func (h *Handler) replaceUser(provider string, req *http.Request) (int, interface{}, error) {
+	u, err := h.getProviderUser(provider, mux.Vars(req)["id"])
+	if err != nil {
+		return 0, nil, err
+	}
+	var input User
+	if err := decode(req, &input); err != nil {
+		return 0, nil, err
+	}
+	if err := validateUser(&input); err != nil {
+		return 0, nil, err
+	}
+	return h.saveUser(provider, u, &input)
+}
+
+func (h *Handler) patchUser(provider string, req *http.Request) (int, interface{}, error) {
+	u, err := h.getProviderUser(provider, mux.Vars(req)["id"])
+	if err != nil {
+		return 0, nil, err
+	}
+	var patch PatchRequest
+	if err := decode(req, &patch); err != nil {
+		return 0, nil, err
+	}
+
+	scimUser := toSCIMUser(provider, u, nil)
+	for _, op := range patch.Operations {
+		if err := patchUser(&scimUser, op); err != nil {
+			return 0, nil, err
+		}
+	}
+	if err := validateUser(&scimUser); err != nil {
+		return 0, nil, err
+	}
+	return h.saveUser(provider, u, &scimUser)
+}
+
+func (h *Handler) saveUser(provider string, u *v3.User, input *User) (int, interface{}, error) {
+	if err := h.checkUserNameUnique(provider, input.UserName, u.Name); err != nil {
+		return 0, nil, err
+	}
+	u, err := h.updateUser(provider, u.Name, input, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	groups, err := h.providerGroups(provider)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, toSCIMUser(provider, u, groups), nil
+}
+
+// deleteUser deprovisions the user by deleting the Rancher User, which deletes its tokens and bindings. Adopted Users
+// are released instead, only the principal of the SCIM user is removed from them.
+func (h *Handler) deleteUser(provider string, req *http.Request) (int, interface{}, error) {
+	u, err := h.getProviderUser(provider, mux.Vars(req)["id"])
+	if err != nil {
+		return 0, nil, err
+	}
+	groups, err := h.providerGroups(provider)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, group := range groups {
+		if !containsString(group.Spec.Members, u.Name) {
+			continue
+		}
+		if err := h.updateGroupMembers(group.Name, func(members []string) []string {
+			return removeString(members, u.Name)
+		}); err != nil {
+			return 0, nil, err
+		}
+	}
+	if u.Annotations[adoptedAnnotation] == "true" {
+		if err := h.releaseUser(u.Name); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusNoContent, nil, nil
+	}
+	if err := h.users.Delete(u.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+// releaseUser removes the principal and the SCIM attributes of an adopted User, along with its SCIM group principals.
+func (h *Handler) releaseUser(name string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		u, err := h.users.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		u = u.DeepCopy()
+		release(u)
+		_, err = h.users.Update(u)
+		return err
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return h.userAttributes.UserAttributeCreateOrUpdate(name, attributesKey, []v3.Principal{}, map[string][]string{})
+	})
+}
+
+// adoptable returns true if all principals of an existing User belong to the auth provider.
+func adoptable(provider string, u *v3.User) bool {
+	for _, principal := range u.PrincipalIDs {
+		if !strings.HasPrefix(principal, provider+"_") {
+			return false
+		}
+	}
+	return true
+}
+
+// release removes the principal, label and annotations the SCIM user added to an adopted User.
+func release(u *v3.User) {
+	u.PrincipalIDs = removeString(u.PrincipalIDs, u.Annotations[principalAnnotation])
+	delete(u.Labels, providerLabel)
+	delete(u.Annotations, userNameAnnotation)
+	delete(u.Annotations, externalIDAnnotation)
+	delete(u.Annotations, principalAnnotation)
+	delete(u.Annotations, adoptedAnnotation)
+}
+
+// getProviderUser returns the User if it was provisioned for the auth provider.
+func (h *Handler) getProviderUser(provider, id string) (*v3.User, error) {
+	u, err := h.userLister.Get("", id)
+	if apierrors.IsNotFound(err) || (err == nil && u.Labels[providerLabel] != provider) {
+		return nil, notFound(userResourceType, id)
+	}
+	return u, err
+}
+
+func (h *Handler) checkUserNameUnique(provider, userName, id string) error {
+	users, err := h.userLister.List("", labels.SelectorFromSet(labels.Set{providerLabel: provider}))
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		if u.Name != id && strings.EqualFold(u.Annotations[userNameAnnotation], userName) {
+			return conflict("user %s already exists", userName)
+		}
+	}
+	return nil
+}
+
+// updateUser stores the SCIM attributes of the user in the User and its UserAttribute. adopted marks a User that
+// existed before it was provisioned.
+func (h *Handler) updateUser(provider, name string, input *User, adopted bool) (*v3.User, error) {
+	principal := principalID(provider, "user", input.ExternalID, input.UserName)
+	var result *v3.User
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		u, err := h.users.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		u = u.DeepCopy()
+		if u.Labels == nil {
+			u.Labels = map[string]string{}
+		}
+		if u.Annotations == nil {
+			u.Annotations = map[string]string{}
+		}
+		// the principal changes if the userName of a user without externalId changes
+		if previous := u.Annotations[principalAnnotation]; previous != "" && previous != principal {
+			u.PrincipalIDs = removeString(u.PrincipalIDs, previous)
+		}
+		if !containsString(u.PrincipalIDs, principal) {
+			u.PrincipalIDs = append(u.PrincipalIDs, principal)
+		}
+		u.Labels[providerLabel] = provider
+		u.Annotations[userNameAnnotation] = input.UserName
+		u.Annotations[externalIDAnnotation] = input.ExternalID
+		u.Annotations[principalAnnotation] = principal
+		if adopted {
+			u.Annotations[adoptedAnnotation] = "true"
+		}
+		u.DisplayName = displayName(input)
+		enabled := input.Active == nil || *input.Active
+		u.Enabled = &enabled
+
+		result, err = h.users.Update(u)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, h.syncUserAttribute(provider, result)
+}
+
+// syncUserAttribute stores the group principals of the SCIM groups of the user in its UserAttribute.
+func (h *Handler) syncUserAttribute(provider string, u *v3.User) error {
+	groups, err := h.groups.List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	groupPrincipals := []v3.Principal{}
+	for _, group := range groups.Items {
+		if group.Spec.Provider != provider || !containsString(group.Spec.Members, u.Name) {
+			continue
+		}
+		groupPrincipals = append(groupPrincipals, v3.Principal{
+			ObjectMeta:    metav1.ObjectMeta{Name: group.Spec.PrincipalID},
+			DisplayName:   group.Spec.DisplayName,
+			PrincipalType: "group",
+			Provider:      provider,
+			MemberOf:      true,
+		})
+	}
+	extras := map[string][]string{
+		common.UserAttributePrincipalID: {u.Annotations[principalAnnotation]},
+		common.UserAttributeUserName:    {u.Annotations[userNameAnnotation]},
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return h.userAttributes.UserAttributeCreateOrUpdate(u.Name, attributesKey, groupPrincipals, extras)
+	})
+}
+
+func toSCIMUser(provider string, u *v3.User, groups []*apimgmtv3.SCIMGroup) User {
+	active := u.Enabled == nil || *u.Enabled
+	scimUser := User{
+		Schemas:     []string{userSchema},
+		ID:          u.Name,
+		ExternalID:  u.Annotations[externalIDAnnotation],
+		UserName:    u.Annotations[userNameAnnotation],
+		DisplayName: u.DisplayName,
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: userResourceType,
+			Created:      u.CreationTimestamp.UTC().Format(time.RFC3339),
+			Location:     location(provider, userResourceType, u.Name),
+		},
+	}
+	for _, group := range groups {
+		if containsString(group.Spec.Members, u.Name) {
+			scimUser.Groups = append(scimUser.Groups, Reference{Value: group.Name, Display: group.Spec.DisplayName})
+		}
+	}
+	return scimUser
+}
+
+func validateUser(u *User) error {
+	if strings.TrimSpace(u.UserName) == "" {
+		return badRequest("invalidValue", "userName is required")
+	}
+	return nil
+}
+
+func displayName(u *User) string {
+	switch {
+	case u.DisplayName != "":
+		return u.DisplayName
+	case u.Name != nil && u.Name.Formatted != "":
+		return u.Name.Formatted
+	case u.Name != nil && (u.Name.GivenName != "" || u.Name.FamilyName != ""):
+		return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+	}
+	return u.UserName
+}
+
+// patchUser applies a patch operation to the user. Operations on attributes that Rancher doesn't store are ignored.
+func patchUser(u *User, op PatchOperation) error {
+	operation := strings.ToLower(op.Op)
+	if operation != "add" && operation != "replace" && operation != "remove" {
+		return badRequest("invalidSyntax", "unsupported patch operation %q", op.Op)
+	}
+
+	if op.Path == "" {
+		if operation == "remove" {
+			return badRequest("noTarget", "remove operations require a path")
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return badRequest("invalidValue", "the value of a patch operation without path must be an object")
+		}
+		for path, value := range values {
+			if err := patchUserAttribute(u, operation, path, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return patchUserAttribute(u, operation, op.Path, op.Value)
+}
+
+func patchUserAttribute(u *User, operation, path string, value json.RawMessage) error {
+	path = strings.ToLower(strings.TrimPrefix(path, userSchema+":"))
+	if operation == "remove" {
+		value = json.RawMessage(`""`)
+		if path == "active" {
+			return nil
+		}
+	}
+	if u.Name == nil {
+		u.Name = &Name{}
+	}
+
+	var err error
+	switch path {
+	case "active":
+		var active bool
+		active, err = parseBool(value)
+		u.Active = &active
+	case "username":
+		u.UserName, err = parseString(value)
+	case "externalid":
+		u.ExternalID, err = parseString(value)
+	case "displayname":
+		u.DisplayName, err = parseString(value)
+	case "name":
+		if operation == "remove" {
+			u.Name = &Name{}
+		} else if err = json.Unmarshal(value, u.Name); err != nil {
+			err = badRequest("invalidValue", "invalid name %s", value)
+		}
+	case "name.formatted":
+		u.Name.Formatted, err = parseString(value)
+	case "name.givenname":
+		u.Name.GivenName, err = parseString(value)
+	case "name.familyname":
+		u.Name.FamilyName, err = parseString(value)
+	}
+	return err
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
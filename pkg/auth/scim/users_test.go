@@ -0,0 +1,35 @@
+package scim
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAdoptable(t *testing.T) {
+	assert.True(t, adoptable("azuread", &v3.User{PrincipalIDs: []string{"azuread_user://alice"}}))
+	assert.False(t, adoptable("azuread", &v3.User{PrincipalIDs: []string{"azuread_user://alice", "local://u-abc"}}))
+	assert.False(t, adoptable("azuread", &v3.User{PrincipalIDs: []string{"azureadx_user://alice"}}))
+}
+
+func TestRelease(t *testing.T) {
+	u := &v3.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{providerLabel: "okta", "other": "label"},
+			Annotations: map[string]string{
+				userNameAnnotation:   "alice",
+				externalIDAnnotation: "ext-1",
+				principalAnnotation:  "okta_user://ext-1",
+				adoptedAnnotation:    "true",
+			},
+		},
+		PrincipalIDs: []string{"okta_user://alice@example.com", "okta_user://ext-1"},
+	}
+
+	release(u)
+	assert.Equal(t, []string{"okta_user://alice@example.com"}, u.PrincipalIDs)
+	assert.Equal(t, map[string]string{"other": "label"}, u.Labels)
+	assert.Empty(t, u.Annotations)
+}
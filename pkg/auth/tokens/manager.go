@@ -100,6 +100,9 @@ func (m *Manager) createDerivedToken(jsonInput clientv3.Token, tokenAuthValue st
 	if err != nil {
 		return v3.Token{}, "", 401, err
 	}
+	if len(token.Scopes) > 0 {
+		return v3.Token{}, "", 403, errors.New("scoped tokens can not be used to create tokens")
+	}
 
 	tokenTTL, err := ClampToMaxTTL(time.Duration(int64(jsonInput.TTLMillis)) * time.Millisecond)
 	if err != nil {
@@ -116,6 +119,7 @@ func (m *Manager) createDerivedToken(jsonInput clientv3.Token, tokenAuthValue st
 		ProviderInfo:  token.ProviderInfo,
 		Description:   jsonInput.Description,
 		ClusterName:   jsonInput.ClusterID,
+		Scopes:        convertTokenScopes(jsonInput.Scopes),
 	}
 	derivedToken, unhashedTokenKey, err = m.createToken(&derivedToken)
 
@@ -123,6 +127,19 @@ func (m *Manager) createDerivedToken(jsonInput clientv3.Token, tokenAuthValue st
 
 }
 
+func convertTokenScopes(scopes []clientv3.TokenScope) []v32.TokenScope {
+	var result []v32.TokenScope
+	for _, scope := range scopes {
+		result = append(result, v32.TokenScope{
+			Verbs:     scope.Verbs,
+			APIGroups: scope.APIGroups,
+			Resources: scope.Resources,
+			Projects:  scope.Projects,
+		})
+	}
+	return result
+}
+
 // createToken returns the token object and it's unhashed token key, which is stored hashed
 func (m *Manager) createToken(k8sToken *v3.Token) (v3.Token, string, error) {
 	key, err := randomtoken.Generate()
@@ -54,6 +54,8 @@ func (p *purger) purge() {
 		logrus.Infof("Purged %v expired tokens", count)
 	}
 
+	p.disableIdleTokens(allTokens, MaxIdle(), time.Now())
+
 	// saml tokens store encrypted token for login request from rancher cli
 	samlTokens, err := p.samlTokensLister.List(namespace.GlobalNamespace, labels.Everything())
 	if err != nil {
@@ -76,3 +78,50 @@ func (p *purger) purge() {
 		logrus.Infof("Purged %v saml tokens", count)
 	}
 }
+
+// disableIdleTokens disables user tokens that weren't used within maxIdle. Disabled tokens are kept so their owners can
+// see why they stopped working and re-enable them if they are still needed.
+func (p *purger) disableIdleTokens(allTokens []*v3.Token, maxIdle time.Duration, now time.Time) {
+	if maxIdle <= 0 {
+		return
+	}
+
+	var count int
+	for _, token := range allTokens {
+		enabled := token.Enabled == nil || *token.Enabled
+		if !enabled || !IsUserToken(token) || IsExpired(*token) {
+			continue
+		}
+
+		if token.Annotations[DisabledReasonAnnotation] == DisabledReasonIdle {
+			// The token was re-enabled after being disabled, count its idle time from now.
+			token = token.DeepCopy()
+			delete(token.Annotations, DisabledReasonAnnotation)
+			token.Annotations[IdleResetAnnotation] = now.Format(time.RFC3339)
+			if _, err := p.tokens.Update(token); err != nil && !clientbase.IsNotFound(err) {
+				logrus.Errorf("Error: while resetting idle time of token %v: %v", token.Name, err)
+			}
+			continue
+		}
+
+		if !IsIdle(token, maxIdle, now) {
+			continue
+		}
+
+		token = token.DeepCopy()
+		disabled := false
+		token.Enabled = &disabled
+		if token.Annotations == nil {
+			token.Annotations = map[string]string{}
+		}
+		token.Annotations[DisabledReasonAnnotation] = DisabledReasonIdle
+		if _, err := p.tokens.Update(token); err != nil && !clientbase.IsNotFound(err) {
+			logrus.Errorf("Error: while disabling idle token %v: %v", token.Name, err)
+			continue
+		}
+		count++
+	}
+	if count > 0 {
+		logrus.Infof("Disabled %v tokens unused for more than %v", count, maxIdle)
+	}
+}
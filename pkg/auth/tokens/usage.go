@@ -0,0 +1,60 @@
+package tokens
+
+import (
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/settings"
+)
+
+const (
+	// DisabledReasonAnnotation records why Rancher disabled a token.
+	DisabledReasonAnnotation = "authn.management.cattle.io/disabled-reason"
+	// DisabledReasonIdle marks tokens disabled for not being used within auth-token-max-idle-days.
+	DisabledReasonIdle = "idle"
+	// IdleResetAnnotation is the RFC3339 time a token disabled for being idle was found re-enabled. Idleness is
+	// counted from then on so the token isn't disabled again right away.
+	IdleResetAnnotation = "authn.management.cattle.io/idle-reset"
+)
+
+// userTokenKinds are the kinds of tokens issued to users. Tokens of other kinds are created by Rancher components for
+// their own use.
+var userTokenKinds = map[string]bool{
+	"":           true,
+	"session":    true,
+	"kubeconfig": true,
+}
+
+// IsUserToken returns true if the token was issued to a user rather than created by a Rancher component.
+func IsUserToken(token *v3.Token) bool {
+	return userTokenKinds[token.Labels[TokenKindLabel]]
+}
+
+// IdleSince returns the time the token was last used, or its creation time if no usage was recorded. A token that was
+// re-enabled after being disabled for being idle is idle since it was re-enabled at the earliest.
+func IdleSince(token *v3.Token) time.Time {
+	since := token.CreationTimestamp.Time
+	for _, value := range []string{token.LastUsedAt, token.Annotations[IdleResetAnnotation]} {
+		if value == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil && t.After(since) {
+			since = t
+		}
+	}
+	return since
+}
+
+// IsIdle returns true if the token wasn't used within maxIdle.
+func IsIdle(token *v3.Token, maxIdle time.Duration, now time.Time) bool {
+	return maxIdle > 0 && now.Sub(IdleSince(token)) > maxIdle
+}
+
+// MaxIdle returns the duration after which unused tokens are disabled, 0 if they never are.
+func MaxIdle() time.Duration {
+	days := settings.AuthTokenMaxIdleDays.GetInt()
+	if days <= 0 {
+		return 0
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -0,0 +1,75 @@
+package tokens
+
+import (
+	"testing"
+	"time"
+
+	apiv3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3/fakes"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIdleSince(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	token := &v3.Token{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}}
+	assert.Equal(t, created, IdleSince(token))
+
+	token.LastUsedAt = "2023-03-01T10:00:00Z"
+	assert.Equal(t, time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC), IdleSince(token))
+
+	token.Annotations = map[string]string{IdleResetAnnotation: "2023-06-01T00:00:00Z"}
+	assert.Equal(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), IdleSince(token))
+
+	now := time.Date(2023, 6, 11, 0, 0, 0, 0, time.UTC)
+	assert.True(t, IsIdle(token, 9*24*time.Hour, now))
+	assert.False(t, IsIdle(token, 11*24*time.Hour, now))
+	assert.False(t, IsIdle(token, 0, now))
+}
+
+func TestDisableIdleTokens(t *testing.T) {
+	now := time.Now()
+	longAgo := metav1.NewTime(now.Add(-30 * 24 * time.Hour))
+	disabled := false
+
+	newToken := func(name string, mutate func(*v3.Token)) *v3.Token {
+		token := &v3.Token{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: longAgo}}
+		mutate(token)
+		return token
+	}
+	allTokens := []*v3.Token{
+		newToken("idle", func(*v3.Token) {}),
+		newToken("recently-used", func(token *v3.Token) { token.LastUsedAt = now.Add(-time.Hour).Format(time.RFC3339) }),
+		newToken("system", func(token *v3.Token) { token.Labels = map[string]string{TokenKindLabel: "agent"} }),
+		newToken("already-disabled", func(token *v3.Token) { token.Enabled = &disabled }),
+		newToken("expired", func(token *v3.Token) { token.TTLMillis = 1 }),
+		newToken("re-enabled", func(token *v3.Token) {
+			token.Annotations = map[string]string{DisabledReasonAnnotation: DisabledReasonIdle}
+		}),
+	}
+
+	updated := map[string]*apiv3.Token{}
+	p := &purger{
+		tokens: &fakes.TokenInterfaceMock{
+			UpdateFunc: func(token *apiv3.Token) (*apiv3.Token, error) {
+				updated[token.Name] = token
+				return token, nil
+			},
+		},
+	}
+	p.disableIdleTokens(allTokens, 7*24*time.Hour, now)
+
+	assert.Len(t, updated, 2)
+	if assert.Contains(t, updated, "idle") {
+		assert.False(t, *updated["idle"].Enabled)
+		assert.Equal(t, DisabledReasonIdle, updated["idle"].Annotations[DisabledReasonAnnotation])
+	}
+	if assert.Contains(t, updated, "re-enabled") {
+		assert.Nil(t, updated["re-enabled"].Enabled)
+		assert.NotContains(t, updated["re-enabled"].Annotations, DisabledReasonAnnotation)
+		assert.Equal(t, now.Format(time.RFC3339), updated["re-enabled"].Annotations[IdleResetAnnotation])
+	}
+	// The lister's objects must not be modified.
+	assert.Nil(t, allTokens[0].Enabled)
+}
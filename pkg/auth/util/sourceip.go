@@ -0,0 +1,76 @@
+package util
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/sirupsen/logrus"
+)
+
+// SourceIP returns the address a request was sent from. X-Forwarded-For entries are only trusted if they were added
+// by a proxy of the trusted-proxy-cidrs setting: the entries are walked from the right, starting with the address of
+// the peer, and the first address that isn't a trusted proxy is the source. The client can forge any entries before
+// the ones added by trusted proxies.
+func SourceIP(req *http.Request) string {
+	source, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		source = req.RemoteAddr
+	}
+
+	proxies := trustedProxies(settings.TrustedProxyCIDRs.Get())
+	if len(proxies) == 0 {
+		return source
+	}
+
+	var forwardedFor []string
+	for _, header := range req.Header.Values("X-Forwarded-For") {
+		forwardedFor = append(forwardedFor, strings.Split(header, ",")...)
+	}
+	for i := len(forwardedFor) - 1; i >= 0 && isTrustedProxy(proxies, source); i-- {
+		entry := strings.TrimSpace(forwardedFor[i])
+		if net.ParseIP(entry) == nil {
+			break
+		}
+		source = entry
+	}
+	return source
+}
+
+func trustedProxies(setting string) []*net.IPNet {
+	var proxies []*net.IPNet
+	for _, cidr := range strings.Split(setting, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, proxy, err := net.ParseCIDR(cidr)
+		if err != nil {
+			logrus.Warnf("Ignoring invalid CIDR %q in setting %s", cidr, settings.TrustedProxyCIDRs.Name)
+			continue
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies
+}
+
+func isTrustedProxy(proxies []*net.IPNet, address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range proxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
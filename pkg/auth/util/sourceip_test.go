@@ -0,0 +1,35 @@
+package util
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceIP(t *testing.T) {
+	req := &http.Request{RemoteAddr: "10.0.0.1:53422", Header: http.Header{}}
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 198.51.100.2")
+
+	// without trusted proxies the header is ignored
+	assert.Equal(t, "10.0.0.1", SourceIP(req))
+
+	previous := settings.TrustedProxyCIDRs.Get()
+	t.Cleanup(func() {
+		_ = settings.TrustedProxyCIDRs.Set(previous)
+	})
+
+	require.NoError(t, settings.TrustedProxyCIDRs.Set("10.0.0.0/8, invalid"))
+	assert.Equal(t, "198.51.100.2", SourceIP(req), "entries before the last untrusted address can be forged")
+
+	require.NoError(t, settings.TrustedProxyCIDRs.Set("10.0.0.0/8,198.51.100.2"))
+	assert.Equal(t, "203.0.113.7", SourceIP(req))
+
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, garbage, 198.51.100.2")
+	assert.Equal(t, "198.51.100.2", SourceIP(req))
+
+	req.RemoteAddr = "192.168.1.1:53422"
+	assert.Equal(t, "192.168.1.1", SourceIP(req), "requests from untrusted peers aren't forwarded")
+}
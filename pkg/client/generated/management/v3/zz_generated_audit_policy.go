@@ -0,0 +1,122 @@
+package client
+
+import (
+	"github.com/rancher/norman/types"
+)
+
+const (
+	AuditPolicyType                 = "auditPolicy"
+	AuditPolicyFieldAnnotations     = "annotations"
+	AuditPolicyFieldCreated         = "created"
+	AuditPolicyFieldCreatorID       = "creatorId"
+	AuditPolicyFieldLabels          = "labels"
+	AuditPolicyFieldName            = "name"
+	AuditPolicyFieldOwnerReferences = "ownerReferences"
+	AuditPolicyFieldRedactions      = "redactions"
+	AuditPolicyFieldRemoved         = "removed"
+	AuditPolicyFieldRules           = "rules"
+	AuditPolicyFieldUUID            = "uuid"
+)
+
+type AuditPolicy struct {
+	types.Resource
+	Annotations     map[string]string    `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	Created         string               `json:"created,omitempty" yaml:"created,omitempty"`
+	CreatorID       string               `json:"creatorId,omitempty" yaml:"creatorId,omitempty"`
+	Labels          map[string]string    `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Name            string               `json:"name,omitempty" yaml:"name,omitempty"`
+	OwnerReferences []OwnerReference     `json:"ownerReferences,omitempty" yaml:"ownerReferences,omitempty"`
+	Redactions      []AuditRedactionRule `json:"redactions,omitempty" yaml:"redactions,omitempty"`
+	Removed         string               `json:"removed,omitempty" yaml:"removed,omitempty"`
+	Rules           []AuditPolicyRule    `json:"rules,omitempty" yaml:"rules,omitempty"`
+	UUID            string               `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+}
+
+type AuditPolicyCollection struct {
+	types.Collection
+	Data   []AuditPolicy `json:"data,omitempty"`
+	client *AuditPolicyClient
+}
+
+type AuditPolicyClient struct {
+	apiClient *Client
+}
+
+type AuditPolicyOperations interface {
+	List(opts *types.ListOpts) (*AuditPolicyCollection, error)
+	ListAll(opts *types.ListOpts) (*AuditPolicyCollection, error)
+	Create(opts *AuditPolicy) (*AuditPolicy, error)
+	Update(existing *AuditPolicy, updates interface{}) (*AuditPolicy, error)
+	Replace(existing *AuditPolicy) (*AuditPolicy, error)
+	ByID(id string) (*AuditPolicy, error)
+	Delete(container *AuditPolicy) error
+}
+
+func newAuditPolicyClient(apiClient *Client) *AuditPolicyClient {
+	return &AuditPolicyClient{
+		apiClient: apiClient,
+	}
+}
+
+func (c *AuditPolicyClient) Create(container *AuditPolicy) (*AuditPolicy, error) {
+	resp := &AuditPolicy{}
+	err := c.apiClient.Ops.DoCreate(AuditPolicyType, container, resp)
+	return resp, err
+}
+
+func (c *AuditPolicyClient) Update(existing *AuditPolicy, updates interface{}) (*AuditPolicy, error) {
+	resp := &AuditPolicy{}
+	err := c.apiClient.Ops.DoUpdate(AuditPolicyType, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *AuditPolicyClient) Replace(obj *AuditPolicy) (*AuditPolicy, error) {
+	resp := &AuditPolicy{}
+	err := c.apiClient.Ops.DoReplace(AuditPolicyType, &obj.Resource, obj, resp)
+	return resp, err
+}
+
+func (c *AuditPolicyClient) List(opts *types.ListOpts) (*AuditPolicyCollection, error) {
+	resp := &AuditPolicyCollection{}
+	err := c.apiClient.Ops.DoList(AuditPolicyType, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (c *AuditPolicyClient) ListAll(opts *types.ListOpts) (*AuditPolicyCollection, error) {
+	resp := &AuditPolicyCollection{}
+	resp, err := c.List(opts)
+	if err != nil {
+		return resp, err
+	}
+	data := resp.Data
+	for next, err := resp.Next(); next != nil && err == nil; next, err = next.Next() {
+		data = append(data, next.Data...)
+		resp = next
+		resp.Data = data
+	}
+	if err != nil {
+		return resp, err
+	}
+	return resp, err
+}
+
+func (cc *AuditPolicyCollection) Next() (*AuditPolicyCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &AuditPolicyCollection{}
+		err := cc.client.apiClient.Ops.DoNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *AuditPolicyClient) ByID(id string) (*AuditPolicy, error) {
+	resp := &AuditPolicy{}
+	err := c.apiClient.Ops.DoByID(AuditPolicyType, id, resp)
+	return resp, err
+}
+
+func (c *AuditPolicyClient) Delete(container *AuditPolicy) error {
+	return c.apiClient.Ops.DoResourceDelete(AuditPolicyType, &container.Resource)
+}
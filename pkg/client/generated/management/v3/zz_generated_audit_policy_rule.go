@@ -0,0 +1,22 @@
+package client
+
+const (
+	AuditPolicyRuleType                    = "auditPolicyRule"
+	AuditPolicyRuleFieldAPIGroups          = "apiGroups"
+	AuditPolicyRuleFieldLevel              = "level"
+	AuditPolicyRuleFieldRequestURIPrefixes = "requestURIPrefixes"
+	AuditPolicyRuleFieldResources          = "resources"
+	AuditPolicyRuleFieldUserGroups         = "userGroups"
+	AuditPolicyRuleFieldUsers              = "users"
+	AuditPolicyRuleFieldVerbs              = "verbs"
+)
+
+type AuditPolicyRule struct {
+	APIGroups          []string `json:"apiGroups,omitempty" yaml:"apiGroups,omitempty"`
+	Level              string   `json:"level,omitempty" yaml:"level,omitempty"`
+	RequestURIPrefixes []string `json:"requestURIPrefixes,omitempty" yaml:"requestURIPrefixes,omitempty"`
+	Resources          []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+	UserGroups         []string `json:"userGroups,omitempty" yaml:"userGroups,omitempty"`
+	Users              []string `json:"users,omitempty" yaml:"users,omitempty"`
+	Verbs              []string `json:"verbs,omitempty" yaml:"verbs,omitempty"`
+}
@@ -0,0 +1,12 @@
+package client
+
+const (
+	AuditPolicySpecType            = "auditPolicySpec"
+	AuditPolicySpecFieldRedactions = "redactions"
+	AuditPolicySpecFieldRules      = "rules"
+)
+
+type AuditPolicySpec struct {
+	Redactions []AuditRedactionRule `json:"redactions,omitempty" yaml:"redactions,omitempty"`
+	Rules      []AuditPolicyRule    `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
@@ -0,0 +1,16 @@
+package client
+
+const (
+	AuditRedactionRuleType           = "auditRedactionRule"
+	AuditRedactionRuleFieldHeaders   = "headers"
+	AuditRedactionRuleFieldKeys      = "keys"
+	AuditRedactionRuleFieldPaths     = "paths"
+	AuditRedactionRuleFieldResources = "resources"
+)
+
+type AuditRedactionRule struct {
+	Headers   []string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Keys      []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+	Paths     []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
@@ -37,6 +37,7 @@ type Client struct {
 	Token                                   TokenOperations
 	DynamicSchema                           DynamicSchemaOperations
 	Preference                              PreferenceOperations
+	LoginLockout                            LoginLockoutOperations
 	ProjectNetworkPolicy                    ProjectNetworkPolicyOperations
 	ClusterLogging                          ClusterLoggingOperations
 	ProjectLogging                          ProjectLoggingOperations
@@ -73,6 +74,7 @@ type Client struct {
 	CisBenchmarkVersion                     CisBenchmarkVersionOperations
 	FleetWorkspace                          FleetWorkspaceOperations
 	RancherUserNotification                 RancherUserNotificationOperations
+	AuditPolicy                             AuditPolicyOperations
 }
 
 func NewClient(opts *clientbase.ClientOpts) (*Client, error) {
@@ -115,6 +117,7 @@ func NewClient(opts *clientbase.ClientOpts) (*Client, error) {
 	client.Token = newTokenClient(client)
 	client.DynamicSchema = newDynamicSchemaClient(client)
 	client.Preference = newPreferenceClient(client)
+	client.LoginLockout = newLoginLockoutClient(client)
 	client.ProjectNetworkPolicy = newProjectNetworkPolicyClient(client)
 	client.ClusterLogging = newClusterLoggingClient(client)
 	client.ProjectLogging = newProjectLoggingClient(client)
@@ -151,6 +154,7 @@ func NewClient(opts *clientbase.ClientOpts) (*Client, error) {
 	client.CisBenchmarkVersion = newCisBenchmarkVersionClient(client)
 	client.FleetWorkspace = newFleetWorkspaceClient(client)
 	client.RancherUserNotification = newRancherUserNotificationClient(client)
+	client.AuditPolicy = newAuditPolicyClient(client)
 
 	return client, nil
 }
@@ -0,0 +1,14 @@
+package client
+
+const (
+	EnrollTOTPOutputType               = "enrollTOTPOutput"
+	EnrollTOTPOutputFieldRecoveryCodes = "recoveryCodes"
+	EnrollTOTPOutputFieldSecret        = "secret"
+	EnrollTOTPOutputFieldURL           = "url"
+)
+
+type EnrollTOTPOutput struct {
+	RecoveryCodes []string `json:"recoveryCodes,omitempty" yaml:"recoveryCodes,omitempty"`
+	Secret        string   `json:"secret,omitempty" yaml:"secret,omitempty"`
+	URL           string   `json:"url,omitempty" yaml:"url,omitempty"`
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"github.com/rancher/norman/types"
+)
+
+const (
+	LoginLockoutType                 = "loginLockout"
+	LoginLockoutFieldAnnotations     = "annotations"
+	LoginLockoutFieldCount           = "count"
+	LoginLockoutFieldCreated         = "created"
+	LoginLockoutFieldCreatorID       = "creatorId"
+	LoginLockoutFieldFailures        = "failures"
+	LoginLockoutFieldLabels          = "labels"
+	LoginLockoutFieldLockedUntil     = "lockedUntil"
+	LoginLockoutFieldName            = "name"
+	LoginLockoutFieldOwnerReferences = "ownerReferences"
+	LoginLockoutFieldRemoved         = "removed"
+	LoginLockoutFieldSourceIP        = "sourceIP"
+	LoginLockoutFieldUUID            = "uuid"
+	LoginLockoutFieldUsername        = "username"
+)
+
+type LoginLockout struct {
+	types.Resource
+	Annotations     map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	Count           int64             `json:"count,omitempty" yaml:"count,omitempty"`
+	Created         string            `json:"created,omitempty" yaml:"created,omitempty"`
+	CreatorID       string            `json:"creatorId,omitempty" yaml:"creatorId,omitempty"`
+	Failures        int64             `json:"failures,omitempty" yaml:"failures,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	LockedUntil     string            `json:"lockedUntil,omitempty" yaml:"lockedUntil,omitempty"`
+	Name            string            `json:"name,omitempty" yaml:"name,omitempty"`
+	OwnerReferences []OwnerReference  `json:"ownerReferences,omitempty" yaml:"ownerReferences,omitempty"`
+	Removed         string            `json:"removed,omitempty" yaml:"removed,omitempty"`
+	SourceIP        string            `json:"sourceIP,omitempty" yaml:"sourceIP,omitempty"`
+	UUID            string            `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+	Username        string            `json:"username,omitempty" yaml:"username,omitempty"`
+}
+
+type LoginLockoutCollection struct {
+	types.Collection
+	Data   []LoginLockout `json:"data,omitempty"`
+	client *LoginLockoutClient
+}
+
+type LoginLockoutClient struct {
+	apiClient *Client
+}
+
+type LoginLockoutOperations interface {
+	List(opts *types.ListOpts) (*LoginLockoutCollection, error)
+	ListAll(opts *types.ListOpts) (*LoginLockoutCollection, error)
+	Create(opts *LoginLockout) (*LoginLockout, error)
+	Update(existing *LoginLockout, updates interface{}) (*LoginLockout, error)
+	Replace(existing *LoginLockout) (*LoginLockout, error)
+	ByID(id string) (*LoginLockout, error)
+	Delete(container *LoginLockout) error
+}
+
+func newLoginLockoutClient(apiClient *Client) *LoginLockoutClient {
+	return &LoginLockoutClient{
+		apiClient: apiClient,
+	}
+}
+
+func (c *LoginLockoutClient) Create(container *LoginLockout) (*LoginLockout, error) {
+	resp := &LoginLockout{}
+	err := c.apiClient.Ops.DoCreate(LoginLockoutType, container, resp)
+	return resp, err
+}
+
+func (c *LoginLockoutClient) Update(existing *LoginLockout, updates interface{}) (*LoginLockout, error) {
+	resp := &LoginLockout{}
+	err := c.apiClient.Ops.DoUpdate(LoginLockoutType, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *LoginLockoutClient) Replace(obj *LoginLockout) (*LoginLockout, error) {
+	resp := &LoginLockout{}
+	err := c.apiClient.Ops.DoReplace(LoginLockoutType, &obj.Resource, obj, resp)
+	return resp, err
+}
+
+func (c *LoginLockoutClient) List(opts *types.ListOpts) (*LoginLockoutCollection, error) {
+	resp := &LoginLockoutCollection{}
+	err := c.apiClient.Ops.DoList(LoginLockoutType, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (c *LoginLockoutClient) ListAll(opts *types.ListOpts) (*LoginLockoutCollection, error) {
+	resp := &LoginLockoutCollection{}
+	resp, err := c.List(opts)
+	if err != nil {
+		return resp, err
+	}
+	data := resp.Data
+	for next, err := resp.Next(); next != nil && err == nil; next, err = next.Next() {
+		data = append(data, next.Data...)
+		resp = next
+		resp.Data = data
+	}
+	if err != nil {
+		return resp, err
+	}
+	return resp, err
+}
+
+func (cc *LoginLockoutCollection) Next() (*LoginLockoutCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &LoginLockoutCollection{}
+		err := cc.client.apiClient.Ops.DoNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *LoginLockoutClient) ByID(id string) (*LoginLockout, error) {
+	resp := &LoginLockout{}
+	err := c.apiClient.Ops.DoByID(LoginLockoutType, id, resp)
+	return resp, err
+}
+
+func (c *LoginLockoutClient) Delete(container *LoginLockout) error {
+	return c.apiClient.Ops.DoResourceDelete(LoginLockoutType, &container.Resource)
+}
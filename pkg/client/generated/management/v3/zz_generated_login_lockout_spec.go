@@ -0,0 +1,18 @@
+package client
+
+const (
+	LoginLockoutSpecType             = "loginLockoutSpec"
+	LoginLockoutSpecFieldCount       = "count"
+	LoginLockoutSpecFieldFailures    = "failures"
+	LoginLockoutSpecFieldLockedUntil = "lockedUntil"
+	LoginLockoutSpecFieldSourceIP    = "sourceIP"
+	LoginLockoutSpecFieldUsername    = "username"
+)
+
+type LoginLockoutSpec struct {
+	Count       int64  `json:"count,omitempty" yaml:"count,omitempty"`
+	Failures    int64  `json:"failures,omitempty" yaml:"failures,omitempty"`
+	LockedUntil string `json:"lockedUntil,omitempty" yaml:"lockedUntil,omitempty"`
+	SourceIP    string `json:"sourceIP,omitempty" yaml:"sourceIP,omitempty"`
+	Username    string `json:"username,omitempty" yaml:"username,omitempty"`
+}
@@ -0,0 +1,18 @@
+package client
+
+const (
+	OIDCClaimMappingType                  = "oidcClaimMapping"
+	OIDCClaimMappingFieldDisplayNameClaim = "displayNameClaim"
+	OIDCClaimMappingFieldGroupPrefix      = "groupPrefix"
+	OIDCClaimMappingFieldGroupsClaim      = "groupsClaim"
+	OIDCClaimMappingFieldUserPrefix       = "userPrefix"
+	OIDCClaimMappingFieldUsernameClaim    = "usernameClaim"
+)
+
+type OIDCClaimMapping struct {
+	DisplayNameClaim string `json:"displayNameClaim,omitempty" yaml:"displayNameClaim,omitempty"`
+	GroupPrefix      string `json:"groupPrefix,omitempty" yaml:"groupPrefix,omitempty"`
+	GroupsClaim      string `json:"groupsClaim,omitempty" yaml:"groupsClaim,omitempty"`
+	UserPrefix       string `json:"userPrefix,omitempty" yaml:"userPrefix,omitempty"`
+	UsernameClaim    string `json:"usernameClaim,omitempty" yaml:"usernameClaim,omitempty"`
+}
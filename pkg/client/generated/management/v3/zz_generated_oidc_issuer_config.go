@@ -0,0 +1,22 @@
+package client
+
+const (
+	OIDCIssuerConfigType              = "oidcIssuerConfig"
+	OIDCIssuerConfigFieldAuthEndpoint = "authEndpoint"
+	OIDCIssuerConfigFieldClaimMapping = "claimMapping"
+	OIDCIssuerConfigFieldClientID     = "clientId"
+	OIDCIssuerConfigFieldClientSecret = "clientSecret"
+	OIDCIssuerConfigFieldIssuer       = "issuer"
+	OIDCIssuerConfigFieldName         = "name"
+	OIDCIssuerConfigFieldScopes       = "scope"
+)
+
+type OIDCIssuerConfig struct {
+	AuthEndpoint string            `json:"authEndpoint,omitempty" yaml:"authEndpoint,omitempty"`
+	ClaimMapping *OIDCClaimMapping `json:"claimMapping,omitempty" yaml:"claimMapping,omitempty"`
+	ClientID     string            `json:"clientId,omitempty" yaml:"clientId,omitempty"`
+	ClientSecret string            `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+	Issuer       string            `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	Name         string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Scopes       string            `json:"scope,omitempty" yaml:"scope,omitempty"`
+}
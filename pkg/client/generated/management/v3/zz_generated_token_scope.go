@@ -0,0 +1,16 @@
+package client
+
+const (
+	TokenScopeType           = "tokenScope"
+	TokenScopeFieldAPIGroups = "apiGroups"
+	TokenScopeFieldProjects  = "projects"
+	TokenScopeFieldResources = "resources"
+	TokenScopeFieldVerbs     = "verbs"
+)
+
+type TokenScope struct {
+	APIGroups []string `json:"apiGroups,omitempty" yaml:"apiGroups,omitempty"`
+	Projects  []string `json:"projects,omitempty" yaml:"projects,omitempty"`
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Verbs     []string `json:"verbs,omitempty" yaml:"verbs,omitempty"`
+}
@@ -69,12 +69,24 @@ type UserOperations interface {
 	ByID(id string) (*User, error)
 	Delete(container *User) error
 
+	ActionEnrolltotp(resource *User) (*EnrollTOTPOutput, error)
+
 	ActionRefreshauthprovideraccess(resource *User) error
 
+	ActionResettotp(resource *User) error
+
 	ActionSetpassword(resource *User, input *SetPasswordInput) (*User, error)
 
+	ActionUnlocklogin(resource *User) error
+
+	CollectionActionActivatetotp(resource *UserCollection, input *VerifyTOTPInput) error
+
 	CollectionActionChangepassword(resource *UserCollection, input *ChangePasswordInput) error
 
+	CollectionActionDisabletotp(resource *UserCollection, input *VerifyTOTPInput) error
+
+	CollectionActionEnrolltotp(resource *UserCollection) (*EnrollTOTPOutput, error)
+
 	CollectionActionRefreshauthprovideraccess(resource *UserCollection) error
 }
 
@@ -147,22 +159,54 @@ func (c *UserClient) Delete(container *User) error {
 	return c.apiClient.Ops.DoResourceDelete(UserType, &container.Resource)
 }
 
+func (c *UserClient) ActionEnrolltotp(resource *User) (*EnrollTOTPOutput, error) {
+	resp := &EnrollTOTPOutput{}
+	err := c.apiClient.Ops.DoAction(UserType, "enrolltotp", &resource.Resource, nil, resp)
+	return resp, err
+}
+
 func (c *UserClient) ActionRefreshauthprovideraccess(resource *User) error {
 	err := c.apiClient.Ops.DoAction(UserType, "refreshauthprovideraccess", &resource.Resource, nil, nil)
 	return err
 }
 
+func (c *UserClient) ActionResettotp(resource *User) error {
+	err := c.apiClient.Ops.DoAction(UserType, "resettotp", &resource.Resource, nil, nil)
+	return err
+}
+
 func (c *UserClient) ActionSetpassword(resource *User, input *SetPasswordInput) (*User, error) {
 	resp := &User{}
 	err := c.apiClient.Ops.DoAction(UserType, "setpassword", &resource.Resource, input, resp)
 	return resp, err
 }
 
+func (c *UserClient) ActionUnlocklogin(resource *User) error {
+	err := c.apiClient.Ops.DoAction(UserType, "unlocklogin", &resource.Resource, nil, nil)
+	return err
+}
+
+func (c *UserClient) CollectionActionActivatetotp(resource *UserCollection, input *VerifyTOTPInput) error {
+	err := c.apiClient.Ops.DoCollectionAction(UserType, "activatetotp", &resource.Collection, input, nil)
+	return err
+}
+
 func (c *UserClient) CollectionActionChangepassword(resource *UserCollection, input *ChangePasswordInput) error {
 	err := c.apiClient.Ops.DoCollectionAction(UserType, "changepassword", &resource.Collection, input, nil)
 	return err
 }
 
+func (c *UserClient) CollectionActionDisabletotp(resource *UserCollection, input *VerifyTOTPInput) error {
+	err := c.apiClient.Ops.DoCollectionAction(UserType, "disabletotp", &resource.Collection, input, nil)
+	return err
+}
+
+func (c *UserClient) CollectionActionEnrolltotp(resource *UserCollection) (*EnrollTOTPOutput, error) {
+	resp := &EnrollTOTPOutput{}
+	err := c.apiClient.Ops.DoCollectionAction(UserType, "enrolltotp", &resource.Collection, nil, resp)
+	return resp, err
+}
+
 func (c *UserClient) CollectionActionRefreshauthprovideraccess(resource *UserCollection) error {
 	err := c.apiClient.Ops.DoCollectionAction(UserType, "refreshauthprovideraccess", &resource.Collection, nil, nil)
 	return err
@@ -0,0 +1,10 @@
+package client
+
+const (
+	VerifyTOTPInputType      = "verifyTOTPInput"
+	VerifyTOTPInputFieldCode = "code"
+)
+
+type VerifyTOTPInput struct {
+	Code string `json:"code,omitempty" yaml:"code,omitempty"`
+}
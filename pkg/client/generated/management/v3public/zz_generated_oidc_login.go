@@ -4,6 +4,7 @@
 	OIDCLoginType              = "oidcLogin"
 	OIDCLoginFieldCode         = "code"
 	OIDCLoginFieldDescription  = "description"
+	OIDCLoginFieldIssuer       = "issuer"
 	OIDCLoginFieldResponseType = "responseType"
 	OIDCLoginFieldTTLMillis    = "ttl"
 )
@@ -11,6 +12,7 @@
 type OIDCLogin struct {
 	Code         string `json:"code,omitempty" yaml:"code,omitempty"`
 	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
+	Issuer       string `json:"issuer,omitempty" yaml:"issuer,omitempty"`
 	ResponseType string `json:"responseType,omitempty" yaml:"responseType,omitempty"`
 	TTLMillis    int64  `json:"ttl,omitempty" yaml:"ttl,omitempty"`
 }
@@ -33,6 +33,9 @@ type tokenHandler struct {
 }
 
 func (h *tokenHandler) Create(token *managementv3.Token) (runtime.Object, error) {
+	if len(token.Scopes) > 0 {
+		return h.removeScoped(token)
+	}
 	_, err := h.clusterAuthTokenLister.Get(h.namespace, token.Name)
 	if !errors.IsNotFound(err) {
 		return h.Updated(token)
@@ -58,6 +61,9 @@ func (h *tokenHandler) Create(token *managementv3.Token) (runtime.Object, error)
 }
 
 func (h *tokenHandler) Updated(token *managementv3.Token) (runtime.Object, error) {
+	if len(token.Scopes) > 0 {
+		return h.removeScoped(token)
+	}
 	clusterAuthToken, err := h.clusterAuthTokenLister.Get(h.namespace, token.Name)
 	if errors.IsNotFound(err) {
 		return h.Create(token)
@@ -121,6 +127,21 @@ func (h *tokenHandler) Remove(token *managementv3.Token) (runtime.Object, error)
 	return nil, nil
 }
 
+// removeScoped makes sure a scoped token has no ClusterAuthToken. Scopes are only enforced by Rancher, a downstream
+// cluster authenticating the token with authorized cluster endpoint would grant it all permissions of its user, so
+// scoped tokens can only be used through Rancher.
+func (h *tokenHandler) removeScoped(token *managementv3.Token) (runtime.Object, error) {
+	_, err := h.clusterAuthTokenLister.Get(h.namespace, token.Name)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	err = h.clusterAuthToken.Delete(token.Name, &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	return nil, nil
+}
+
 func (h *tokenHandler) updateClusterUserAttribute(token *managementv3.Token) error {
 	userID := token.UserID
 	user, err := h.userLister.Get("", userID)
@@ -17,6 +17,7 @@
 	"github.com/rancher/rancher/pkg/controllers/managementuser/resourcequota"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/secret"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/snapshotbackpopulate"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/snapshotsource"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/windows"
 	"github.com/rancher/rancher/pkg/controllers/managementuserlegacy"
 	"github.com/rancher/rancher/pkg/features"
@@ -41,6 +42,7 @@ func Register(ctx context.Context, mgmt *config.ScaledContext, cluster *config.U
 		snapshotbackpopulate.Register(ctx, cluster)
 		pspdelete.Register(ctx, cluster)
 		machinerole.Register(ctx, cluster)
+		snapshotsource.Register(ctx, cluster)
 	}
 
 	// register controller for API
@@ -0,0 +1,80 @@
+package snapshotsource
+
+import (
+	"context"
+	"fmt"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	cluster2 "github.com/rancher/rancher/pkg/controllers/provisioningv2/cluster"
+	provisioningcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type handler struct {
+	clusterName  string
+	clusterCache provisioningcontrollers.ClusterCache
+	nodes        v1.NodeInterface
+}
+
+// Register sets up the snapshot source controller. A cluster seeded from the etcd snapshot of another cluster inherits
+// the nodes of the source cluster with the datastore, this controller deletes them unless they are to be kept.
+func Register(ctx context.Context, userContext *config.UserContext) {
+	h := handler{
+		clusterName:  userContext.ClusterName,
+		clusterCache: userContext.Management.Wrangler.Provisioning.Cluster().Cache(),
+		nodes:        userContext.Core.Nodes(""),
+	}
+	userContext.Core.Nodes("").Controller().AddHandler(ctx, "snapshot-source-nodes", h.OnChange)
+}
+
+func (h *handler) OnChange(_ string, node *corev1.Node) (runtime.Object, error) {
+	if node == nil || node.DeletionTimestamp != nil {
+		return node, nil
+	}
+
+	clusters, err := h.clusterCache.GetByIndex(cluster2.ByCluster, h.clusterName)
+	if err != nil || len(clusters) != 1 {
+		return node, fmt.Errorf("error while retrieving cluster %s from cache via index: %w", h.clusterName, err)
+	}
+
+	cluster := clusters[0]
+	if !isSourceNode(cluster, node) {
+		return node, nil
+	}
+
+	logrus.Infof("[snapshotsource] rkecluster %s/%s: deleting node %s of etcd snapshot source cluster %s", cluster.Namespace, cluster.Name, node.Name, node.Annotations[capi.ClusterNameAnnotation])
+	if err := h.nodes.Delete(node.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return node, err
+	}
+	return node, nil
+}
+
+// isSourceNode returns true if the node was restored from the etcd snapshot source of the cluster and is to be deleted.
+// Nodes of the source cluster are annotated with the name of the source cluster and never become ready, as no kubelet
+// of the cluster reports for them. Ready nodes are left alone, they may have been registered again under the same
+// name by the cluster.
+func isSourceNode(cluster *provv1.Cluster, node *corev1.Node) bool {
+	if cluster.Spec.RKEConfig == nil || cluster.Spec.RKEConfig.ETCDSnapshotSource == nil ||
+		cluster.Spec.RKEConfig.ETCDSnapshotSource.KeepSourceNodes {
+		return false
+	}
+
+	clusterName := node.Annotations[capi.ClusterNameAnnotation]
+	if clusterName == "" || clusterName == cluster.Name {
+		return false
+	}
+
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package snapshotsource
+
+import (
+	"testing"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestIsSourceNode(t *testing.T) {
+	cluster := &provv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "clone"},
+		Spec: provv1.ClusterSpec{
+			RKEConfig: &provv1.RKEConfig{
+				ETCDSnapshotSource: &rkev1.ETCDSnapshotSource{Name: "source-snapshot"},
+			},
+		},
+	}
+	newNode := func(clusterName string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{capi.ClusterNameAnnotation: clusterName},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+			},
+		}
+	}
+
+	assert.True(t, isSourceNode(cluster, newNode("source", corev1.ConditionUnknown)))
+	assert.False(t, isSourceNode(cluster, newNode("source", corev1.ConditionTrue)))
+	assert.False(t, isSourceNode(cluster, newNode("clone", corev1.ConditionUnknown)))
+	assert.False(t, isSourceNode(cluster, newNode("", corev1.ConditionUnknown)))
+
+	cluster.Spec.RKEConfig.ETCDSnapshotSource.KeepSourceNodes = true
+	assert.False(t, isSourceNode(cluster, newNode("source", corev1.ConditionUnknown)))
+
+	cluster.Spec.RKEConfig.ETCDSnapshotSource = nil
+	assert.False(t, isSourceNode(cluster, newNode("source", corev1.ConditionUnknown)))
+}
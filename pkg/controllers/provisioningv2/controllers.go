@@ -22,6 +22,7 @@
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2/secret"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2/unmanaged"
 	"github.com/rancher/rancher/pkg/features"
+	"github.com/rancher/rancher/pkg/provisioningv2/autoscaler"
 	"github.com/rancher/rancher/pkg/provisioningv2/capi"
 	"github.com/rancher/rancher/pkg/provisioningv2/kubeconfig"
 	planner2 "github.com/rancher/rancher/pkg/provisioningv2/rke2/planner"
@@ -45,6 +46,7 @@ func Register(ctx context.Context, clients *wrangler.Context) error {
 			dynamicschema.Register(ctx, clients)
 			secret.Register(ctx, clients)
 			machineprovision.Register(ctx, clients, kubeconfigManager)
+			autoscaler.Register(ctx, clients)
 		}
 		rkecluster.Register(ctx, clients)
 		provisioningcluster.Register(ctx, clients)
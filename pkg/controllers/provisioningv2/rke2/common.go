@@ -43,7 +43,9 @@
 	DrainAnnotation           = "rke.cattle.io/drain-options"
 	DrainDoneAnnotation       = "rke.cattle.io/drain-done"
 	DrainErrorAnnotation      = "rke.cattle.io/drain-error"
+	DryRunAnnotation          = "rke.cattle.io/dry-run"
 	EtcdRoleLabel             = "rke.cattle.io/etcd-role"
+	EventSeverityLabel        = "rke.cattle.io/event-severity"
 	InitNodeLabel             = "rke.cattle.io/init-node"
 	InitNodeMachineIDLabel    = "rke.cattle.io/init-node-machine-id"
 	InternalAddressAnnotation = "rke.cattle.io/internal-address"
@@ -62,6 +64,7 @@
 	PreDrainAnnotation        = "rke.cattle.io/pre-drain"
 	RoleLabel                 = "rke.cattle.io/service-account-role"
 	SecretTypeMachinePlan     = "rke.cattle.io/machine-plan"
+	SecretTypePlanOutput      = "rke.cattle.io/machine-plan-output"
 	TaintsAnnotation          = "rke.cattle.io/taints"
 	UnCordonAnnotation        = "rke.cattle.io/uncordon"
 	WorkerRoleLabel           = "rke.cattle.io/worker-role"
@@ -78,15 +81,17 @@
 	RKEMachineAPIVersion           = "rke-machine.cattle.io/v1"
 	RKEAPIVersion                  = "rke.cattle.io/v1"
 
-	Provisioned         = condition.Cond("Provisioned")
-	Updated             = condition.Cond("Updated")
-	Reconciled          = condition.Cond("Reconciled")
-	Ready               = condition.Cond("Ready")
-	Waiting             = condition.Cond("Waiting")
-	Pending             = condition.Cond("Pending")
-	Removed             = condition.Cond("Removed")
-	PlanApplied         = condition.Cond("PlanApplied")
-	InfrastructureReady = condition.Cond(capi.InfrastructureReadyCondition)
+	Provisioned                 = condition.Cond("Provisioned")
+	Updated                     = condition.Cond("Updated")
+	Reconciled                  = condition.Cond("Reconciled")
+	Ready                       = condition.Cond("Ready")
+	Waiting                     = condition.Cond("Waiting")
+	Pending                     = condition.Cond("Pending")
+	Removed                     = condition.Cond("Removed")
+	PlanApplied                 = condition.Cond("PlanApplied")
+	Verified                    = condition.Cond("Verified")
+	WaitingForMaintenanceWindow = condition.Cond("WaitingForMaintenanceWindow")
+	InfrastructureReady         = condition.Cond(capi.InfrastructureReadyCondition)
 
 	RuntimeK3S  = "k3s"
 	RuntimeRKE2 = "rke2"
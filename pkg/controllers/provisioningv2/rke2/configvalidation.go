@@ -0,0 +1,221 @@
+package rke2
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/rancher/channelserver/pkg/model"
+	"github.com/rancher/norman/types/convert"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateRKEConfig validates the machine configs, registries and local cluster auth endpoint of a cluster against the
+// flags known for the release of its Kubernetes version in the KDM data. Config keys unknown to the release are
+// errors, as the planner drops them from the config of the machines.
+func ValidateRKEConfig(release *model.Release, kubernetesVersion string, spec rkev1.RKEClusterSpecCommon, localClusterAuthEndpoint rkev1.LocalClusterAuthEndpoint) []rkev1.ConfigValidationResult {
+	var results []rkev1.ConfigValidationResult
+	if release == nil {
+		return nil
+	}
+	if release.Version == "" {
+		results = append(results, configWarning("kubernetesVersion", "release data for %s not found, validating against the flags of all releases", kubernetesVersion))
+	}
+
+	// the machine configs can't be validated before the KDM data is loaded
+	if len(release.AgentArgs) > 0 || len(release.ServerArgs) > 0 {
+		results = append(results, validateMachineConfig(release, "machineGlobalConfig", spec.MachineGlobalConfig.Data)...)
+		for i, selectorConfig := range spec.MachineSelectorConfig {
+			results = append(results, validateMachineConfig(release, fmt.Sprintf("machineSelectorConfig[%d].config", i), selectorConfig.Config.Data)...)
+		}
+	}
+	results = append(results, validateRegistries(spec.Registries)...)
+	results = append(results, validateLocalClusterAuthEndpoint(localClusterAuthEndpoint)...)
+	return results
+}
+
+// ConfigValidationErrors returns the messages of the errors among the validation results.
+func ConfigValidationErrors(results []rkev1.ConfigValidationResult) (errors []string) {
+	for _, result := range results {
+		if result.Severity == rkev1.ConfigValidationSeverityError {
+			errors = append(errors, result.Field+": "+result.Message)
+		}
+	}
+	return errors
+}
+
+func validateMachineConfig(release *model.Release, field string, config map[string]interface{}) (results []rkev1.ConfigValidationResult) {
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		argField, ok := release.AgentArgs[k]
+		if !ok {
+			argField, ok = release.ServerArgs[k]
+		}
+		if !ok {
+			results = append(results, configError(field+"."+k, "%s is not a flag of %s", k, releaseName(release)))
+			continue
+		}
+
+		v := config[k]
+		switch v.(type) {
+		case nil, string, bool, []interface{}:
+		default:
+			results = append(results, configError(field+"."+k, "value must be a string, a boolean or a list of strings"))
+			continue
+		}
+
+		if argField.Type == "boolean" {
+			if s, ok := v.(string); ok && s != "true" && s != "false" {
+				results = append(results, configWarning(field+"."+k, "value %q of boolean flag %s will be converted to %t", s, k, convert.ToBool(v)))
+			}
+			continue
+		}
+
+		if len(argField.Options) > 0 {
+			value := convert.ToString(v)
+			if !validOption(argField.Options, value) {
+				results = append(results, configError(field+"."+k, "value %q is not one of the options %s of %s", value, strings.Join(argField.Options, ", "), releaseName(release)))
+			}
+		}
+		if k == "cni" {
+			results = append(results, validateCNI(field+"."+k, convert.ToString(v))...)
+		}
+	}
+	return results
+}
+
+// validOption returns true if the value is one of the options, or if all values of a comma separated list are.
+func validOption(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	for _, part := range strings.Split(value, ",") {
+		found := false
+		for _, option := range options {
+			if option == strings.TrimSpace(part) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// validateCNI checks the combinations of CNIs: multus must be followed by another CNI, and none can't be combined.
+func validateCNI(field, value string) (results []rkev1.ConfigValidationResult) {
+	cnis := strings.Split(value, ",")
+	for i, cni := range cnis {
+		switch strings.TrimSpace(cni) {
+		case "multus":
+			if i != 0 || len(cnis) == 1 {
+				results = append(results, configError(field, "multus must be the first of at least two CNIs"))
+			}
+		case "none":
+			if len(cnis) > 1 {
+				results = append(results, configError(field, "none can't be combined with other CNIs"))
+			}
+		}
+	}
+	return results
+}
+
+func validateRegistries(registries *rkev1.Registry) (results []rkev1.ConfigValidationResult) {
+	if registries == nil {
+		return nil
+	}
+
+	mirrors := make([]string, 0, len(registries.Mirrors))
+	for registry := range registries.Mirrors {
+		mirrors = append(mirrors, registry)
+	}
+	sort.Strings(mirrors)
+
+	for _, registry := range mirrors {
+		field := "registries.mirrors." + registry
+		if strings.Contains(registry, "://") {
+			results = append(results, configError(field, "registry %s must be a host name without a scheme", registry))
+		}
+		mirror := registries.Mirrors[registry]
+		if len(mirror.Endpoints) == 0 {
+			results = append(results, configWarning(field, "mirror has no endpoints, %s will be pulled from directly", registry))
+		}
+		for _, endpoint := range mirror.Endpoints {
+			u, err := url.Parse(endpoint)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				results = append(results, configError(field, "endpoint %s must be an http or https URL with a host", endpoint))
+			}
+		}
+	}
+
+	configs := make([]string, 0, len(registries.Configs))
+	for registry := range registries.Configs {
+		configs = append(configs, registry)
+	}
+	sort.Strings(configs)
+
+	for _, registry := range configs {
+		if strings.Contains(registry, "://") {
+			results = append(results, configError("registries.configs."+registry, "registry %s must be a host name without a scheme", registry))
+		}
+	}
+	return results
+}
+
+func validateLocalClusterAuthEndpoint(endpoint rkev1.LocalClusterAuthEndpoint) (results []rkev1.ConfigValidationResult) {
+	if !endpoint.Enabled {
+		if endpoint.FQDN != "" || endpoint.CACerts != "" {
+			results = append(results, configWarning("localClusterAuthEndpoint", "fqdn and caCerts are ignored while the endpoint is disabled"))
+		}
+		return results
+	}
+
+	if endpoint.CACerts != "" && endpoint.FQDN == "" {
+		results = append(results, configError("localClusterAuthEndpoint.caCerts", "caCerts requires an fqdn"))
+	}
+	if endpoint.FQDN != "" {
+		host := endpoint.FQDN
+		if h, _, err := net.SplitHostPort(endpoint.FQDN); err == nil {
+			host = h
+		}
+		if errs := validation.IsDNS1123Subdomain(host); len(errs) > 0 && net.ParseIP(host) == nil {
+			results = append(results, configError("localClusterAuthEndpoint.fqdn", "%s is not a valid host name: %s", endpoint.FQDN, strings.Join(errs, ", ")))
+		}
+	}
+	return results
+}
+
+func releaseName(release *model.Release) string {
+	if release.Version == "" {
+		return "any release"
+	}
+	return release.Version
+}
+
+func configError(field, format string, args ...interface{}) rkev1.ConfigValidationResult {
+	return rkev1.ConfigValidationResult{
+		Severity: rkev1.ConfigValidationSeverityError,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func configWarning(field, format string, args ...interface{}) rkev1.ConfigValidationResult {
+	return rkev1.ConfigValidationResult{
+		Severity: rkev1.ConfigValidationSeverityWarning,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,105 @@
+package rke2
+
+import (
+	"testing"
+
+	"github.com/rancher/channelserver/pkg/model"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/schemas"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRKEConfig(t *testing.T) {
+	release := &model.Release{
+		Version: "v1.25.6+rke2r1",
+		ServerArgs: map[string]schemas.Field{
+			"cni":                 {Type: "array", Options: []string{"canal", "calico", "cilium", "multus", "none"}},
+			"disable-kube-proxy":  {Type: "boolean"},
+			"etcd-expose-metrics": {Type: "boolean"},
+			"kube-apiserver-arg":  {Type: "array"},
+			"profile":             {Type: "string", Options: []string{"cis-1.6", "cis-1.23"}},
+		},
+		AgentArgs: map[string]schemas.Field{
+			"kubelet-arg": {Type: "array"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		spec     rkev1.RKEClusterSpecCommon
+		endpoint rkev1.LocalClusterAuthEndpoint
+		expected []rkev1.ConfigValidationResult
+	}{
+		{
+			name: "valid",
+			spec: rkev1.RKEClusterSpecCommon{
+				MachineGlobalConfig: rkev1.GenericMap{Data: map[string]interface{}{
+					"cni":                "multus,canal",
+					"disable-kube-proxy": false,
+					"kube-apiserver-arg": []interface{}{"audit-log-maxage=30"},
+				}},
+				Registries: &rkev1.Registry{Mirrors: map[string]rkev1.Mirror{
+					"docker.io": {Endpoints: []string{"https://mirror.example.com"}},
+				}},
+			},
+			endpoint: rkev1.LocalClusterAuthEndpoint{Enabled: true, FQDN: "k8s.example.com:6443"},
+		},
+		{
+			name: "unknown keys and invalid options",
+			spec: rkev1.RKEClusterSpecCommon{
+				MachineGlobalConfig: rkev1.GenericMap{Data: map[string]interface{}{
+					"cni":     "flannel",
+					"profile": "cis-1.5",
+				}},
+				MachineSelectorConfig: []rkev1.RKESystemConfig{{
+					Config: rkev1.GenericMap{Data: map[string]interface{}{"kubelet-args": []interface{}{"max-pods=250"}}},
+				}},
+			},
+			expected: []rkev1.ConfigValidationResult{
+				configError("machineGlobalConfig.cni", `value "flannel" is not one of the options canal, calico, cilium, multus, none of v1.25.6+rke2r1`),
+				configError("machineGlobalConfig.profile", `value "cis-1.5" is not one of the options cis-1.6, cis-1.23 of v1.25.6+rke2r1`),
+				configError("machineSelectorConfig[0].config.kubelet-args", "kubelet-args is not a flag of v1.25.6+rke2r1"),
+			},
+		},
+		{
+			name: "cni combinations and booleans",
+			spec: rkev1.RKEClusterSpecCommon{
+				MachineGlobalConfig: rkev1.GenericMap{Data: map[string]interface{}{
+					"cni":                 "canal,multus",
+					"etcd-expose-metrics": "yes",
+				}},
+			},
+			expected: []rkev1.ConfigValidationResult{
+				configError("machineGlobalConfig.cni", "multus must be the first of at least two CNIs"),
+				configWarning("machineGlobalConfig.etcd-expose-metrics", `value "yes" of boolean flag etcd-expose-metrics will be converted to true`),
+			},
+		},
+		{
+			name: "registries and local cluster auth endpoint",
+			spec: rkev1.RKEClusterSpecCommon{
+				Registries: &rkev1.Registry{
+					Mirrors: map[string]rkev1.Mirror{
+						"https://docker.io": {Endpoints: []string{"mirror.example.com"}},
+					},
+					Configs: map[string]rkev1.RegistryConfig{
+						"https://mirror.example.com": {},
+					},
+				},
+			},
+			endpoint: rkev1.LocalClusterAuthEndpoint{Enabled: true, CACerts: "cert"},
+			expected: []rkev1.ConfigValidationResult{
+				configError("registries.mirrors.https://docker.io", "registry https://docker.io must be a host name without a scheme"),
+				configError("registries.mirrors.https://docker.io", "endpoint mirror.example.com must be an http or https URL with a host"),
+				configError("registries.configs.https://mirror.example.com", "registry https://mirror.example.com must be a host name without a scheme"),
+				configError("localClusterAuthEndpoint.caCerts", "caCerts requires an fqdn"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ValidateRKEConfig(release, release.Version, tt.spec, tt.endpoint))
+		})
+	}
+
+	assert.Len(t, ConfigValidationErrors(ValidateRKEConfig(release, release.Version, tests[1].spec, tests[1].endpoint)), 3)
+}
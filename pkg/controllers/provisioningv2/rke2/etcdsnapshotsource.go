@@ -0,0 +1,45 @@
+package rke2
+
+import (
+	"context"
+	"fmt"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// creatorIDAnnotation is set by the webhook to the user that created a cluster.
+const creatorIDAnnotation = "field.cattle.io/creatorId"
+
+// AuthorizeETCDSnapshotSource checks that the creator of a cluster may restore the snapshots of the source cluster of
+// its etcd snapshot source, which requires the permission to update the source cluster. A cluster seeded from a
+// snapshot gets the server token and the whole datastore of the source cluster, so being able to create clusters isn't
+// enough.
+func AuthorizeETCDSnapshotSource(ctx context.Context, sar authorizationv1.SubjectAccessReviewInterface, cluster *provv1.Cluster, sourceClusterName string) error {
+	creatorID := cluster.Annotations[creatorIDAnnotation]
+	if creatorID == "" {
+		return fmt.Errorf("cluster %s/%s has no creator, the permission to restore etcd snapshots of cluster %s can't be verified", cluster.Namespace, cluster.Name, sourceClusterName)
+	}
+
+	review, err := sar.Create(ctx, &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User: creatorID,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Verb:      "update",
+				Namespace: cluster.Namespace,
+				Group:     provv1.SchemeGroupVersion.Group,
+				Resource:  "clusters",
+				Name:      sourceClusterName,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("error checking the permission of %s to restore etcd snapshots of cluster %s/%s: %w", creatorID, cluster.Namespace, sourceClusterName, err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("creator %s of cluster %s/%s is not allowed to restore etcd snapshots of cluster %s", creatorID, cluster.Namespace, cluster.Name, sourceClusterName)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package rke2
+
+import (
+	"context"
+	"testing"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	authv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAuthorizeETCDSnapshotSource(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var reviewed *authv1.SubjectAccessReview
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviewed = action.(k8stesting.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+		review := reviewed.DeepCopy()
+		review.Status.Allowed = review.Spec.User == "u-owner"
+		return true, review, nil
+	})
+	sar := client.AuthorizationV1().SubjectAccessReviews()
+
+	cluster := &provv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "clone", Namespace: "fleet-default"}}
+	assert.Error(t, AuthorizeETCDSnapshotSource(context.Background(), sar, cluster, "prod"), "clusters without creator are denied")
+	assert.Nil(t, reviewed)
+
+	cluster.Annotations = map[string]string{creatorIDAnnotation: "u-member"}
+	assert.Error(t, AuthorizeETCDSnapshotSource(context.Background(), sar, cluster, "prod"))
+	assert.Equal(t, authv1.ResourceAttributes{
+		Verb:      "update",
+		Namespace: "fleet-default",
+		Group:     "provisioning.cattle.io",
+		Resource:  "clusters",
+		Name:      "prod",
+	}, *reviewed.Spec.ResourceAttributes)
+
+	cluster.Annotations[creatorIDAnnotation] = "u-owner"
+	assert.NoError(t, AuthorizeETCDSnapshotSource(context.Background(), sar, cluster, "prod"))
+}
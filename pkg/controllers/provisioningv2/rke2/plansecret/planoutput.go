@@ -0,0 +1,54 @@
+package plansecret
+
+import (
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"github.com/rancher/rancher/pkg/provisioningv2/rke2/planner"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reconcilePlanOutputHistory records the output of the plan applied according to the plan secret in the plan output
+// history secret of the machine, so the output of previously applied plans is still available once the plan changes.
+// The history secret is owned by the plan secret.
+func (h *handler) reconcilePlanOutputHistory(secret *corev1.Secret) error {
+	output, err := planner.SecretToPlanOutput(secret)
+	if err != nil || output == nil {
+		return err
+	}
+
+	historySecret, err := h.secretsCache.Get(secret.Namespace, planner.PlanOutputHistorySecretName(secret.Name))
+	if apierrors.IsNotFound(err) {
+		historySecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      planner.PlanOutputHistorySecretName(secret.Name),
+				Namespace: secret.Namespace,
+				Labels: map[string]string{
+					rke2.ClusterNameLabel: secret.Labels[rke2.ClusterNameLabel],
+					rke2.MachineNameLabel: secret.Labels[rke2.MachineNameLabel],
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "v1",
+					Kind:       "Secret",
+					Name:       secret.Name,
+					UID:        secret.UID,
+				}},
+			},
+			Type: rke2.SecretTypePlanOutput,
+		}
+		if _, err := planner.AddPlanOutputToHistory(historySecret, *output); err != nil {
+			return err
+		}
+		_, err = h.secrets.Create(historySecret)
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	historySecret = historySecret.DeepCopy()
+	if changed, err := planner.AddPlanOutputToHistory(historySecret, *output); err != nil || !changed {
+		return err
+	}
+	_, err = h.secrets.Update(historySecret)
+	return err
+}
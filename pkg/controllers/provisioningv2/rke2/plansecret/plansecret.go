@@ -27,6 +27,7 @@
 
 type handler struct {
 	secrets             corecontrollers.SecretClient
+	secretsCache        corecontrollers.SecretCache
 	machinesCache       capicontrollers.MachineCache
 	machinesClient      capicontrollers.MachineClient
 	etcdSnapshotsClient rkev1controllers.ETCDSnapshotClient
@@ -36,6 +37,7 @@ type handler struct {
 func Register(ctx context.Context, clients *wrangler.Context) {
 	h := handler{
 		secrets:             clients.Core.Secret(),
+		secretsCache:        clients.Core.Secret().Cache(),
 		machinesCache:       clients.CAPI.Machine().Cache(),
 		machinesClient:      clients.CAPI.Machine(),
 		etcdSnapshotsClient: clients.RKE.ETCDSnapshot(),
@@ -68,6 +70,16 @@ func (h *handler) OnChange(key string, secret *corev1.Secret) (*corev1.Secret, e
 		}
 	}
 
+	if v, ok := node.PeriodicOutput[planner.ETCDSnapshotVerifyInstructionName]; ok && len(v.Stdout) > 0 {
+		if err := h.reconcileEtcdSnapshotVerification(secret, v); err != nil {
+			logrus.Errorf("[plansecret] error reconciling snapshot verification for secret %s/%s: %v", secret.Namespace, secret.Name, err)
+		}
+	}
+
+	if err := h.reconcilePlanOutputHistory(secret); err != nil {
+		logrus.Errorf("[plansecret] error reconciling plan output history for secret %s/%s: %v", secret.Namespace, secret.Name, err)
+	}
+
 	appliedChecksum := string(secret.Data["applied-checksum"])
 	failedChecksum := string(secret.Data["failed-checksum"])
 	plan := secret.Data["plan"]
@@ -0,0 +1,155 @@
+package plansecret
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	sb "github.com/rancher/rancher/pkg/controllers/managementuser/snapshotbackpopulate"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// snapshotVerification is the result of restoring a snapshot into a scratch etcd instance as printed by the
+// etcd-snapshot-verify periodic instruction.
+type snapshotVerification struct {
+	Snapshot            string
+	Keys                int
+	RegistryKeys        int
+	KubeSystemNamespace bool
+}
+
+func outputToSnapshotVerification(stdout []byte) snapshotVerification {
+	var result snapshotVerification
+	scanner := bufio.NewScanner(bytes.NewBuffer(stdout))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "snapshot":
+			result.Snapshot = value
+		case "keys":
+			result.Keys, _ = strconv.Atoi(value)
+		case "registryKeys":
+			result.RegistryKeys, _ = strconv.Atoi(value)
+		case "kubeSystemNamespace":
+			result.KubeSystemNamespace = value == "true"
+		}
+	}
+	return result
+}
+
+// verificationError returns why a restored snapshot doesn't look like the datastore of a Kubernetes cluster, nil if it
+// does.
+func (s snapshotVerification) verificationError() error {
+	switch {
+	case s.Keys == 0:
+		return fmt.Errorf("restored snapshot %s contains no keys", s.Snapshot)
+	case s.RegistryKeys == 0:
+		return fmt.Errorf("restored snapshot %s contains no keys under /registry", s.Snapshot)
+	case !s.KubeSystemNamespace:
+		return fmt.Errorf("restored snapshot %s does not contain the kube-system namespace", s.Snapshot)
+	}
+	return nil
+}
+
+// reconcileEtcdSnapshotVerification sets the Verified condition of the local snapshot the output of the
+// etcd-snapshot-verify periodic instruction refers to.
+func (h *handler) reconcileEtcdSnapshotVerification(secret *corev1.Secret, output plan.PeriodicInstructionOutput) error {
+	result := outputToSnapshotVerification(output.Stdout)
+	if result.Snapshot == "" {
+		return nil
+	}
+
+	cnl := secret.Labels[rke2.ClusterNameLabel]
+	if len(cnl) == 0 {
+		return fmt.Errorf("node secret did not have label %s", rke2.ClusterNameLabel)
+	}
+	machineName, ok := secret.Labels[rke2.MachineNameLabel]
+	if !ok {
+		return fmt.Errorf("did not find machine label on secret %s/%s", secret.Namespace, secret.Name)
+	}
+	machine, err := h.machinesCache.Get(secret.Namespace, machineName)
+	if err != nil {
+		return err
+	}
+	if machine.Labels[rke2.MachineIDLabel] == "" {
+		return fmt.Errorf("error finding machine ID for machine %s/%s", machine.Namespace, machine.Name)
+	}
+
+	etcdSnapshots, err := h.etcdSnapshotsCache.List(secret.Namespace, labels.SelectorFromSet(map[string]string{
+		rke2.ClusterNameLabel: cnl,
+		rke2.MachineIDLabel:   machine.Labels[rke2.MachineIDLabel],
+	}))
+	if err != nil {
+		return err
+	}
+
+	snapshotName := sanitizeSnapshotName(result.Snapshot)
+	var snapshot *v1.ETCDSnapshot
+	for _, s := range etcdSnapshots {
+		if sanitizeSnapshotName(s.SnapshotFile.Name) == snapshotName {
+			snapshot = s
+			break
+		}
+	}
+	if snapshot == nil {
+		// the snapshot object is created from the snapshot list output, the next verification will find it.
+		logrus.Debugf("[plansecret] machine %s/%s: no etcd snapshot object found for verified snapshot %s", machine.Namespace, machine.Name, result.Snapshot)
+		return nil
+	}
+
+	verifyErr := result.verificationError()
+	message := fmt.Sprintf("restored %d keys, %d under /registry", result.Keys, result.RegistryKeys)
+	lastRun := output.LastSuccessfulRunTime
+	if output.ExitCode != 0 {
+		verifyErr = fmt.Errorf("restoring snapshot %s failed with exit code %d: %s", result.Snapshot, output.ExitCode, strings.TrimSpace(string(output.Stderr)))
+		lastRun = ""
+	}
+	if verifyErr != nil {
+		message = verifyErr.Error()
+	}
+
+	if rke2.Verified.IsTrue(snapshot) == (verifyErr == nil) && rke2.Verified.GetMessage(snapshot) == message &&
+		(lastRun == "" || rke2.Verified.GetLastUpdated(snapshot) == formatRunTime(lastRun)) {
+		return nil
+	}
+
+	snapshot = snapshot.DeepCopy()
+	rke2.Verified.SetStatusBool(snapshot, verifyErr == nil)
+	rke2.Verified.Message(snapshot, message)
+	if verifyErr != nil {
+		rke2.Verified.Reason(snapshot, "Error")
+	} else {
+		rke2.Verified.Reason(snapshot, "")
+	}
+	if lastRun != "" {
+		rke2.Verified.LastUpdated(snapshot, formatRunTime(lastRun))
+	}
+	logrus.Infof("[plansecret] machine %s/%s: setting Verified=%t on etcd snapshot %s/%s: %s", machine.Namespace, machine.Name, verifyErr == nil, snapshot.Namespace, snapshot.Name, message)
+	_, err = h.etcdSnapshotsClient.UpdateStatus(snapshot)
+	return err
+}
+
+func sanitizeSnapshotName(name string) string {
+	return strings.ToLower(sb.InvalidKeyChars.ReplaceAllString(name, "-"))
+}
+
+// formatRunTime converts the time.UnixDate run time reported by the system-agent to the RFC3339 format of condition
+// timestamps.
+func formatRunTime(runTime string) string {
+	t, err := time.Parse(time.UnixDate, runTime)
+	if err != nil {
+		return runTime
+	}
+	return t.UTC().Format(time.RFC3339)
+}
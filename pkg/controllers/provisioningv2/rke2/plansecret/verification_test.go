@@ -0,0 +1,29 @@
+package plansecret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputToSnapshotVerification(t *testing.T) {
+	result := outputToSnapshotVerification([]byte("snapshot=etcd-snapshot-node1-1681300800\nkeys=1523\nregistryKeys=1490\nkubeSystemNamespace=true\n"))
+	assert.Equal(t, snapshotVerification{
+		Snapshot:            "etcd-snapshot-node1-1681300800",
+		Keys:                1523,
+		RegistryKeys:        1490,
+		KubeSystemNamespace: true,
+	}, result)
+	assert.NoError(t, result.verificationError())
+
+	result = outputToSnapshotVerification([]byte("snapshot=etcd-snapshot-node1-1681300800\nkeys=3\nregistryKeys=0\nkubeSystemNamespace=false\n"))
+	assert.EqualError(t, result.verificationError(), "restored snapshot etcd-snapshot-node1-1681300800 contains no keys under /registry")
+
+	result = outputToSnapshotVerification([]byte("snapshot=etcd-snapshot-node1-1681300800\n"))
+	assert.EqualError(t, result.verificationError(), "restored snapshot etcd-snapshot-node1-1681300800 contains no keys")
+}
+
+func TestFormatRunTime(t *testing.T) {
+	assert.Equal(t, "2023-04-12T12:00:00Z", formatRunTime("Wed Apr 12 12:00:00 UTC 2023"))
+	assert.Equal(t, "not a time", formatRunTime("not a time"))
+}
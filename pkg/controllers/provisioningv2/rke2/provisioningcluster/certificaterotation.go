@@ -0,0 +1,80 @@
+package provisioningcluster
+
+import (
+	"strconv"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/sirupsen/logrus"
+)
+
+// rotateExpiringCertificates requests a rotation of all certificates of a cluster with automatic certificate rotation
+// enabled once a certificate collected by the planner expires within the configured number of days. It returns
+// generic.ErrSkip if the cluster was updated.
+func (h *handler) rotateExpiringCertificates(cluster *rancherv1.Cluster, cp *rkev1.RKEControlPlane) error {
+	automatic := cluster.Spec.RKEConfig.AutomaticCertificateRotation
+	if automatic == nil || !automatic.Enabled || !cp.Status.Initialized {
+		return nil
+	}
+
+	rotation := cluster.Spec.RKEConfig.RotateCertificates
+	if rotation != nil && rotation.Generation != cp.Status.CertificateRotationGeneration {
+		// a rotation is already pending
+		return nil
+	}
+
+	expiring, ok := expiringCertificate(cp.Status.CertificateExpiration, certificateExpiresInDays(automatic), time.Now())
+	if !ok {
+		return nil
+	}
+
+	generation := int64(1)
+	if rotation != nil {
+		generation = rotation.Generation + 1
+	}
+	cluster = cluster.DeepCopy()
+	cluster.Spec.RKEConfig.RotateCertificates = &rkev1.RotateCertificates{
+		Generation: generation,
+	}
+
+	logrus.Infof("rkecluster %s/%s: rotating certificates, certificate %s of machine %s expires at %s",
+		cluster.Namespace, cluster.Name, expiring.Certificate, expiring.MachineName, expiring.ExpiresAt.UTC().Format(time.RFC3339))
+	if _, err := h.clusterController.Update(cluster); err != nil {
+		return err
+	}
+	return generic.ErrSkip
+}
+
+// certificateExpiresInDays returns the number of days before the expiration of a certificate at which the
+// certificates are rotated.
+func certificateExpiresInDays(automatic *rkev1.AutomaticCertificateRotation) int {
+	if automatic.ExpiresInDays > 0 {
+		return automatic.ExpiresInDays
+	}
+	days, err := strconv.Atoi(settings.RotateCertsIfExpiringInDays.Get())
+	if err != nil || days <= 0 {
+		return 7
+	}
+	return days
+}
+
+// expiringCertificate returns the first certificate expiring within the given number of days.
+func expiringCertificate(expirations []rkev1.CertificateExpiration, days int, now time.Time) (rkev1.CertificateExpiration, bool) {
+	var (
+		result rkev1.CertificateExpiration
+		found  bool
+	)
+	threshold := now.Add(time.Duration(days) * 24 * time.Hour)
+	for _, expiration := range expirations {
+		if expiration.ExpiresAt.IsZero() || expiration.ExpiresAt.Time.After(threshold) {
+			continue
+		}
+		if !found || expiration.ExpiresAt.Before(&result.ExpiresAt) {
+			result, found = expiration, true
+		}
+	}
+	return result, found
+}
@@ -11,6 +11,7 @@
 	"github.com/rancher/lasso/pkg/dynamic"
 	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/channelserver"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
 	"github.com/rancher/rancher/pkg/features"
 	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
@@ -30,6 +31,7 @@
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -41,31 +43,35 @@
 )
 
 type handler struct {
-	dynamic           *dynamic.Controller
-	dynamicSchema     mgmtcontroller.DynamicSchemaCache
-	clusterCache      rocontrollers.ClusterCache
-	clusterController rocontrollers.ClusterController
-	secretCache       corecontrollers.SecretCache
-	secretClient      corecontrollers.SecretClient
-	capiClusters      capicontrollers.ClusterCache
-	mgmtClusterCache  mgmtcontroller.ClusterCache
-	mgmtClusterClient mgmtcontroller.ClusterClient
-	rkeControlPlane   rkecontroller.RKEControlPlaneCache
-	etcdSnapshotCache rkecontroller.ETCDSnapshotCache
-	capiMachineCache  capicontrollers.MachineCache
+	dynamic              *dynamic.Controller
+	dynamicSchema        mgmtcontroller.DynamicSchemaCache
+	clusterCache         rocontrollers.ClusterCache
+	clusterController    rocontrollers.ClusterController
+	secretCache          corecontrollers.SecretCache
+	secretClient         corecontrollers.SecretClient
+	capiClusters         capicontrollers.ClusterCache
+	mgmtClusterCache     mgmtcontroller.ClusterCache
+	mgmtClusterClient    mgmtcontroller.ClusterClient
+	rkeControlPlane      rkecontroller.RKEControlPlaneCache
+	etcdSnapshotCache    rkecontroller.ETCDSnapshotCache
+	etcdSnapshots        rkecontroller.ETCDSnapshotClient
+	capiMachineCache     capicontrollers.MachineCache
+	subjectAccessReviews authorizationv1.SubjectAccessReviewInterface
 }
 
 func Register(ctx context.Context, clients *wrangler.Context) {
 	h := handler{
-		dynamic:           clients.Dynamic,
-		secretCache:       clients.Core.Secret().Cache(),
-		secretClient:      clients.Core.Secret(),
-		clusterCache:      clients.Provisioning.Cluster().Cache(),
-		clusterController: clients.Provisioning.Cluster(),
-		capiClusters:      clients.CAPI.Cluster().Cache(),
-		rkeControlPlane:   clients.RKE.RKEControlPlane().Cache(),
-		etcdSnapshotCache: clients.RKE.ETCDSnapshot().Cache(),
-		capiMachineCache:  clients.CAPI.Machine().Cache(),
+		dynamic:              clients.Dynamic,
+		secretCache:          clients.Core.Secret().Cache(),
+		secretClient:         clients.Core.Secret(),
+		clusterCache:         clients.Provisioning.Cluster().Cache(),
+		clusterController:    clients.Provisioning.Cluster(),
+		capiClusters:         clients.CAPI.Cluster().Cache(),
+		rkeControlPlane:      clients.RKE.RKEControlPlane().Cache(),
+		etcdSnapshotCache:    clients.RKE.ETCDSnapshot().Cache(),
+		etcdSnapshots:        clients.RKE.ETCDSnapshot(),
+		capiMachineCache:     clients.CAPI.Machine().Cache(),
+		subjectAccessReviews: clients.K8s.AuthorizationV1().SubjectAccessReviews(),
 	}
 
 	if features.MCM.Enabled() {
@@ -173,6 +179,12 @@ func (h *handler) findSnapshotClusterSpec(snapshotNamespace, snapshotName string
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving etcdsnapshot %s/%s: %w", snapshotNamespace, snapshotName, err)
 	}
+	return SnapshotClusterSpec(snapshot)
+}
+
+// SnapshotClusterSpec returns the spec of the provisioning cluster stored in the metadata of the snapshot when it was
+// taken.
+func SnapshotClusterSpec(snapshot *rkev1.ETCDSnapshot) (*rancherv1.ClusterSpec, error) {
 	if snapshot.SnapshotFile.Metadata != "" {
 		var md map[string]string
 		b, err := base64.StdEncoding.DecodeString(snapshot.SnapshotFile.Metadata)
@@ -186,7 +198,7 @@ func (h *handler) findSnapshotClusterSpec(snapshotNamespace, snapshotName string
 			return decompressClusterSpec(v)
 		}
 	}
-	return nil, fmt.Errorf("unable to find and decode snapshot ClusterSpec for snapshot %s/%s", snapshotNamespace, snapshotName)
+	return nil, fmt.Errorf("unable to find and decode snapshot ClusterSpec for snapshot %s/%s", snapshot.Namespace, snapshot.Name)
 }
 
 // reconcileClusterSpecEtcdRestore reconciles the cluster against the desiredSpec, but only sets fields that should be set
@@ -241,6 +253,9 @@ func (h *handler) OnRancherClusterChange(obj *rancherv1.Cluster, status rancherv
 		return nil, status, nil
 	}
 
+	release := channelserver.GetReleaseConfigByRuntimeAndVersion(context.TODO(), rke2.GetRuntime(obj.Spec.KubernetesVersion), obj.Spec.KubernetesVersion)
+	status.ConfigValidation = rke2.ValidateRKEConfig(&release, obj.Spec.KubernetesVersion, obj.Spec.RKEConfig.RKEClusterSpecCommon, obj.Spec.LocalClusterAuthEndpoint)
+
 	rkeCP, err := h.getRKEControlPlaneForCluster(obj)
 	if err != nil {
 		return nil, status, err
@@ -248,6 +263,18 @@ func (h *handler) OnRancherClusterChange(obj *rancherv1.Cluster, status rancherv
 
 	// If the rkecontrolplane is not nil, we can check it to determine action items.
 	if rkeCP != nil {
+		if err := h.restoreEtcdSnapshotSource(obj, rkeCP); err != nil {
+			return nil, status, err
+		}
+		if err := h.rollbackUpgrade(obj, rkeCP); err != nil {
+			return nil, status, err
+		}
+		if err := h.rotateExpiringCertificates(obj, rkeCP); err != nil {
+			return nil, status, err
+		}
+		if err := h.rotateEncryptionKeysOnSchedule(obj, rkeCP); err != nil {
+			return nil, status, err
+		}
 		// If EtcdSnapshotRestore is not nil, we need to check to see if we need to update the cluster object it.
 		if obj.Spec.RKEConfig.ETCDSnapshotRestore != nil &&
 			obj.Spec.RKEConfig.ETCDSnapshotRestore.Name != "" &&
@@ -284,6 +311,10 @@ func (h *handler) OnRancherClusterChange(obj *rancherv1.Cluster, status rancherv
 				}
 			}
 		}
+		if rke2.WaitingForMaintenanceWindow.GetStatus(rkeCP) != "" {
+			rke2.WaitingForMaintenanceWindow.SetStatus(&status, rke2.WaitingForMaintenanceWindow.GetStatus(rkeCP))
+			rke2.WaitingForMaintenanceWindow.Message(&status, rke2.WaitingForMaintenanceWindow.GetMessage(rkeCP))
+		}
 		logrus.Debugf("rkecluster %s/%s: updating cluster provisioning status", obj.Namespace, obj.Name)
 		if status, err = h.setProvisionedStatusFromMachineInfra(obj, status, rkeCP); err != nil && !apierror.IsNotFound(err) && !errors.Is(err, generic.ErrSkip) {
 			return nil, status, err
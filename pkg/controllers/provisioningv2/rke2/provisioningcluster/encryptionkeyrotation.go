@@ -0,0 +1,70 @@
+package provisioningcluster
+
+import (
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultEncryptionKeyRotationIntervalDays = 90
+
+// rotateEncryptionKeysOnSchedule requests a rotation of the encryption keys of a cluster with automatic encryption key
+// rotation enabled once the interval since the last successful rotation, or since the creation of the cluster if the
+// keys were never rotated, has passed. A failed rotation is not retried automatically. It returns generic.ErrSkip if
+// the cluster was updated.
+func (h *handler) rotateEncryptionKeysOnSchedule(cluster *rancherv1.Cluster, cp *rkev1.RKEControlPlane) error {
+	automatic := cluster.Spec.RKEConfig.AutomaticEncryptionKeyRotation
+	if automatic == nil || !automatic.Enabled || !cp.Status.Initialized {
+		return nil
+	}
+
+	rotation := cluster.Spec.RKEConfig.RotateEncryptionKeys
+	if rotation != nil && (cp.Status.RotateEncryptionKeys == nil ||
+		cp.Status.RotateEncryptionKeys.Generation != rotation.Generation ||
+		cp.Status.RotateEncryptionKeysPhase != rkev1.RotateEncryptionKeysPhaseDone) {
+		// a rotation is pending, in progress or failed
+		return nil
+	}
+
+	now := time.Now()
+	due := nextEncryptionKeyRotation(cp, automatic)
+	if now.Before(due) {
+		h.clusterController.EnqueueAfter(cluster.Namespace, cluster.Name, due.Sub(now))
+		return nil
+	}
+
+	generation := int64(1)
+	if rotation != nil {
+		generation = rotation.Generation + 1
+	}
+	cluster = cluster.DeepCopy()
+	cluster.Spec.RKEConfig.RotateEncryptionKeys = &rkev1.RotateEncryptionKeys{
+		Generation: generation,
+	}
+
+	logrus.Infof("rkecluster %s/%s: rotating encryption keys, scheduled rotation was due at %s", cluster.Namespace, cluster.Name, due.UTC().Format(time.RFC3339))
+	if _, err := h.clusterController.Update(cluster); err != nil {
+		return err
+	}
+	return generic.ErrSkip
+}
+
+// nextEncryptionKeyRotation returns the time the encryption keys of the control plane are due to be rotated.
+func nextEncryptionKeyRotation(cp *rkev1.RKEControlPlane, automatic *rkev1.AutomaticEncryptionKeyRotation) time.Time {
+	days := automatic.IntervalDays
+	if days <= 0 {
+		days = defaultEncryptionKeyRotationIntervalDays
+	}
+
+	last := cp.CreationTimestamp.Time
+	for _, entry := range cp.Status.EncryptionKeyRotationHistory {
+		if entry.Result == rkev1.RotateEncryptionKeysPhaseDone {
+			last = entry.FinishedAt.Time
+			break
+		}
+	}
+	return last.Add(time.Duration(days) * 24 * time.Hour)
+}
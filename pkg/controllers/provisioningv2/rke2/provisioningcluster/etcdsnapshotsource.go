@@ -0,0 +1,118 @@
+package provisioningcluster
+
+import (
+	"context"
+	"fmt"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/sirupsen/logrus"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// etcdSnapshotSourceRestoredAnnotation records the etcd snapshot source a cluster was restored from, so the source is
+// only restored once.
+const etcdSnapshotSourceRestoredAnnotation = "rke.cattle.io/etcd-snapshot-source-restored"
+
+// restoreEtcdSnapshotSource restores the etcd snapshot source of a cluster once its control plane is initialized. The
+// snapshot of the source cluster is copied to the cluster, pointing to the machine holding the file for a local
+// snapshot, and an etcd snapshot restore of the copy is requested. The creator of the cluster must be allowed to
+// restore snapshots of the source cluster. It returns generic.ErrSkip if the cluster was updated.
+func (h *handler) restoreEtcdSnapshotSource(cluster *rancherv1.Cluster, cp *rkev1.RKEControlPlane) error {
+	source := cluster.Spec.RKEConfig.ETCDSnapshotSource
+	if source == nil || source.Name == "" || !cp.Status.Initialized ||
+		cluster.Annotations[etcdSnapshotSourceRestoredAnnotation] == source.Name {
+		return nil
+	}
+
+	snapshot, err := h.etcdSnapshotCache.Get(cluster.Namespace, source.Name)
+	if err != nil {
+		return fmt.Errorf("error retrieving etcd snapshot source %s/%s: %w", cluster.Namespace, source.Name, err)
+	}
+
+	// the creator annotation is read from the cluster itself, the cached copy may lag behind
+	current, err := h.clusterController.Get(cluster.Namespace, cluster.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := rke2.AuthorizeETCDSnapshotSource(context.TODO(), h.subjectAccessReviews, current, snapshot.Spec.ClusterName); err != nil {
+		return err
+	}
+
+	restoreSnapshot, err := h.etcdSnapshotSourceCopy(cluster, snapshot, source.MachineName)
+	if err != nil {
+		return err
+	}
+	if _, err := h.etcdSnapshots.Create(restoreSnapshot); err != nil && !apierror.IsAlreadyExists(err) {
+		return fmt.Errorf("error creating etcd snapshot %s/%s from etcd snapshot source %s: %w", restoreSnapshot.Namespace, restoreSnapshot.Name, source.Name, err)
+	}
+
+	logrus.Infof("rkecluster %s/%s: restoring etcd snapshot source %s", cluster.Namespace, cluster.Name, source.Name)
+	cluster = cluster.DeepCopy()
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[etcdSnapshotSourceRestoredAnnotation] = source.Name
+	generation := 1
+	if cluster.Spec.RKEConfig.ETCDSnapshotRestore != nil {
+		generation = cluster.Spec.RKEConfig.ETCDSnapshotRestore.Generation + 1
+	}
+	cluster.Spec.RKEConfig.ETCDSnapshotRestore = &rkev1.ETCDSnapshotRestore{
+		Name:             restoreSnapshot.Name,
+		Generation:       generation,
+		RestoreRKEConfig: source.RestoreRKEConfig,
+	}
+	if _, err := h.clusterController.Update(cluster); err != nil {
+		return err
+	}
+	return generic.ErrSkip
+}
+
+// etcdSnapshotSourceCopy returns a copy of the snapshot of the source cluster that belongs to the cluster. The copy
+// isn't labeled with the cluster name, so it isn't mistaken for a snapshot taken by the cluster, and it is owned by
+// the cluster so it is removed along with it.
+func (h *handler) etcdSnapshotSourceCopy(cluster *rancherv1.Cluster, snapshot *rkev1.ETCDSnapshot, machineName string) (*rkev1.ETCDSnapshot, error) {
+	restoreSnapshot := &rkev1.ETCDSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.SafeConcatName(cluster.Name, "source", snapshot.Name),
+			Namespace: cluster.Namespace,
+			Labels:    map[string]string{},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         rancherv1.SchemeGroupVersion.String(),
+				Kind:               "Cluster",
+				Name:               cluster.Name,
+				UID:                cluster.UID,
+				Controller:         &[]bool{true}[0],
+				BlockOwnerDeletion: &[]bool{true}[0],
+			}},
+		},
+		Spec: rkev1.ETCDSnapshotSpec{
+			ClusterName: cluster.Name,
+		},
+		SnapshotFile: *snapshot.SnapshotFile.DeepCopy(),
+	}
+	if snapshot.SnapshotFile.S3 != nil {
+		return restoreSnapshot, nil
+	}
+
+	if machineName == "" {
+		return nil, fmt.Errorf("etcd snapshot source %s/%s is a local snapshot, the machine it was copied to must be set", snapshot.Namespace, snapshot.Name)
+	}
+	machine, err := h.capiMachineCache.Get(cluster.Namespace, machineName)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving machine %s/%s for etcd snapshot source %s: %w", cluster.Namespace, machineName, snapshot.Name, err)
+	}
+	if machine.Labels[capi.ClusterLabelName] != cluster.Name || machine.Labels[rke2.MachineIDLabel] == "" {
+		return nil, fmt.Errorf("machine %s/%s for etcd snapshot source %s is not a registered machine of cluster %s", machine.Namespace, machine.Name, snapshot.Name, cluster.Name)
+	}
+	restoreSnapshot.Labels[rke2.MachineIDLabel] = machine.Labels[rke2.MachineIDLabel]
+	if machine.Status.NodeRef != nil {
+		restoreSnapshot.SnapshotFile.NodeName = machine.Status.NodeRef.Name
+	}
+	return restoreSnapshot, nil
+}
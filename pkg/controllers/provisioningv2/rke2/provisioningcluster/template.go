@@ -538,6 +538,7 @@ func rkeControlPlane(cluster *rancherv1.Cluster) (*rkev1.RKEControlPlane, error)
 	filteredClusterSpec := cluster.Spec.DeepCopy()
 	// set the corresponding specification for various operations to nil as these cause unnecessary reconciliation.
 	filteredClusterSpec.RKEConfig.ETCDSnapshotRestore = nil
+	filteredClusterSpec.RKEConfig.ETCDSnapshotSource = nil
 	filteredClusterSpec.RKEConfig.ETCDSnapshotCreate = nil
 	filteredClusterSpec.RKEConfig.RotateEncryptionKeys = nil
 	filteredClusterSpec.RKEConfig.RotateCertificates = nil
@@ -547,6 +548,19 @@ func rkeControlPlane(cluster *rancherv1.Cluster) (*rkev1.RKEControlPlane, error)
 		return nil, err
 	}
 	rkeConfig := cluster.Spec.RKEConfig.DeepCopy()
+	annotations := map[string]string{
+		rke2.ClusterSpecAnnotation: b64GZCluster,
+	}
+	// the planner previews plan changes instead of applying them during a dry run
+	if dryRun := cluster.Annotations[rke2.DryRunAnnotation]; dryRun != "" {
+		annotations[rke2.DryRunAnnotation] = dryRun
+	}
+	// the planner waits for the approval annotations of the worker rollout stages on the control plane
+	for _, stage := range rkeConfig.UpgradeStrategy.WorkerRolloutStages {
+		if approval := cluster.Annotations[stage.ApprovalAnnotation]; stage.ApprovalAnnotation != "" && approval != "" {
+			annotations[stage.ApprovalAnnotation] = approval
+		}
+	}
 	return &rkev1.RKEControlPlane{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cluster.Name,
@@ -554,14 +568,13 @@ func rkeControlPlane(cluster *rancherv1.Cluster) (*rkev1.RKEControlPlane, error)
 			Labels: map[string]string{
 				rke2.InitNodeMachineIDLabel: cluster.Labels[rke2.InitNodeMachineIDLabel],
 			},
-			Annotations: map[string]string{
-				rke2.ClusterSpecAnnotation: b64GZCluster,
-			},
+			Annotations: annotations,
 		},
 		Spec: rkev1.RKEControlPlaneSpec{
 			RKEClusterSpecCommon:     rkeConfig.RKEClusterSpecCommon,
 			LocalClusterAuthEndpoint: *cluster.Spec.LocalClusterAuthEndpoint.DeepCopy(),
 			ETCDSnapshotRestore:      rkeConfig.ETCDSnapshotRestore,
+			ETCDSnapshotSource:       rkeConfig.ETCDSnapshotSource,
 			ETCDSnapshotCreate:       rkeConfig.ETCDSnapshotCreate,
 			RotateCertificates:       rkeConfig.RotateCertificates,
 			RotateEncryptionKeys:     rkeConfig.RotateEncryptionKeys,
@@ -0,0 +1,44 @@
+package provisioningcluster
+
+import (
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// rollbackUpgrade reverts the Kubernetes version and RKE config of a cluster to the spec applied before the upgrade the
+// planner is rolling back, requesting a restore of the pre-upgrade etcd snapshot if one was taken. It returns
+// generic.ErrSkip if the cluster was updated.
+func (h *handler) rollbackUpgrade(cluster *rancherv1.Cluster, cp *rkev1.RKEControlPlane) error {
+	upgrade := cp.Status.Upgrade
+	if upgrade == nil || upgrade.Phase != rkev1.UpgradePhaseRollingBack || cp.Status.AppliedSpec == nil ||
+		cluster.Spec.KubernetesVersion != upgrade.ToVersion {
+		return nil
+	}
+
+	appliedSpec := cp.Status.AppliedSpec
+	cluster = cluster.DeepCopy()
+	cluster.Spec.KubernetesVersion = appliedSpec.KubernetesVersion
+	if !equality.Semantic.DeepEqual(cluster.Spec.RKEConfig.RKEClusterSpecCommon, appliedSpec.RKEClusterSpecCommon) {
+		cluster.Spec.RKEConfig.RKEClusterSpecCommon = *appliedSpec.RKEClusterSpecCommon.DeepCopy()
+	}
+	if upgrade.RestoreSnapshot != "" {
+		generation := 1
+		if cluster.Spec.RKEConfig.ETCDSnapshotRestore != nil {
+			generation = cluster.Spec.RKEConfig.ETCDSnapshotRestore.Generation + 1
+		}
+		cluster.Spec.RKEConfig.ETCDSnapshotRestore = &rkev1.ETCDSnapshotRestore{
+			Name:             upgrade.RestoreSnapshot,
+			Generation:       generation,
+			RestoreRKEConfig: restoreRKEConfigNone,
+		}
+	}
+
+	logrus.Infof("rkecluster %s/%s: rolling back upgrade from %s to %s: %s", cluster.Namespace, cluster.Name, upgrade.FromVersion, upgrade.ToVersion, upgrade.Message)
+	if _, err := h.clusterController.Update(cluster); err != nil {
+		return err
+	}
+	return generic.ErrSkip
+}
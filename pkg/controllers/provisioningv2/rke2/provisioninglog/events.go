@@ -0,0 +1,155 @@
+package provisioninglog
+
+import (
+	"fmt"
+	"sort"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// maxEvents is the number of provisioning events kept per cluster, the oldest events are removed first.
+	maxEvents = 1000
+)
+
+// OnMachine records the reconciliation messages of the machines of clusters provisioned with an RKE config as
+// provisioning events of their cluster.
+func (h *handler) OnMachine(key string, machine *capi.Machine) (*capi.Machine, error) {
+	if machine == nil || machine.Spec.ClusterName == "" {
+		return machine, nil
+	}
+	if !machine.DeletionTimestamp.IsZero() {
+		h.forgetLastMessage(lastMessageKey(machine.Namespace, machine.Spec.ClusterName, machine.Name))
+		return machine, nil
+	}
+	provCluster, err := h.clusterCache.Get(machine.Namespace, machine.Spec.ClusterName)
+	if apierrors.IsNotFound(err) {
+		return machine, nil
+	} else if err != nil {
+		return machine, err
+	}
+	if provCluster.Spec.RKEConfig == nil {
+		return machine, nil
+	}
+
+	msg := rke2.Reconciled.GetMessage(machine)
+	error := rke2.Reconciled.IsFalse(machine)
+	if machine.Status.FailureMessage != nil && *machine.Status.FailureMessage != "" {
+		msg = *machine.Status.FailureMessage
+		error = true
+	}
+	if msg == "" {
+		return machine, nil
+	}
+
+	return machine, h.recordEvent(provCluster, machine.Name, machine.Status.Phase, error, msg)
+}
+
+// recordEvent creates a provisioning event for the cluster, unless the message is the same as the one of the last event
+// recorded for the machine, or for the cluster itself if machineName is empty. The oldest events of the cluster are
+// removed once it has more than maxEvents.
+func (h *handler) recordEvent(provCluster *provv1.Cluster, machineName, phase string, error bool, msg string) error {
+	h.lastMessagesLock.Lock()
+	defer h.lastMessagesLock.Unlock()
+
+	key := lastMessageKey(provCluster.Namespace, provCluster.Name, machineName)
+	lastMessage, known := h.lastMessages[key]
+	if known && lastMessage == msg {
+		return nil
+	}
+
+	events, err := h.provisioningEventsCache.List(provCluster.Namespace, labels.SelectorFromSet(map[string]string{
+		rke2.ClusterNameLabel: provCluster.Name,
+	}))
+	if err != nil {
+		return err
+	}
+	sortEvents(events)
+
+	if !known {
+		// the events recorded before a restart are only known from the cache
+		for i := len(events) - 1; i >= 0; i-- {
+			if events[i].Spec.MachineName == machineName {
+				if events[i].Spec.Message == msg {
+					h.lastMessages[key] = msg
+					return nil
+				}
+				break
+			}
+		}
+	}
+
+	severity := rkev1.ProvisioningEventSeverityInfo
+	if error {
+		severity = rkev1.ProvisioningEventSeverityError
+	}
+	eventLabels := map[string]string{
+		rke2.ClusterNameLabel:   provCluster.Name,
+		rke2.EventSeverityLabel: string(severity),
+	}
+	if machineName != "" {
+		eventLabels[rke2.MachineNameLabel] = machineName
+	}
+
+	_, err = h.provisioningEvents.Create(&rkev1.ProvisioningEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: provCluster.Name + "-",
+			Namespace:    provCluster.Namespace,
+			Labels:       eventLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: provv1.SchemeGroupVersion.String(),
+				Kind:       "Cluster",
+				Name:       provCluster.Name,
+				UID:        provCluster.UID,
+			}},
+		},
+		Spec: rkev1.ProvisioningEventSpec{
+			ClusterName: provCluster.Name,
+			MachineName: machineName,
+			Phase:       phase,
+			Severity:    severity,
+			Message:     msg,
+			Timestamp:   metav1.NowMicro(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("creating provisioning event for cluster %s/%s: %w", provCluster.Namespace, provCluster.Name, err)
+	}
+	h.lastMessages[key] = msg
+
+	// the event just created is not in the cache yet
+	for i := 0; i < len(events)+1-maxEvents; i++ {
+		if err := h.provisioningEvents.Delete(events[i].Namespace, events[i].Name, nil); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// forgetLastMessage drops the last message recorded for a deleted machine.
+func (h *handler) forgetLastMessage(key string) {
+	h.lastMessagesLock.Lock()
+	defer h.lastMessagesLock.Unlock()
+	delete(h.lastMessages, key)
+}
+
+func lastMessageKey(namespace, clusterName, machineName string) string {
+	return namespace + "/" + clusterName + "/" + machineName
+}
+
+// sortEvents sorts events from the oldest to the most recent.
+func sortEvents(events []*rkev1.ProvisioningEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Spec.Timestamp.Equal(&events[j].Spec.Timestamp) {
+			return events[i].Spec.Timestamp.Before(&events[j].Spec.Timestamp)
+		}
+		return events[i].CreationTimestamp.Before(&events[j].CreationTimestamp) ||
+			(events[i].CreationTimestamp.Equal(&events[j].CreationTimestamp) && events[i].Name < events[j].Name)
+	})
+}
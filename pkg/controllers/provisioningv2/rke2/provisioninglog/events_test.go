@@ -0,0 +1,139 @@
+package provisioninglog
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// fakeEventCache returns the events it was populated with, it doesn't see created events like a cache that hasn't
+// caught up yet.
+type fakeEventCache struct {
+	rkecontrollers.ProvisioningEventCache
+	events []*rkev1.ProvisioningEvent
+}
+
+func (f *fakeEventCache) List(namespace string, selector labels.Selector) ([]*rkev1.ProvisioningEvent, error) {
+	var result []*rkev1.ProvisioningEvent
+	for _, event := range f.events {
+		if event.Namespace == namespace && selector.Matches(labels.Set(event.Labels)) {
+			result = append(result, event.DeepCopy())
+		}
+	}
+	return result, nil
+}
+
+type fakeEvents struct {
+	rkecontrollers.ProvisioningEventController
+	created []*rkev1.ProvisioningEvent
+	deleted []string
+}
+
+func (f *fakeEvents) Create(event *rkev1.ProvisioningEvent) (*rkev1.ProvisioningEvent, error) {
+	f.created = append(f.created, event)
+	return event, nil
+}
+
+func (f *fakeEvents) Delete(namespace, name string, _ *metav1.DeleteOptions) error {
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func (f *fakeEvents) messages() []string {
+	var result []string
+	for _, event := range f.created {
+		result = append(result, event.Spec.MachineName+": "+event.Spec.Message)
+	}
+	return result
+}
+
+func newTestHandler(cached []*rkev1.ProvisioningEvent) (*handler, *fakeEvents) {
+	events := &fakeEvents{}
+	return &handler{
+		provisioningEventsCache: &fakeEventCache{events: cached},
+		provisioningEvents:      events,
+		lastMessages:            map[string]string{},
+	}, events
+}
+
+func testEvent(name, machineName, msg string, timestamp time.Time) *rkev1.ProvisioningEvent {
+	return &rkev1.ProvisioningEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "fleet-default",
+			Labels:    map[string]string{rke2.ClusterNameLabel: "c1"},
+		},
+		Spec: rkev1.ProvisioningEventSpec{
+			ClusterName: "c1",
+			MachineName: machineName,
+			Message:     msg,
+			Timestamp:   metav1.NewMicroTime(timestamp),
+		},
+	}
+}
+
+func TestRecordEventDeduplication(t *testing.T) {
+	cluster := &provv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "fleet-default"}}
+	h, events := newTestHandler(nil)
+
+	require.NoError(t, h.recordEvent(cluster, "m1", "Provisioning", false, "creating server"))
+	// the cache doesn't contain the event just created yet
+	require.NoError(t, h.recordEvent(cluster, "m1", "Provisioning", false, "creating server"))
+	require.NoError(t, h.recordEvent(cluster, "m2", "Provisioning", false, "creating server"))
+	require.NoError(t, h.recordEvent(cluster, "", "", false, "waiting for machines"))
+	require.NoError(t, h.recordEvent(cluster, "m1", "Provisioning", false, "waiting for agent"))
+	require.NoError(t, h.recordEvent(cluster, "m1", "Provisioning", false, "creating server"))
+	require.NoError(t, h.recordEvent(cluster, "", "", false, "waiting for machines"))
+
+	assert.Equal(t, []string{
+		"m1: creating server",
+		"m2: creating server",
+		": waiting for machines",
+		"m1: waiting for agent",
+		"m1: creating server",
+	}, events.messages())
+
+	// a deleted machine that comes back with the same name records its messages again
+	h.forgetLastMessage(lastMessageKey("fleet-default", "c1", "m2"))
+	require.NoError(t, h.recordEvent(cluster, "m2", "Provisioning", false, "creating server"))
+	assert.Len(t, events.created, 6)
+}
+
+func TestRecordEventDeduplicationAfterRestart(t *testing.T) {
+	cluster := &provv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "fleet-default"}}
+	now := time.Now()
+	h, events := newTestHandler([]*rkev1.ProvisioningEvent{
+		testEvent("c1-a", "m1", "creating server", now.Add(-2*time.Minute)),
+		testEvent("c1-b", "m1", "waiting for agent", now.Add(-time.Minute)),
+	})
+
+	require.NoError(t, h.recordEvent(cluster, "m1", "Provisioning", false, "waiting for agent"))
+	assert.Empty(t, events.created)
+
+	require.NoError(t, h.recordEvent(cluster, "m1", "Provisioning", false, "creating server"))
+	assert.Equal(t, []string{"m1: creating server"}, events.messages())
+}
+
+func TestRecordEventTrimsOldestEvents(t *testing.T) {
+	cluster := &provv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "fleet-default"}}
+	start := time.Now().Add(-time.Hour)
+	var cached []*rkev1.ProvisioningEvent
+	// cached in reverse order to check the events are sorted by their timestamp
+	for i := maxEvents - 1; i >= 0; i-- {
+		cached = append(cached, testEvent(fmt.Sprintf("c1-%04d", i), "m1", fmt.Sprintf("message %d", i), start.Add(time.Duration(i)*time.Second)))
+	}
+	h, events := newTestHandler(cached)
+
+	require.NoError(t, h.recordEvent(cluster, "m1", "Provisioning", false, "new message"))
+	assert.Len(t, events.created, 1)
+	assert.Equal(t, []string{"c1-0000"}, events.deleted)
+}
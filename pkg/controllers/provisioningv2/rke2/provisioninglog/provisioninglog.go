@@ -5,12 +5,14 @@
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/controllers/dashboard/clusterindex"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
 	provisioningcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/wrangler"
 	corev1controllers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -29,19 +31,30 @@
 
 func Register(ctx context.Context, clients *wrangler.Context) {
 	h := &handler{
-		configMapsCache: clients.Core.ConfigMap().Cache(),
-		configMaps:      clients.Core.ConfigMap(),
-		clusterCache:    clients.Provisioning.Cluster().Cache(),
+		configMapsCache:         clients.Core.ConfigMap().Cache(),
+		configMaps:              clients.Core.ConfigMap(),
+		clusterCache:            clients.Provisioning.Cluster().Cache(),
+		provisioningEventsCache: clients.RKE.ProvisioningEvent().Cache(),
+		provisioningEvents:      clients.RKE.ProvisioningEvent(),
+		lastMessages:            map[string]string{},
 	}
 
 	clients.Core.Namespace().OnChange(ctx, "prov-log-namespace", h.OnNamespace)
 	clients.Core.ConfigMap().OnChange(ctx, "prov-log-configmap", h.OnConfigMap)
+	clients.CAPI.Machine().OnChange(ctx, "prov-log-machine", h.OnMachine)
 }
 
 type handler struct {
-	configMapsCache corev1controllers.ConfigMapCache
-	configMaps      corev1controllers.ConfigMapController
-	clusterCache    provisioningcontrollers.ClusterCache
+	configMapsCache         corev1controllers.ConfigMapCache
+	configMaps              corev1controllers.ConfigMapController
+	clusterCache            provisioningcontrollers.ClusterCache
+	provisioningEventsCache rkecontrollers.ProvisioningEventCache
+	provisioningEvents      rkecontrollers.ProvisioningEventController
+
+	// lastMessages is the message of the last event recorded per cluster and machine. Events just created aren't in
+	// the cache yet, so it is checked before the cache.
+	lastMessagesLock sync.Mutex
+	lastMessages     map[string]string
 }
 
 func (h *handler) OnConfigMap(key string, cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
@@ -116,7 +129,16 @@ func (h *handler) recordMessage(provCluster *provv1.Cluster, cm *corev1.ConfigMa
 
 	cm.Data["log"] = appendLog(error, cm.Data["log"], msg)
 	cm.Data["last"] = msg
-	return h.configMaps.Update(cm)
+	cm, err := h.configMaps.Update(cm)
+	if err != nil {
+		return cm, err
+	}
+
+	phase := string(rke2.Provisioned)
+	if rke2.Provisioned.IsTrue(provCluster) {
+		phase = string(rke2.Updated)
+	}
+	return cm, h.recordEvent(provCluster, "", phase, error, msg)
 }
 
 func (h *handler) OnNamespace(key string, ns *corev1.Namespace) (*corev1.Namespace, error) {
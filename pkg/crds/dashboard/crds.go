@@ -68,6 +68,24 @@ func List(cfg *rest.Config) (_ []crd.CRD, err error) {
 			return c.
 				WithColumn("Value", ".value")
 		}),
+		newCRD(&v3.AuditPolicy{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c
+		}),
+		newCRD(&v3.LoginLockout{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithColumn("Username", ".spec.username").
+				WithColumn("Source IP", ".spec.sourceIP").
+				WithColumn("Locked Until", ".spec.lockedUntil")
+		}),
+		newCRD(&v3.SCIMGroup{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithColumn("Provider", ".spec.provider").
+				WithColumn("Display Name", ".spec.displayName").
+				WithColumn("Principal", ".spec.principalId")
+		}),
 		newCRD(&v3.Preference{}, func(c crd.CRD) crd.CRD {
 			return c.
 				WithColumn("Value", ".value")
@@ -110,6 +110,15 @@ func rke2() []crd.CRD {
 			}
 			return clusterIndexed(c)
 		}),
+		newRKECRD(&rkev1.ProvisioningEvent{}, func(c crd.CRD) crd.CRD {
+			c.Status = false
+			return clusterIndexed(c).
+				WithColumn("Cluster", ".spec.clusterName").
+				WithColumn("Machine", ".spec.machineName").
+				WithColumn("Severity", ".spec.severity").
+				WithColumn("Message", ".spec.message").
+				WithColumn("Timestamp", ".spec.timestamp")
+		}),
 	}
 }
 
@@ -36,6 +36,10 @@ func (c *FakeRkeV1) ETCDSnapshots(namespace string) v1.ETCDSnapshotInterface {
 	return &FakeETCDSnapshots{c, namespace}
 }
 
+func (c *FakeRkeV1) ProvisioningEvents(namespace string) v1.ProvisioningEventInterface {
+	return &FakeProvisioningEvents{c, namespace}
+}
+
 func (c *FakeRkeV1) RKEBootstraps(namespace string) v1.RKEBootstrapInterface {
 	return &FakeRKEBootstraps{c, namespace}
 }
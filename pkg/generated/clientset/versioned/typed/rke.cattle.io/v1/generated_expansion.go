@@ -22,6 +22,8 @@ type CustomMachineExpansion interface{
 
 type ETCDSnapshotExpansion interface{}
 
+type ProvisioningEventExpansion interface{}
+
 type RKEBootstrapExpansion interface{}
 
 type RKEBootstrapTemplateExpansion interface{}
@@ -30,6 +30,7 @@ type RkeV1Interface interface {
 	RESTClient() rest.Interface
 	CustomMachinesGetter
 	ETCDSnapshotsGetter
+	ProvisioningEventsGetter
 	RKEBootstrapsGetter
 	RKEBootstrapTemplatesGetter
 	RKEClustersGetter
@@ -49,6 +50,10 @@ func (c *RkeV1Client) ETCDSnapshots(namespace string) ETCDSnapshotInterface {
 	return newETCDSnapshots(c, namespace)
 }
 
+func (c *RkeV1Client) ProvisioningEvents(namespace string) ProvisioningEventInterface {
+	return newProvisioningEvents(c, namespace)
+}
+
 func (c *RkeV1Client) RKEBootstraps(namespace string) RKEBootstrapInterface {
 	return newRKEBootstraps(c, namespace)
 }
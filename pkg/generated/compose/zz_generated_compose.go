@@ -72,6 +72,7 @@ type Config struct {
 	CisBenchmarkVersions                     map[string]managementClient.CisBenchmarkVersion                     `json:"cisBenchmarkVersions,omitempty" yaml:"cisBenchmarkVersions,omitempty"`
 	FleetWorkspaces                          map[string]managementClient.FleetWorkspace                          `json:"fleetWorkspaces,omitempty" yaml:"fleetWorkspaces,omitempty"`
 	RancherUserNotifications                 map[string]managementClient.RancherUserNotification                 `json:"rancherUserNotifications,omitempty" yaml:"rancherUserNotifications,omitempty"`
+	AuditPolicys                             map[string]managementClient.AuditPolicy                             `json:"auditPolicies,omitempty" yaml:"auditPolicies,omitempty"`
 
 	// Cluster Client
 	Namespaces        map[string]clusterClient.Namespace        `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
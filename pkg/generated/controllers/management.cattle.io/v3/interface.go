@@ -32,6 +32,7 @@ func init() {
 type Interface interface {
 	APIService() APIServiceController
 	ActiveDirectoryProvider() ActiveDirectoryProviderController
+	AuditPolicy() AuditPolicyController
 	AuthConfig() AuthConfigController
 	AuthProvider() AuthProviderController
 	AuthToken() AuthTokenController
@@ -70,6 +71,7 @@ type Interface interface {
 	GroupMember() GroupMemberController
 	KontainerDriver() KontainerDriverController
 	LocalProvider() LocalProviderController
+	LoginLockout() LoginLockoutController
 	ManagedChart() ManagedChartController
 	MonitorMetric() MonitorMetricController
 	MultiClusterApp() MultiClusterAppController
@@ -99,6 +101,7 @@ type Interface interface {
 	RkeK8sServiceOption() RkeK8sServiceOptionController
 	RkeK8sSystemImage() RkeK8sSystemImageController
 	RoleTemplate() RoleTemplateController
+	SCIMGroup() SCIMGroupController
 	SamlProvider() SamlProviderController
 	SamlToken() SamlTokenController
 	Setting() SettingController
@@ -126,6 +129,9 @@ func (c *version) APIService() APIServiceController {
 func (c *version) ActiveDirectoryProvider() ActiveDirectoryProviderController {
 	return NewActiveDirectoryProviderController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ActiveDirectoryProvider"}, "activedirectoryproviders", false, c.controllerFactory)
 }
+func (c *version) AuditPolicy() AuditPolicyController {
+	return NewAuditPolicyController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "AuditPolicy"}, "auditpolicies", false, c.controllerFactory)
+}
 func (c *version) AuthConfig() AuthConfigController {
 	return NewAuthConfigController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "AuthConfig"}, "authconfigs", false, c.controllerFactory)
 }
@@ -240,6 +246,9 @@ func (c *version) KontainerDriver() KontainerDriverController {
 func (c *version) LocalProvider() LocalProviderController {
 	return NewLocalProviderController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "LocalProvider"}, "localproviders", false, c.controllerFactory)
 }
+func (c *version) LoginLockout() LoginLockoutController {
+	return NewLoginLockoutController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "LoginLockout"}, "loginlockouts", false, c.controllerFactory)
+}
 func (c *version) ManagedChart() ManagedChartController {
 	return NewManagedChartController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ManagedChart"}, "managedcharts", true, c.controllerFactory)
 }
@@ -327,6 +336,9 @@ func (c *version) RkeK8sSystemImage() RkeK8sSystemImageController {
 func (c *version) RoleTemplate() RoleTemplateController {
 	return NewRoleTemplateController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "RoleTemplate"}, "roletemplates", false, c.controllerFactory)
 }
+func (c *version) SCIMGroup() SCIMGroupController {
+	return NewSCIMGroupController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "SCIMGroup"}, "scimgroups", false, c.controllerFactory)
+}
 func (c *version) SamlProvider() SamlProviderController {
 	return NewSamlProviderController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "SamlProvider"}, "samlproviders", false, c.controllerFactory)
 }
@@ -32,6 +32,7 @@ func init() {
 type Interface interface {
 	CustomMachine() CustomMachineController
 	ETCDSnapshot() ETCDSnapshotController
+	ProvisioningEvent() ProvisioningEventController
 	RKEBootstrap() RKEBootstrapController
 	RKEBootstrapTemplate() RKEBootstrapTemplateController
 	RKECluster() RKEClusterController
@@ -54,6 +55,9 @@ func (c *version) CustomMachine() CustomMachineController {
 func (c *version) ETCDSnapshot() ETCDSnapshotController {
 	return NewETCDSnapshotController(schema.GroupVersionKind{Group: "rke.cattle.io", Version: "v1", Kind: "ETCDSnapshot"}, "etcdsnapshots", true, c.controllerFactory)
 }
+func (c *version) ProvisioningEvent() ProvisioningEventController {
+	return NewProvisioningEventController(schema.GroupVersionKind{Group: "rke.cattle.io", Version: "v1", Kind: "ProvisioningEvent"}, "provisioningevents", true, c.controllerFactory)
+}
 func (c *version) RKEBootstrap() RKEBootstrapController {
 	return NewRKEBootstrapController(schema.GroupVersionKind{Group: "rke.cattle.io", Version: "v1", Kind: "RKEBootstrap"}, "rkebootstraps", true, c.controllerFactory)
 }
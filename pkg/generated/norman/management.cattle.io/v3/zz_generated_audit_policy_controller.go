@@ -0,0 +1,325 @@
+package v3
+
+import (
+	"context"
+	"time"
+
+	"github.com/rancher/norman/controller"
+	"github.com/rancher/norman/objectclient"
+	"github.com/rancher/norman/resource"
+	"github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	AuditPolicyGroupVersionKind = schema.GroupVersionKind{
+		Version: Version,
+		Group:   GroupName,
+		Kind:    "AuditPolicy",
+	}
+	AuditPolicyResource = metav1.APIResource{
+		Name:         "auditpolicies",
+		SingularName: "auditpolicy",
+		Namespaced:   false,
+		Kind:         AuditPolicyGroupVersionKind.Kind,
+	}
+
+	AuditPolicyGroupVersionResource = schema.GroupVersionResource{
+		Group:    GroupName,
+		Version:  Version,
+		Resource: "auditpolicies",
+	}
+)
+
+func init() {
+	resource.Put(AuditPolicyGroupVersionResource)
+}
+
+// Deprecated: use v3.AuditPolicy instead
+type AuditPolicy = v3.AuditPolicy
+
+func NewAuditPolicy(namespace, name string, obj v3.AuditPolicy) *v3.AuditPolicy {
+	obj.APIVersion, obj.Kind = AuditPolicyGroupVersionKind.ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+type AuditPolicyHandlerFunc func(key string, obj *v3.AuditPolicy) (runtime.Object, error)
+
+type AuditPolicyChangeHandlerFunc func(obj *v3.AuditPolicy) (runtime.Object, error)
+
+type AuditPolicyLister interface {
+	List(namespace string, selector labels.Selector) (ret []*v3.AuditPolicy, err error)
+	Get(namespace, name string) (*v3.AuditPolicy, error)
+}
+
+type AuditPolicyController interface {
+	Generic() controller.GenericController
+	Informer() cache.SharedIndexInformer
+	Lister() AuditPolicyLister
+	AddHandler(ctx context.Context, name string, handler AuditPolicyHandlerFunc)
+	AddFeatureHandler(ctx context.Context, enabled func() bool, name string, sync AuditPolicyHandlerFunc)
+	AddClusterScopedHandler(ctx context.Context, name, clusterName string, handler AuditPolicyHandlerFunc)
+	AddClusterScopedFeatureHandler(ctx context.Context, enabled func() bool, name, clusterName string, handler AuditPolicyHandlerFunc)
+	Enqueue(namespace, name string)
+	EnqueueAfter(namespace, name string, after time.Duration)
+}
+
+type AuditPolicyInterface interface {
+	ObjectClient() *objectclient.ObjectClient
+	Create(*v3.AuditPolicy) (*v3.AuditPolicy, error)
+	GetNamespaced(namespace, name string, opts metav1.GetOptions) (*v3.AuditPolicy, error)
+	Get(name string, opts metav1.GetOptions) (*v3.AuditPolicy, error)
+	Update(*v3.AuditPolicy) (*v3.AuditPolicy, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteNamespaced(namespace, name string, options *metav1.DeleteOptions) error
+	List(opts metav1.ListOptions) (*v3.AuditPolicyList, error)
+	ListNamespaced(namespace string, opts metav1.ListOptions) (*v3.AuditPolicyList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	DeleteCollection(deleteOpts *metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Controller() AuditPolicyController
+	AddHandler(ctx context.Context, name string, sync AuditPolicyHandlerFunc)
+	AddFeatureHandler(ctx context.Context, enabled func() bool, name string, sync AuditPolicyHandlerFunc)
+	AddLifecycle(ctx context.Context, name string, lifecycle AuditPolicyLifecycle)
+	AddFeatureLifecycle(ctx context.Context, enabled func() bool, name string, lifecycle AuditPolicyLifecycle)
+	AddClusterScopedHandler(ctx context.Context, name, clusterName string, sync AuditPolicyHandlerFunc)
+	AddClusterScopedFeatureHandler(ctx context.Context, enabled func() bool, name, clusterName string, sync AuditPolicyHandlerFunc)
+	AddClusterScopedLifecycle(ctx context.Context, name, clusterName string, lifecycle AuditPolicyLifecycle)
+	AddClusterScopedFeatureLifecycle(ctx context.Context, enabled func() bool, name, clusterName string, lifecycle AuditPolicyLifecycle)
+}
+
+type auditPolicyLister struct {
+	ns         string
+	controller *auditPolicyController
+}
+
+func (l *auditPolicyLister) List(namespace string, selector labels.Selector) (ret []*v3.AuditPolicy, err error) {
+	if namespace == "" {
+		namespace = l.ns
+	}
+	err = cache.ListAllByNamespace(l.controller.Informer().GetIndexer(), namespace, selector, func(obj interface{}) {
+		ret = append(ret, obj.(*v3.AuditPolicy))
+	})
+	return
+}
+
+func (l *auditPolicyLister) Get(namespace, name string) (*v3.AuditPolicy, error) {
+	var key string
+	if namespace != "" {
+		key = namespace + "/" + name
+	} else {
+		key = name
+	}
+	obj, exists, err := l.controller.Informer().GetIndexer().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(schema.GroupResource{
+			Group:    AuditPolicyGroupVersionKind.Group,
+			Resource: AuditPolicyGroupVersionResource.Resource,
+		}, key)
+	}
+	return obj.(*v3.AuditPolicy), nil
+}
+
+type auditPolicyController struct {
+	ns string
+	controller.GenericController
+}
+
+func (c *auditPolicyController) Generic() controller.GenericController {
+	return c.GenericController
+}
+
+func (c *auditPolicyController) Lister() AuditPolicyLister {
+	return &auditPolicyLister{
+		ns:         c.ns,
+		controller: c,
+	}
+}
+
+func (c *auditPolicyController) AddHandler(ctx context.Context, name string, handler AuditPolicyHandlerFunc) {
+	c.GenericController.AddHandler(ctx, name, func(key string, obj interface{}) (interface{}, error) {
+		if obj == nil {
+			return handler(key, nil)
+		} else if v, ok := obj.(*v3.AuditPolicy); ok {
+			return handler(key, v)
+		} else {
+			return nil, nil
+		}
+	})
+}
+
+func (c *auditPolicyController) AddFeatureHandler(ctx context.Context, enabled func() bool, name string, handler AuditPolicyHandlerFunc) {
+	c.GenericController.AddHandler(ctx, name, func(key string, obj interface{}) (interface{}, error) {
+		if !enabled() {
+			return nil, nil
+		} else if obj == nil {
+			return handler(key, nil)
+		} else if v, ok := obj.(*v3.AuditPolicy); ok {
+			return handler(key, v)
+		} else {
+			return nil, nil
+		}
+	})
+}
+
+func (c *auditPolicyController) AddClusterScopedHandler(ctx context.Context, name, cluster string, handler AuditPolicyHandlerFunc) {
+	c.GenericController.AddHandler(ctx, name, func(key string, obj interface{}) (interface{}, error) {
+		if obj == nil {
+			return handler(key, nil)
+		} else if v, ok := obj.(*v3.AuditPolicy); ok && controller.ObjectInCluster(cluster, obj) {
+			return handler(key, v)
+		} else {
+			return nil, nil
+		}
+	})
+}
+
+func (c *auditPolicyController) AddClusterScopedFeatureHandler(ctx context.Context, enabled func() bool, name, cluster string, handler AuditPolicyHandlerFunc) {
+	c.GenericController.AddHandler(ctx, name, func(key string, obj interface{}) (interface{}, error) {
+		if !enabled() {
+			return nil, nil
+		} else if obj == nil {
+			return handler(key, nil)
+		} else if v, ok := obj.(*v3.AuditPolicy); ok && controller.ObjectInCluster(cluster, obj) {
+			return handler(key, v)
+		} else {
+			return nil, nil
+		}
+	})
+}
+
+type auditPolicyFactory struct {
+}
+
+func (c auditPolicyFactory) Object() runtime.Object {
+	return &v3.AuditPolicy{}
+}
+
+func (c auditPolicyFactory) List() runtime.Object {
+	return &v3.AuditPolicyList{}
+}
+
+func (s *auditPolicyClient) Controller() AuditPolicyController {
+	genericController := controller.NewGenericController(s.ns, AuditPolicyGroupVersionKind.Kind+"Controller",
+		s.client.controllerFactory.ForResourceKind(AuditPolicyGroupVersionResource, AuditPolicyGroupVersionKind.Kind, false))
+
+	return &auditPolicyController{
+		ns:                s.ns,
+		GenericController: genericController,
+	}
+}
+
+type auditPolicyClient struct {
+	client       *Client
+	ns           string
+	objectClient *objectclient.ObjectClient
+	controller   AuditPolicyController
+}
+
+func (s *auditPolicyClient) ObjectClient() *objectclient.ObjectClient {
+	return s.objectClient
+}
+
+func (s *auditPolicyClient) Create(o *v3.AuditPolicy) (*v3.AuditPolicy, error) {
+	obj, err := s.objectClient.Create(o)
+	return obj.(*v3.AuditPolicy), err
+}
+
+func (s *auditPolicyClient) Get(name string, opts metav1.GetOptions) (*v3.AuditPolicy, error) {
+	obj, err := s.objectClient.Get(name, opts)
+	return obj.(*v3.AuditPolicy), err
+}
+
+func (s *auditPolicyClient) GetNamespaced(namespace, name string, opts metav1.GetOptions) (*v3.AuditPolicy, error) {
+	obj, err := s.objectClient.GetNamespaced(namespace, name, opts)
+	return obj.(*v3.AuditPolicy), err
+}
+
+func (s *auditPolicyClient) Update(o *v3.AuditPolicy) (*v3.AuditPolicy, error) {
+	obj, err := s.objectClient.Update(o.Name, o)
+	return obj.(*v3.AuditPolicy), err
+}
+
+func (s *auditPolicyClient) UpdateStatus(o *v3.AuditPolicy) (*v3.AuditPolicy, error) {
+	obj, err := s.objectClient.UpdateStatus(o.Name, o)
+	return obj.(*v3.AuditPolicy), err
+}
+
+func (s *auditPolicyClient) Delete(name string, options *metav1.DeleteOptions) error {
+	return s.objectClient.Delete(name, options)
+}
+
+func (s *auditPolicyClient) DeleteNamespaced(namespace, name string, options *metav1.DeleteOptions) error {
+	return s.objectClient.DeleteNamespaced(namespace, name, options)
+}
+
+func (s *auditPolicyClient) List(opts metav1.ListOptions) (*v3.AuditPolicyList, error) {
+	obj, err := s.objectClient.List(opts)
+	return obj.(*v3.AuditPolicyList), err
+}
+
+func (s *auditPolicyClient) ListNamespaced(namespace string, opts metav1.ListOptions) (*v3.AuditPolicyList, error) {
+	obj, err := s.objectClient.ListNamespaced(namespace, opts)
+	return obj.(*v3.AuditPolicyList), err
+}
+
+func (s *auditPolicyClient) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	return s.objectClient.Watch(opts)
+}
+
+// Patch applies the patch and returns the patched deployment.
+func (s *auditPolicyClient) Patch(o *v3.AuditPolicy, patchType types.PatchType, data []byte, subresources ...string) (*v3.AuditPolicy, error) {
+	obj, err := s.objectClient.Patch(o.Name, o, patchType, data, subresources...)
+	return obj.(*v3.AuditPolicy), err
+}
+
+func (s *auditPolicyClient) DeleteCollection(deleteOpts *metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return s.objectClient.DeleteCollection(deleteOpts, listOpts)
+}
+
+func (s *auditPolicyClient) AddHandler(ctx context.Context, name string, sync AuditPolicyHandlerFunc) {
+	s.Controller().AddHandler(ctx, name, sync)
+}
+
+func (s *auditPolicyClient) AddFeatureHandler(ctx context.Context, enabled func() bool, name string, sync AuditPolicyHandlerFunc) {
+	s.Controller().AddFeatureHandler(ctx, enabled, name, sync)
+}
+
+func (s *auditPolicyClient) AddLifecycle(ctx context.Context, name string, lifecycle AuditPolicyLifecycle) {
+	sync := NewAuditPolicyLifecycleAdapter(name, false, s, lifecycle)
+	s.Controller().AddHandler(ctx, name, sync)
+}
+
+func (s *auditPolicyClient) AddFeatureLifecycle(ctx context.Context, enabled func() bool, name string, lifecycle AuditPolicyLifecycle) {
+	sync := NewAuditPolicyLifecycleAdapter(name, false, s, lifecycle)
+	s.Controller().AddFeatureHandler(ctx, enabled, name, sync)
+}
+
+func (s *auditPolicyClient) AddClusterScopedHandler(ctx context.Context, name, clusterName string, sync AuditPolicyHandlerFunc) {
+	s.Controller().AddClusterScopedHandler(ctx, name, clusterName, sync)
+}
+
+func (s *auditPolicyClient) AddClusterScopedFeatureHandler(ctx context.Context, enabled func() bool, name, clusterName string, sync AuditPolicyHandlerFunc) {
+	s.Controller().AddClusterScopedFeatureHandler(ctx, enabled, name, clusterName, sync)
+}
+
+func (s *auditPolicyClient) AddClusterScopedLifecycle(ctx context.Context, name, clusterName string, lifecycle AuditPolicyLifecycle) {
+	sync := NewAuditPolicyLifecycleAdapter(name+"_"+clusterName, true, s, lifecycle)
+	s.Controller().AddClusterScopedHandler(ctx, name, clusterName, sync)
+}
+
+func (s *auditPolicyClient) AddClusterScopedFeatureLifecycle(ctx context.Context, enabled func() bool, name, clusterName string, lifecycle AuditPolicyLifecycle) {
+	sync := NewAuditPolicyLifecycleAdapter(name+"_"+clusterName, true, s, lifecycle)
+	s.Controller().AddClusterScopedFeatureHandler(ctx, enabled, name, clusterName, sync)
+}
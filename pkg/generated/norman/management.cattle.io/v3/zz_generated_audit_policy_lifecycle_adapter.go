@@ -0,0 +1,67 @@
+package v3
+
+import (
+	"github.com/rancher/norman/lifecycle"
+	"github.com/rancher/norman/resource"
+	"github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type AuditPolicyLifecycle interface {
+	Create(obj *v3.AuditPolicy) (runtime.Object, error)
+	Remove(obj *v3.AuditPolicy) (runtime.Object, error)
+	Updated(obj *v3.AuditPolicy) (runtime.Object, error)
+}
+
+type auditPolicyLifecycleAdapter struct {
+	lifecycle AuditPolicyLifecycle
+}
+
+func (w *auditPolicyLifecycleAdapter) HasCreate() bool {
+	o, ok := w.lifecycle.(lifecycle.ObjectLifecycleCondition)
+	return !ok || o.HasCreate()
+}
+
+func (w *auditPolicyLifecycleAdapter) HasFinalize() bool {
+	o, ok := w.lifecycle.(lifecycle.ObjectLifecycleCondition)
+	return !ok || o.HasFinalize()
+}
+
+func (w *auditPolicyLifecycleAdapter) Create(obj runtime.Object) (runtime.Object, error) {
+	o, err := w.lifecycle.Create(obj.(*v3.AuditPolicy))
+	if o == nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func (w *auditPolicyLifecycleAdapter) Finalize(obj runtime.Object) (runtime.Object, error) {
+	o, err := w.lifecycle.Remove(obj.(*v3.AuditPolicy))
+	if o == nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func (w *auditPolicyLifecycleAdapter) Updated(obj runtime.Object) (runtime.Object, error) {
+	o, err := w.lifecycle.Updated(obj.(*v3.AuditPolicy))
+	if o == nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func NewAuditPolicyLifecycleAdapter(name string, clusterScoped bool, client AuditPolicyInterface, l AuditPolicyLifecycle) AuditPolicyHandlerFunc {
+	if clusterScoped {
+		resource.PutClusterScoped(AuditPolicyGroupVersionResource)
+	}
+	adapter := &auditPolicyLifecycleAdapter{lifecycle: l}
+	syncFn := lifecycle.NewObjectLifecycleAdapter(name, clusterScoped, adapter, client.ObjectClient())
+	return func(key string, obj *v3.AuditPolicy) (runtime.Object, error) {
+		newObj, err := syncFn(key, obj)
+		if o, ok := newObj.(runtime.Object); ok {
+			return o, err
+		}
+		return nil, err
+	}
+}
@@ -42,6 +42,7 @@ type Interface interface {
 	DynamicSchemasGetter
 	PreferencesGetter
 	UserAttributesGetter
+	LoginLockoutsGetter
 	ProjectNetworkPoliciesGetter
 	ClusterLoggingsGetter
 	ProjectLoggingsGetter
@@ -77,6 +78,7 @@ type Interface interface {
 	CisBenchmarkVersionsGetter
 	FleetWorkspacesGetter
 	RancherUserNotificationsGetter
+	AuditPoliciesGetter
 }
 
 type Client struct {
@@ -547,6 +549,20 @@ func (c *Client) UserAttributes(namespace string) UserAttributeInterface {
 	}
 }
 
+type LoginLockoutsGetter interface {
+	LoginLockouts(namespace string) LoginLockoutInterface
+}
+
+func (c *Client) LoginLockouts(namespace string) LoginLockoutInterface {
+	sharedClient := c.clientFactory.ForResourceKind(LoginLockoutGroupVersionResource, LoginLockoutGroupVersionKind.Kind, false)
+	objectClient := objectclient.NewObjectClient(namespace, sharedClient, &LoginLockoutResource, LoginLockoutGroupVersionKind, loginLockoutFactory{})
+	return &loginLockoutClient{
+		ns:           namespace,
+		client:       c,
+		objectClient: objectClient,
+	}
+}
+
 type ProjectNetworkPoliciesGetter interface {
 	ProjectNetworkPolicies(namespace string) ProjectNetworkPolicyInterface
 }
@@ -1036,3 +1052,17 @@ func (c *Client) RancherUserNotifications(namespace string) RancherUserNotificat
 		objectClient: objectClient,
 	}
 }
+
+type AuditPoliciesGetter interface {
+	AuditPolicies(namespace string) AuditPolicyInterface
+}
+
+func (c *Client) AuditPolicies(namespace string) AuditPolicyInterface {
+	sharedClient := c.clientFactory.ForResourceKind(AuditPolicyGroupVersionResource, AuditPolicyGroupVersionKind.Kind, false)
+	objectClient := objectclient.NewObjectClient(namespace, sharedClient, &AuditPolicyResource, AuditPolicyGroupVersionKind, auditPolicyFactory{})
+	return &auditPolicyClient{
+		ns:           namespace,
+		client:       c,
+		objectClient: objectClient,
+	}
+}
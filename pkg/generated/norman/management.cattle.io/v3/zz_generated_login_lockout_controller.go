@@ -0,0 +1,325 @@
+package v3
+
+import (
+	"context"
+	"time"
+
+	"github.com/rancher/norman/controller"
+	"github.com/rancher/norman/objectclient"
+	"github.com/rancher/norman/resource"
+	"github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	LoginLockoutGroupVersionKind = schema.GroupVersionKind{
+		Version: Version,
+		Group:   GroupName,
+		Kind:    "LoginLockout",
+	}
+	LoginLockoutResource = metav1.APIResource{
+		Name:         "loginlockouts",
+		SingularName: "loginlockout",
+		Namespaced:   false,
+		Kind:         LoginLockoutGroupVersionKind.Kind,
+	}
+
+	LoginLockoutGroupVersionResource = schema.GroupVersionResource{
+		Group:    GroupName,
+		Version:  Version,
+		Resource: "loginlockouts",
+	}
+)
+
+func init() {
+	resource.Put(LoginLockoutGroupVersionResource)
+}
+
+// Deprecated: use v3.LoginLockout instead
+type LoginLockout = v3.LoginLockout
+
+func NewLoginLockout(namespace, name string, obj v3.LoginLockout) *v3.LoginLockout {
+	obj.APIVersion, obj.Kind = LoginLockoutGroupVersionKind.ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+type LoginLockoutHandlerFunc func(key string, obj *v3.LoginLockout) (runtime.Object, error)
+
+type LoginLockoutChangeHandlerFunc func(obj *v3.LoginLockout) (runtime.Object, error)
+
+type LoginLockoutLister interface {
+	List(namespace string, selector labels.Selector) (ret []*v3.LoginLockout, err error)
+	Get(namespace, name string) (*v3.LoginLockout, error)
+}
+
+type LoginLockoutController interface {
+	Generic() controller.GenericController
+	Informer() cache.SharedIndexInformer
+	Lister() LoginLockoutLister
+	AddHandler(ctx context.Context, name string, handler LoginLockoutHandlerFunc)
+	AddFeatureHandler(ctx context.Context, enabled func() bool, name string, sync LoginLockoutHandlerFunc)
+	AddClusterScopedHandler(ctx context.Context, name, clusterName string, handler LoginLockoutHandlerFunc)
+	AddClusterScopedFeatureHandler(ctx context.Context, enabled func() bool, name, clusterName string, handler LoginLockoutHandlerFunc)
+	Enqueue(namespace, name string)
+	EnqueueAfter(namespace, name string, after time.Duration)
+}
+
+type LoginLockoutInterface interface {
+	ObjectClient() *objectclient.ObjectClient
+	Create(*v3.LoginLockout) (*v3.LoginLockout, error)
+	GetNamespaced(namespace, name string, opts metav1.GetOptions) (*v3.LoginLockout, error)
+	Get(name string, opts metav1.GetOptions) (*v3.LoginLockout, error)
+	Update(*v3.LoginLockout) (*v3.LoginLockout, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteNamespaced(namespace, name string, options *metav1.DeleteOptions) error
+	List(opts metav1.ListOptions) (*v3.LoginLockoutList, error)
+	ListNamespaced(namespace string, opts metav1.ListOptions) (*v3.LoginLockoutList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	DeleteCollection(deleteOpts *metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Controller() LoginLockoutController
+	AddHandler(ctx context.Context, name string, sync LoginLockoutHandlerFunc)
+	AddFeatureHandler(ctx context.Context, enabled func() bool, name string, sync LoginLockoutHandlerFunc)
+	AddLifecycle(ctx context.Context, name string, lifecycle LoginLockoutLifecycle)
+	AddFeatureLifecycle(ctx context.Context, enabled func() bool, name string, lifecycle LoginLockoutLifecycle)
+	AddClusterScopedHandler(ctx context.Context, name, clusterName string, sync LoginLockoutHandlerFunc)
+	AddClusterScopedFeatureHandler(ctx context.Context, enabled func() bool, name, clusterName string, sync LoginLockoutHandlerFunc)
+	AddClusterScopedLifecycle(ctx context.Context, name, clusterName string, lifecycle LoginLockoutLifecycle)
+	AddClusterScopedFeatureLifecycle(ctx context.Context, enabled func() bool, name, clusterName string, lifecycle LoginLockoutLifecycle)
+}
+
+type loginLockoutLister struct {
+	ns         string
+	controller *loginLockoutController
+}
+
+func (l *loginLockoutLister) List(namespace string, selector labels.Selector) (ret []*v3.LoginLockout, err error) {
+	if namespace == "" {
+		namespace = l.ns
+	}
+	err = cache.ListAllByNamespace(l.controller.Informer().GetIndexer(), namespace, selector, func(obj interface{}) {
+		ret = append(ret, obj.(*v3.LoginLockout))
+	})
+	return
+}
+
+func (l *loginLockoutLister) Get(namespace, name string) (*v3.LoginLockout, error) {
+	var key string
+	if namespace != "" {
+		key = namespace + "/" + name
+	} else {
+		key = name
+	}
+	obj, exists, err := l.controller.Informer().GetIndexer().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(schema.GroupResource{
+			Group:    LoginLockoutGroupVersionKind.Group,
+			Resource: LoginLockoutGroupVersionResource.Resource,
+		}, key)
+	}
+	return obj.(*v3.LoginLockout), nil
+}
+
+type loginLockoutController struct {
+	ns string
+	controller.GenericController
+}
+
+func (c *loginLockoutController) Generic() controller.GenericController {
+	return c.GenericController
+}
+
+func (c *loginLockoutController) Lister() LoginLockoutLister {
+	return &loginLockoutLister{
+		ns:         c.ns,
+		controller: c,
+	}
+}
+
+func (c *loginLockoutController) AddHandler(ctx context.Context, name string, handler LoginLockoutHandlerFunc) {
+	c.GenericController.AddHandler(ctx, name, func(key string, obj interface{}) (interface{}, error) {
+		if obj == nil {
+			return handler(key, nil)
+		} else if v, ok := obj.(*v3.LoginLockout); ok {
+			return handler(key, v)
+		} else {
+			return nil, nil
+		}
+	})
+}
+
+func (c *loginLockoutController) AddFeatureHandler(ctx context.Context, enabled func() bool, name string, handler LoginLockoutHandlerFunc) {
+	c.GenericController.AddHandler(ctx, name, func(key string, obj interface{}) (interface{}, error) {
+		if !enabled() {
+			return nil, nil
+		} else if obj == nil {
+			return handler(key, nil)
+		} else if v, ok := obj.(*v3.LoginLockout); ok {
+			return handler(key, v)
+		} else {
+			return nil, nil
+		}
+	})
+}
+
+func (c *loginLockoutController) AddClusterScopedHandler(ctx context.Context, name, cluster string, handler LoginLockoutHandlerFunc) {
+	c.GenericController.AddHandler(ctx, name, func(key string, obj interface{}) (interface{}, error) {
+		if obj == nil {
+			return handler(key, nil)
+		} else if v, ok := obj.(*v3.LoginLockout); ok && controller.ObjectInCluster(cluster, obj) {
+			return handler(key, v)
+		} else {
+			return nil, nil
+		}
+	})
+}
+
+func (c *loginLockoutController) AddClusterScopedFeatureHandler(ctx context.Context, enabled func() bool, name, cluster string, handler LoginLockoutHandlerFunc) {
+	c.GenericController.AddHandler(ctx, name, func(key string, obj interface{}) (interface{}, error) {
+		if !enabled() {
+			return nil, nil
+		} else if obj == nil {
+			return handler(key, nil)
+		} else if v, ok := obj.(*v3.LoginLockout); ok && controller.ObjectInCluster(cluster, obj) {
+			return handler(key, v)
+		} else {
+			return nil, nil
+		}
+	})
+}
+
+type loginLockoutFactory struct {
+}
+
+func (c loginLockoutFactory) Object() runtime.Object {
+	return &v3.LoginLockout{}
+}
+
+func (c loginLockoutFactory) List() runtime.Object {
+	return &v3.LoginLockoutList{}
+}
+
+func (s *loginLockoutClient) Controller() LoginLockoutController {
+	genericController := controller.NewGenericController(s.ns, LoginLockoutGroupVersionKind.Kind+"Controller",
+		s.client.controllerFactory.ForResourceKind(LoginLockoutGroupVersionResource, LoginLockoutGroupVersionKind.Kind, false))
+
+	return &loginLockoutController{
+		ns:                s.ns,
+		GenericController: genericController,
+	}
+}
+
+type loginLockoutClient struct {
+	client       *Client
+	ns           string
+	objectClient *objectclient.ObjectClient
+	controller   LoginLockoutController
+}
+
+func (s *loginLockoutClient) ObjectClient() *objectclient.ObjectClient {
+	return s.objectClient
+}
+
+func (s *loginLockoutClient) Create(o *v3.LoginLockout) (*v3.LoginLockout, error) {
+	obj, err := s.objectClient.Create(o)
+	return obj.(*v3.LoginLockout), err
+}
+
+func (s *loginLockoutClient) Get(name string, opts metav1.GetOptions) (*v3.LoginLockout, error) {
+	obj, err := s.objectClient.Get(name, opts)
+	return obj.(*v3.LoginLockout), err
+}
+
+func (s *loginLockoutClient) GetNamespaced(namespace, name string, opts metav1.GetOptions) (*v3.LoginLockout, error) {
+	obj, err := s.objectClient.GetNamespaced(namespace, name, opts)
+	return obj.(*v3.LoginLockout), err
+}
+
+func (s *loginLockoutClient) Update(o *v3.LoginLockout) (*v3.LoginLockout, error) {
+	obj, err := s.objectClient.Update(o.Name, o)
+	return obj.(*v3.LoginLockout), err
+}
+
+func (s *loginLockoutClient) UpdateStatus(o *v3.LoginLockout) (*v3.LoginLockout, error) {
+	obj, err := s.objectClient.UpdateStatus(o.Name, o)
+	return obj.(*v3.LoginLockout), err
+}
+
+func (s *loginLockoutClient) Delete(name string, options *metav1.DeleteOptions) error {
+	return s.objectClient.Delete(name, options)
+}
+
+func (s *loginLockoutClient) DeleteNamespaced(namespace, name string, options *metav1.DeleteOptions) error {
+	return s.objectClient.DeleteNamespaced(namespace, name, options)
+}
+
+func (s *loginLockoutClient) List(opts metav1.ListOptions) (*v3.LoginLockoutList, error) {
+	obj, err := s.objectClient.List(opts)
+	return obj.(*v3.LoginLockoutList), err
+}
+
+func (s *loginLockoutClient) ListNamespaced(namespace string, opts metav1.ListOptions) (*v3.LoginLockoutList, error) {
+	obj, err := s.objectClient.ListNamespaced(namespace, opts)
+	return obj.(*v3.LoginLockoutList), err
+}
+
+func (s *loginLockoutClient) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	return s.objectClient.Watch(opts)
+}
+
+// Patch applies the patch and returns the patched deployment.
+func (s *loginLockoutClient) Patch(o *v3.LoginLockout, patchType types.PatchType, data []byte, subresources ...string) (*v3.LoginLockout, error) {
+	obj, err := s.objectClient.Patch(o.Name, o, patchType, data, subresources...)
+	return obj.(*v3.LoginLockout), err
+}
+
+func (s *loginLockoutClient) DeleteCollection(deleteOpts *metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return s.objectClient.DeleteCollection(deleteOpts, listOpts)
+}
+
+func (s *loginLockoutClient) AddHandler(ctx context.Context, name string, sync LoginLockoutHandlerFunc) {
+	s.Controller().AddHandler(ctx, name, sync)
+}
+
+func (s *loginLockoutClient) AddFeatureHandler(ctx context.Context, enabled func() bool, name string, sync LoginLockoutHandlerFunc) {
+	s.Controller().AddFeatureHandler(ctx, enabled, name, sync)
+}
+
+func (s *loginLockoutClient) AddLifecycle(ctx context.Context, name string, lifecycle LoginLockoutLifecycle) {
+	sync := NewLoginLockoutLifecycleAdapter(name, false, s, lifecycle)
+	s.Controller().AddHandler(ctx, name, sync)
+}
+
+func (s *loginLockoutClient) AddFeatureLifecycle(ctx context.Context, enabled func() bool, name string, lifecycle LoginLockoutLifecycle) {
+	sync := NewLoginLockoutLifecycleAdapter(name, false, s, lifecycle)
+	s.Controller().AddFeatureHandler(ctx, enabled, name, sync)
+}
+
+func (s *loginLockoutClient) AddClusterScopedHandler(ctx context.Context, name, clusterName string, sync LoginLockoutHandlerFunc) {
+	s.Controller().AddClusterScopedHandler(ctx, name, clusterName, sync)
+}
+
+func (s *loginLockoutClient) AddClusterScopedFeatureHandler(ctx context.Context, enabled func() bool, name, clusterName string, sync LoginLockoutHandlerFunc) {
+	s.Controller().AddClusterScopedFeatureHandler(ctx, enabled, name, clusterName, sync)
+}
+
+func (s *loginLockoutClient) AddClusterScopedLifecycle(ctx context.Context, name, clusterName string, lifecycle LoginLockoutLifecycle) {
+	sync := NewLoginLockoutLifecycleAdapter(name+"_"+clusterName, true, s, lifecycle)
+	s.Controller().AddClusterScopedHandler(ctx, name, clusterName, sync)
+}
+
+func (s *loginLockoutClient) AddClusterScopedFeatureLifecycle(ctx context.Context, enabled func() bool, name, clusterName string, lifecycle LoginLockoutLifecycle) {
+	sync := NewLoginLockoutLifecycleAdapter(name+"_"+clusterName, true, s, lifecycle)
+	s.Controller().AddClusterScopedFeatureHandler(ctx, enabled, name, clusterName, sync)
+}
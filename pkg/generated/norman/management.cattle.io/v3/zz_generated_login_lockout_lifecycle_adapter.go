@@ -0,0 +1,67 @@
+package v3
+
+import (
+	"github.com/rancher/norman/lifecycle"
+	"github.com/rancher/norman/resource"
+	"github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type LoginLockoutLifecycle interface {
+	Create(obj *v3.LoginLockout) (runtime.Object, error)
+	Remove(obj *v3.LoginLockout) (runtime.Object, error)
+	Updated(obj *v3.LoginLockout) (runtime.Object, error)
+}
+
+type loginLockoutLifecycleAdapter struct {
+	lifecycle LoginLockoutLifecycle
+}
+
+func (w *loginLockoutLifecycleAdapter) HasCreate() bool {
+	o, ok := w.lifecycle.(lifecycle.ObjectLifecycleCondition)
+	return !ok || o.HasCreate()
+}
+
+func (w *loginLockoutLifecycleAdapter) HasFinalize() bool {
+	o, ok := w.lifecycle.(lifecycle.ObjectLifecycleCondition)
+	return !ok || o.HasFinalize()
+}
+
+func (w *loginLockoutLifecycleAdapter) Create(obj runtime.Object) (runtime.Object, error) {
+	o, err := w.lifecycle.Create(obj.(*v3.LoginLockout))
+	if o == nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func (w *loginLockoutLifecycleAdapter) Finalize(obj runtime.Object) (runtime.Object, error) {
+	o, err := w.lifecycle.Remove(obj.(*v3.LoginLockout))
+	if o == nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func (w *loginLockoutLifecycleAdapter) Updated(obj runtime.Object) (runtime.Object, error) {
+	o, err := w.lifecycle.Updated(obj.(*v3.LoginLockout))
+	if o == nil {
+		return nil, err
+	}
+	return o, err
+}
+
+func NewLoginLockoutLifecycleAdapter(name string, clusterScoped bool, client LoginLockoutInterface, l LoginLockoutLifecycle) LoginLockoutHandlerFunc {
+	if clusterScoped {
+		resource.PutClusterScoped(LoginLockoutGroupVersionResource)
+	}
+	adapter := &loginLockoutLifecycleAdapter{lifecycle: l}
+	syncFn := lifecycle.NewObjectLifecycleAdapter(name, clusterScoped, adapter, client.ObjectClient())
+	return func(key string, obj *v3.LoginLockout) (runtime.Object, error) {
+		newObj, err := syncFn(key, obj)
+		if o, ok := newObj.(runtime.Object); ok {
+			return o, err
+		}
+		return nil, err
+	}
+}
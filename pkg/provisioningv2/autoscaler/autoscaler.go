@@ -0,0 +1,223 @@
+package autoscaler
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/rancher/dynamiclistener/cert"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	provisioningcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/provisioningv2/autoscaler/externalgrpc"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/wrangler"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	cloudConfigKey = "cloud-config"
+
+	// cloudConfigTemplate is the cloud config of the externalgrpc cloud provider, expecting the client secret to be
+	// mounted at /etc/cluster-autoscaler
+	cloudConfigTemplate = `address: %q
+cert: "/etc/cluster-autoscaler/tls.crt"
+key: "/etc/cluster-autoscaler/tls.key"
+cacert: "/etc/cluster-autoscaler/ca.crt"
+`
+)
+
+type handler struct {
+	ca           *caLoader
+	secrets      corecontrollers.SecretClient
+	secretCache  corecontrollers.SecretCache
+	clusters     provisioningcontrollers.ClusterController
+	clusterCache provisioningcontrollers.ClusterCache
+}
+
+// Register maintains the client secret of each cluster with autoscaled machine pools, to be used by the
+// cluster-autoscaler of the cluster. It runs on the leader, the cloud provider itself is served by every replica, see
+// Serve.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		ca:           &caLoader{secrets: clients.Core.Secret()},
+		secrets:      clients.Core.Secret(),
+		secretCache:  clients.Core.Secret().Cache(),
+		clusters:     clients.Provisioning.Cluster(),
+		clusterCache: clients.Provisioning.Cluster().Cache(),
+	}
+	clients.Provisioning.Cluster().OnChange(ctx, "autoscaler-client-secret", h.OnChange)
+	clients.Mgmt.Setting().OnChange(ctx, "autoscaler-settings", h.OnSetting)
+}
+
+// OnSetting enqueues all clusters when the settings of the address of the cloud provider change, so that the client
+// secrets are created or their cloud config is updated.
+func (h *handler) OnSetting(_ string, setting *v3.Setting) (*v3.Setting, error) {
+	if setting == nil || (setting.Name != settings.ClusterAutoscalerGRPCPort.Name && setting.Name != settings.ClusterAutoscalerGRPCAddress.Name) {
+		return setting, nil
+	}
+	clusters, err := h.clusterCache.List("", labels.Everything())
+	if err != nil {
+		return setting, err
+	}
+	for _, cluster := range clusters {
+		h.clusters.Enqueue(cluster.Namespace, cluster.Name)
+	}
+	return setting, nil
+}
+
+// Serve serves the externalgrpc cloud provider of the cluster-autoscaler on the cluster-autoscaler-grpc-port. It runs
+// on every replica, independent of leader election, since the autoscalers of downstream clusters may connect to any
+// replica behind the address of the cloud provider. The port isn't routed by the ingress of Rancher, it has to be
+// exposed separately, e.g. by a LoadBalancer service with TLS passthrough, as the client certificates of the
+// autoscalers are verified by Rancher.
+func Serve(ctx context.Context, clients *wrangler.Context) {
+	s := &server{
+		clusterCache: clients.Provisioning.Cluster().Cache(),
+		clusters:     clients.Provisioning.Cluster(),
+		machineCache: clients.CAPI.Machine().Cache(),
+		machines:     clients.CAPI.Machine(),
+	}
+	ca := &caLoader{secrets: clients.Core.Secret()}
+	go func() {
+		// the setting is read once the caches, including the one of the settings, are synced
+		clients.SharedControllerFactory.SharedCacheFactory().WaitForCacheSync(ctx)
+		port := settings.ClusterAutoscalerGRPCPort.Get()
+		if port == "" {
+			return
+		}
+		if err := serve(ctx, port, s, ca); err != nil {
+			logrus.Errorf("[autoscaler] failed to serve the externalgrpc cloud provider: %v", err)
+		}
+	}()
+}
+
+func serve(ctx context.Context, port string, s externalgrpc.CloudProviderServer, ca *caLoader) error {
+	caCert, caKey, err := ca.Load()
+	if err != nil {
+		return err
+	}
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(caCert)
+	certs := &servingCerts{
+		caCert: caCert,
+		caKey:  caKey,
+		certs:  map[string]*tls.Certificate{},
+	}
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		GetCertificate: certs.GetCertificate,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+		ClientCAs:      clientCAs,
+		MinVersion:     tls.VersionTLS12,
+	})))
+	externalgrpc.RegisterCloudProviderServer(grpcServer, s)
+
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		grpcServer.Stop()
+	}()
+
+	logrus.Infof("[autoscaler] serving the externalgrpc cloud provider on port %s", port)
+	return grpcServer.Serve(listener)
+}
+
+// ClientSecretName returns the name of the secret holding the client certificate and cloud config of the
+// cluster-autoscaler of a cluster.
+func ClientSecretName(clusterName string) string {
+	return name.SafeConcatName(clusterName, "cluster-autoscaler")
+}
+
+// OnChange creates the client secret of clusters with autoscaled machine pools and renews its certificate before
+// it expires.
+func (h *handler) OnChange(_ string, cluster *rancherv1.Cluster) (*rancherv1.Cluster, error) {
+	if cluster == nil || !cluster.DeletionTimestamp.IsZero() || cluster.Spec.RKEConfig == nil || !autoscaled(cluster) {
+		return cluster, nil
+	}
+	// the cloud provider isn't served, OnSetting enqueues the clusters once the port is set
+	if settings.ClusterAutoscalerGRPCPort.Get() == "" {
+		return cluster, nil
+	}
+
+	address, err := serverAddress(settings.ClusterAutoscalerGRPCAddress.Get(), settings.ServerURL.Get(), settings.ClusterAutoscalerGRPCPort.Get())
+	if err != nil {
+		// the server-url is not set yet
+		return cluster, nil
+	}
+	cloudConfig := []byte(fmt.Sprintf(cloudConfigTemplate, address))
+
+	existing, err := h.secretCache.Get(cluster.Namespace, ClientSecretName(cluster.Name))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return cluster, err
+	}
+
+	caCert, caKey, err := h.ca.Load()
+	if err != nil {
+		return cluster, err
+	}
+	caPEM := cert.EncodeCertPEM(caCert)
+
+	if existing != nil && !clientCertNeedsRenewal(existing.Data[corev1.TLSCertKey], caCert, time.Now()) &&
+		bytes.Equal(existing.Data[cloudConfigKey], cloudConfig) && bytes.Equal(existing.Data["ca.crt"], caPEM) {
+		return cluster, nil
+	}
+
+	certPEM, keyPEM, err := newClientCert(caCert, caKey, cluster.Namespace, cluster.Name)
+	if err != nil {
+		return cluster, err
+	}
+	data := map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+		"ca.crt":                caPEM,
+		cloudConfigKey:          cloudConfig,
+	}
+
+	if existing != nil {
+		secret := existing.DeepCopy()
+		secret.Data = data
+		_, err = h.secrets.Update(secret)
+		return cluster, err
+	}
+
+	_, err = h.secrets.Create(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ClientSecretName(cluster.Name),
+			Namespace: cluster.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: rancherv1.SchemeGroupVersion.String(),
+				Kind:       "Cluster",
+				Name:       cluster.Name,
+				UID:        cluster.UID,
+			}},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: data,
+	})
+	return cluster, err
+}
+
+func autoscaled(cluster *rancherv1.Cluster) bool {
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if _, ok := nodeGroupForPool(cluster, pool); ok {
+			return true
+		}
+	}
+	return false
+}
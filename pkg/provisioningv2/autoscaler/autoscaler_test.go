@@ -0,0 +1,70 @@
+package autoscaler
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	provisioningcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type fakeClusterCache struct {
+	provisioningcontrollers.ClusterCache
+	clusters []*rancherv1.Cluster
+}
+
+func (f *fakeClusterCache) List(namespace string, selector labels.Selector) ([]*rancherv1.Cluster, error) {
+	return f.clusters, nil
+}
+
+type fakeClusters struct {
+	provisioningcontrollers.ClusterController
+	enqueued []string
+}
+
+func (f *fakeClusters) Enqueue(namespace, name string) {
+	f.enqueued = append(f.enqueued, namespace+"/"+name)
+}
+
+func TestOnSettingEnqueuesClusters(t *testing.T) {
+	clusters := &fakeClusters{}
+	h := &handler{
+		clusters: clusters,
+		clusterCache: &fakeClusterCache{clusters: []*rancherv1.Cluster{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "c1"}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "c2"}},
+		}},
+	}
+
+	_, err := h.OnSetting("", &v3.Setting{ObjectMeta: metav1.ObjectMeta{Name: settings.ServerURL.Name}})
+	require.NoError(t, err)
+	assert.Empty(t, clusters.enqueued)
+
+	_, err = h.OnSetting("", &v3.Setting{ObjectMeta: metav1.ObjectMeta{Name: settings.ClusterAutoscalerGRPCPort.Name}, Value: "9443"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fleet-default/c1", "fleet-default/c2"}, clusters.enqueued)
+}
+
+func TestOnChangeWithoutPort(t *testing.T) {
+	previous := settings.ClusterAutoscalerGRPCPort.Get()
+	require.NoError(t, settings.ClusterAutoscalerGRPCPort.Set(""))
+	defer settings.ClusterAutoscalerGRPCPort.Set(previous)
+
+	cluster := &rancherv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "c1"},
+		Spec: rancherv1.ClusterSpec{RKEConfig: &rancherv1.RKEConfig{MachinePools: []rancherv1.RKEMachinePool{{
+			Name:                         "workers",
+			WorkerRole:                   true,
+			MachineDeploymentAnnotations: map[string]string{MinSizeAnnotation: "1", MaxSizeAnnotation: "5"},
+		}}}},
+	}
+	// the handler has no clients, the client secret isn't touched while the cloud provider isn't served
+	result, err := (&handler{}).OnChange("", cluster)
+	require.NoError(t, err)
+	assert.Equal(t, cluster, result)
+}
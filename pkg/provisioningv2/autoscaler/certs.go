@@ -0,0 +1,175 @@
+package autoscaler
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/rancher/dynamiclistener/factory"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/settings"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	caSecretName = "cluster-autoscaler-ca"
+
+	// clientCertRenewBefore is how long before its expiration the client certificate of a cluster is renewed
+	clientCertRenewBefore = 30 * 24 * time.Hour
+)
+
+// caLoader loads the CA once it exists.
+type caLoader struct {
+	lock    sync.Mutex
+	secrets corecontrollers.SecretClient
+	caCert  *x509.Certificate
+	caKey   crypto.Signer
+}
+
+func (c *caLoader) Load() (*x509.Certificate, crypto.Signer, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.caCert != nil {
+		return c.caCert, c.caKey, nil
+	}
+	caCert, caKey, err := loadOrCreateCA(c.secrets)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.caCert, c.caKey = caCert, caKey
+	return caCert, caKey, nil
+}
+
+// loadOrCreateCA returns the CA signing the serving certificate of the server and the client certificates of the
+// clusters, creating it if it doesn't exist.
+func loadOrCreateCA(secrets corecontrollers.SecretClient) (*x509.Certificate, crypto.Signer, error) {
+	secret, err := secrets.Get(namespace.System, caSecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		caCert, caKey, err := factory.GenCA()
+		if err != nil {
+			return nil, nil, err
+		}
+		certPEM, keyPEM, err := factory.Marshal(caCert, caKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		secret, err = secrets.Create(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      caSecretName,
+				Namespace: namespace.System,
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+			},
+		})
+		if apierrors.IsAlreadyExists(err) {
+			secret, err = secrets.Get(namespace.System, caSecretName, metav1.GetOptions{})
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return factory.LoadCA(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+}
+
+// newClientCert returns a client certificate identifying the cluster and its private key, PEM encoded.
+func newClientCert(caCert *x509.Certificate, caKey crypto.Signer, clusterNamespace, clusterName string) ([]byte, []byte, error) {
+	key, err := factory.NewPrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := factory.NewSignedClientCert(key, caCert, caKey, clusterNamespace+"/"+clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return factory.Marshal(cert, key)
+}
+
+// clientCertNeedsRenewal returns true if the PEM encoded client certificate can't be parsed, wasn't signed by the CA
+// or expires soon.
+func clientCertNeedsRenewal(certPEM []byte, caCert *x509.Certificate, now time.Time) bool {
+	cert, err := factory.ParseCertPEM(certPEM)
+	if err != nil {
+		return true
+	}
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		return true
+	}
+	return now.Add(clientCertRenewBefore).After(cert.NotAfter)
+}
+
+// servingCerts signs the serving certificate of the server for the hostname of the server-url, which is the address
+// in the cloud config of the clusters.
+type servingCerts struct {
+	lock   sync.Mutex
+	caCert *x509.Certificate
+	caKey  crypto.Signer
+	certs  map[string]*tls.Certificate
+}
+
+func (s *servingCerts) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	serverName := "localhost"
+	if u, err := url.Parse(settings.ServerURL.Get()); err == nil && u.Hostname() != "" {
+		serverName = u.Hostname()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if cert, ok := s.certs[serverName]; ok && time.Now().Add(clientCertRenewBefore).Before(cert.Leaf.NotAfter) {
+		return cert, nil
+	}
+
+	var (
+		domains []string
+		ips     []net.IP
+	)
+	if ip := net.ParseIP(serverName); ip != nil {
+		ips = append(ips, ip)
+	} else {
+		domains = append(domains, serverName)
+	}
+
+	key, err := factory.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	cert, err := factory.NewSignedCert(key, s.caCert, s.caKey, "cluster-autoscaler", nil, domains, ips)
+	if err != nil {
+		return nil, err
+	}
+	tlsCert := &tls.Certificate{
+		Certificate: [][]byte{cert.Raw, s.caCert.Raw},
+		PrivateKey:  key,
+		Leaf:        cert,
+	}
+	s.certs[serverName] = tlsCert
+	return tlsCert, nil
+}
+
+// serverAddress returns the address of the server, which is the configured address if set, or the host of the given
+// server-url with the given port.
+func serverAddress(address, serverURL, port string) (string, error) {
+	if address != "" {
+		return address, nil
+	}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("invalid server-url %q", serverURL)
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
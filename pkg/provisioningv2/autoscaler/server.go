@@ -0,0 +1,396 @@
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/provisioningv2/autoscaler/externalgrpc"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// MinSizeAnnotation and MaxSizeAnnotation are set in the machineDeploymentAnnotations of a machine pool to
+	// autoscale it between the given number of machines. Only worker pools can be autoscaled.
+	MinSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-min-size"
+	MaxSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size"
+
+	// providerIDPrefix prefixes the instance ids of machines without a provider id.
+	providerIDPrefix = "rancher://"
+)
+
+// server maps the node groups of the cluster-autoscaler of a downstream cluster to the autoscaled machine pools of the
+// cluster. The cluster is identified by the common name, namespace/name, of the client certificate of the autoscaler.
+// Scaling a node group updates the quantity of the machine pool, which is applied to the replicas of its
+// MachineDeployment.
+type server struct {
+	externalgrpc.UnimplementedCloudProviderServer
+
+	clusterCache rocontrollers.ClusterCache
+	clusters     rocontrollers.ClusterClient
+	machineCache capicontrollers.MachineCache
+	machines     capicontrollers.MachineClient
+}
+
+func (s *server) NodeGroups(ctx context.Context, _ *externalgrpc.NodeGroupsRequest) (*externalgrpc.NodeGroupsResponse, error) {
+	cluster, err := s.cluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &externalgrpc.NodeGroupsResponse{}
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if group, ok := nodeGroupForPool(cluster, pool); ok {
+			resp.NodeGroups = append(resp.NodeGroups, group)
+		}
+	}
+	return resp, nil
+}
+
+func (s *server) NodeGroupForNode(ctx context.Context, req *externalgrpc.NodeGroupForNodeRequest) (*externalgrpc.NodeGroupForNodeResponse, error) {
+	cluster, err := s.cluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Node == nil {
+		return nil, status.Error(codes.InvalidArgument, "node is required")
+	}
+
+	machines, err := s.clusterMachines(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &externalgrpc.NodeGroupForNodeResponse{NodeGroup: &externalgrpc.NodeGroup{}}
+	for _, machine := range machines {
+		if !machineIsNode(machine, req.Node) {
+			continue
+		}
+		for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+			if pool.Name != machine.Labels[rke2.RKEMachinePoolNameLabel] {
+				continue
+			}
+			if group, ok := nodeGroupForPool(cluster, pool); ok {
+				resp.NodeGroup = group
+			}
+		}
+		break
+	}
+	return resp, nil
+}
+
+func (s *server) GPULabel(context.Context, *externalgrpc.GPULabelRequest) (*externalgrpc.GPULabelResponse, error) {
+	return &externalgrpc.GPULabelResponse{}, nil
+}
+
+func (s *server) GetAvailableGPUTypes(context.Context, *externalgrpc.GetAvailableGPUTypesRequest) (*externalgrpc.GetAvailableGPUTypesResponse, error) {
+	return &externalgrpc.GetAvailableGPUTypesResponse{}, nil
+}
+
+func (s *server) Cleanup(context.Context, *externalgrpc.CleanupRequest) (*externalgrpc.CleanupResponse, error) {
+	return &externalgrpc.CleanupResponse{}, nil
+}
+
+func (s *server) Refresh(context.Context, *externalgrpc.RefreshRequest) (*externalgrpc.RefreshResponse, error) {
+	return &externalgrpc.RefreshResponse{}, nil
+}
+
+func (s *server) NodeGroupTargetSize(ctx context.Context, req *externalgrpc.NodeGroupTargetSizeRequest) (*externalgrpc.NodeGroupTargetSizeResponse, error) {
+	cluster, err := s.cluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pool, _, err := autoscaledPool(cluster, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &externalgrpc.NodeGroupTargetSizeResponse{TargetSize: poolQuantity(pool)}, nil
+}
+
+func (s *server) NodeGroupIncreaseSize(ctx context.Context, req *externalgrpc.NodeGroupIncreaseSizeRequest) (*externalgrpc.NodeGroupIncreaseSizeResponse, error) {
+	if req.Delta <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "size increase must be positive")
+	}
+	err := s.scale(ctx, req.Id, func(group *externalgrpc.NodeGroup, quantity int32) (int32, error) {
+		size := quantity + req.Delta
+		if size > group.MaxSize {
+			return 0, status.Errorf(codes.InvalidArgument, "size increase too large, desired %d max %d", size, group.MaxSize)
+		}
+		return size, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &externalgrpc.NodeGroupIncreaseSizeResponse{}, nil
+}
+
+func (s *server) NodeGroupDeleteNodes(ctx context.Context, req *externalgrpc.NodeGroupDeleteNodesRequest) (*externalgrpc.NodeGroupDeleteNodesResponse, error) {
+	cluster, err := s.cluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pool, group, err := autoscaledPool(cluster, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if poolQuantity(pool)-int32(len(req.Nodes)) < group.MinSize {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to delete %d machines in node group %s, minimum size is %d", len(req.Nodes), group.Id, group.MinSize)
+	}
+
+	machines, err := s.poolMachines(cluster, pool.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// mark the machines for deletion first so the MachineSet deletes them when the replicas are decreased
+	for _, node := range req.Nodes {
+		var machine *capi.Machine
+		for _, m := range machines {
+			if machineIsNode(m, node) {
+				machine = m
+				break
+			}
+		}
+		if machine == nil {
+			return nil, status.Errorf(codes.NotFound, "node %s does not belong to node group %s", node.Name, group.Id)
+		}
+		if _, ok := machine.Annotations[capi.DeleteMachineAnnotation]; ok {
+			continue
+		}
+		machine = machine.DeepCopy()
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		machine.Annotations[capi.DeleteMachineAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		if _, err := s.machines.Update(machine); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.scale(ctx, req.Id, func(group *externalgrpc.NodeGroup, quantity int32) (int32, error) {
+		return quantity - int32(len(req.Nodes)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &externalgrpc.NodeGroupDeleteNodesResponse{}, nil
+}
+
+func (s *server) NodeGroupDecreaseTargetSize(ctx context.Context, req *externalgrpc.NodeGroupDecreaseTargetSizeRequest) (*externalgrpc.NodeGroupDecreaseTargetSizeResponse, error) {
+	if req.Delta >= 0 {
+		return nil, status.Error(codes.InvalidArgument, "size decrease must be negative")
+	}
+	cluster, err := s.cluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pool, _, err := autoscaledPool(cluster, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	machines, err := s.poolMachines(cluster, pool.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// only machines which haven't been created yet can be removed by decreasing the target size
+	err = s.scale(ctx, req.Id, func(group *externalgrpc.NodeGroup, quantity int32) (int32, error) {
+		size := quantity + req.Delta
+		if size < int32(len(machines)) {
+			return 0, status.Errorf(codes.InvalidArgument, "attempt to delete existing machines, target size %d machines %d", size, len(machines))
+		}
+		return size, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &externalgrpc.NodeGroupDecreaseTargetSizeResponse{}, nil
+}
+
+func (s *server) NodeGroupNodes(ctx context.Context, req *externalgrpc.NodeGroupNodesRequest) (*externalgrpc.NodeGroupNodesResponse, error) {
+	cluster, err := s.cluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pool, _, err := autoscaledPool(cluster, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	machines, err := s.poolMachines(cluster, pool.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &externalgrpc.NodeGroupNodesResponse{}
+	for _, machine := range machines {
+		resp.Instances = append(resp.Instances, machineInstance(machine))
+	}
+	return resp, nil
+}
+
+// scale updates the quantity of the machine pool of a node group to the size returned by resize, retrying on
+// conflicts.
+func (s *server) scale(ctx context.Context, id string, resize func(group *externalgrpc.NodeGroup, quantity int32) (int32, error)) error {
+	cluster, err := s.cluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster, err := s.clusters.Get(cluster.Namespace, cluster.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		cluster = cluster.DeepCopy()
+		pool, group, err := autoscaledPool(cluster, id)
+		if err != nil {
+			return err
+		}
+		quantity := poolQuantity(pool)
+		size, err := resize(group, quantity)
+		if err != nil {
+			return err
+		}
+		if size == quantity {
+			return nil
+		}
+		pool.Quantity = &size
+		logrus.Infof("[autoscaler] rkecluster %s/%s: scaling machine pool %s from %d to %d", cluster.Namespace, cluster.Name, pool.Name, quantity, size)
+		_, err = s.clusters.Update(cluster)
+		return err
+	})
+}
+
+// cluster returns the cluster identified by the client certificate of the request.
+func (s *server) cluster(ctx context.Context) (*rancherv1.Cluster, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "no peer")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "no verified client certificate")
+	}
+
+	namespace, name, ok := strings.Cut(tlsInfo.State.VerifiedChains[0][0].Subject.CommonName, "/")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid client certificate")
+	}
+	cluster, err := s.clusterCache.Get(namespace, name)
+	if apierrors.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "cluster %s/%s not found", namespace, name)
+	} else if err != nil {
+		return nil, err
+	}
+	if cluster.Spec.RKEConfig == nil || !cluster.DeletionTimestamp.IsZero() {
+		return nil, status.Errorf(codes.FailedPrecondition, "cluster %s/%s can not be autoscaled", namespace, name)
+	}
+	return cluster, nil
+}
+
+func (s *server) clusterMachines(cluster *rancherv1.Cluster) ([]*capi.Machine, error) {
+	return s.machineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName: cluster.Name,
+	}))
+}
+
+func (s *server) poolMachines(cluster *rancherv1.Cluster, pool string) ([]*capi.Machine, error) {
+	return s.machineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName:        cluster.Name,
+		rke2.RKEMachinePoolNameLabel: pool,
+	}))
+}
+
+// autoscaledPool returns the machine pool of the cluster for the node group with the given id.
+func autoscaledPool(cluster *rancherv1.Cluster, id string) (*rancherv1.RKEMachinePool, *externalgrpc.NodeGroup, error) {
+	for i, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if pool.Name != id {
+			continue
+		}
+		group, ok := nodeGroupForPool(cluster, pool)
+		if !ok {
+			return nil, nil, status.Errorf(codes.FailedPrecondition, "machine pool %s is not autoscaled", id)
+		}
+		return &cluster.Spec.RKEConfig.MachinePools[i], group, nil
+	}
+	return nil, nil, status.Errorf(codes.NotFound, "node group %s not found", id)
+}
+
+// nodeGroupForPool returns the node group of a worker machine pool with valid min and max size annotations.
+func nodeGroupForPool(cluster *rancherv1.Cluster, pool rancherv1.RKEMachinePool) (*externalgrpc.NodeGroup, bool) {
+	if !pool.WorkerRole || pool.EtcdRole || pool.ControlPlaneRole || pool.Paused {
+		return nil, false
+	}
+	minSize, err := strconv.ParseInt(pool.MachineDeploymentAnnotations[MinSizeAnnotation], 10, 32)
+	if err != nil || minSize < 0 {
+		return nil, false
+	}
+	maxSize, err := strconv.ParseInt(pool.MachineDeploymentAnnotations[MaxSizeAnnotation], 10, 32)
+	if err != nil || maxSize < minSize || maxSize == 0 {
+		return nil, false
+	}
+	return &externalgrpc.NodeGroup{
+		Id:      pool.Name,
+		MinSize: int32(minSize),
+		MaxSize: int32(maxSize),
+		Debug:   fmt.Sprintf("%s/%s machine pool %s", cluster.Namespace, cluster.Name, pool.Name),
+	}, true
+}
+
+func poolQuantity(pool *rancherv1.RKEMachinePool) int32 {
+	if pool.Quantity == nil {
+		// the replicas of a MachineDeployment default to 1
+		return 1
+	}
+	return *pool.Quantity
+}
+
+func machineProviderID(machine *capi.Machine) string {
+	if machine.Spec.ProviderID != nil && *machine.Spec.ProviderID != "" {
+		return *machine.Spec.ProviderID
+	}
+	return providerIDPrefix + machine.Namespace + "/" + machine.Name
+}
+
+func machineIsNode(machine *capi.Machine, node *externalgrpc.ExternalGrpcNode) bool {
+	if node.ProviderID != "" && node.ProviderID == machineProviderID(machine) {
+		return true
+	}
+	return node.Name != "" && machine.Status.NodeRef != nil && machine.Status.NodeRef.Name == node.Name
+}
+
+func machineInstance(machine *capi.Machine) *externalgrpc.Instance {
+	instance := &externalgrpc.Instance{
+		Id:     machineProviderID(machine),
+		Status: &externalgrpc.InstanceStatus{InstanceState: externalgrpc.InstanceStatus_instanceCreating},
+	}
+	switch {
+	case !machine.DeletionTimestamp.IsZero():
+		instance.Status.InstanceState = externalgrpc.InstanceStatus_instanceDeleting
+	case machine.Status.NodeRef != nil:
+		instance.Status.InstanceState = externalgrpc.InstanceStatus_instanceRunning
+	case machine.Status.FailureMessage != nil && *machine.Status.FailureMessage != "":
+		instance.Status.ErrorInfo = &externalgrpc.InstanceErrorInfo{
+			ErrorCode:    "MachineFailed",
+			ErrorMessage: *machine.Status.FailureMessage,
+			// OtherErrorClass of the autoscaler
+			InstanceErrorClass: 99,
+		}
+	}
+	return instance
+}
@@ -0,0 +1,147 @@
+package autoscaler
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/provisioningv2/autoscaler/externalgrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestNodeGroupForPool(t *testing.T) {
+	cluster := &rancherv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "c1"}}
+	sizes := map[string]string{MinSizeAnnotation: "1", MaxSizeAnnotation: "5"}
+
+	tests := []struct {
+		name string
+		pool rancherv1.RKEMachinePool
+		want *externalgrpc.NodeGroup
+	}{
+		{
+			name: "worker pool",
+			pool: rancherv1.RKEMachinePool{Name: "workers", WorkerRole: true, MachineDeploymentAnnotations: sizes},
+			want: &externalgrpc.NodeGroup{Id: "workers", MinSize: 1, MaxSize: 5, Debug: "fleet-default/c1 machine pool workers"},
+		},
+		{
+			name: "scale from zero",
+			pool: rancherv1.RKEMachinePool{Name: "workers", WorkerRole: true, MachineDeploymentAnnotations: map[string]string{
+				MinSizeAnnotation: "0", MaxSizeAnnotation: "3",
+			}},
+			want: &externalgrpc.NodeGroup{Id: "workers", MinSize: 0, MaxSize: 3, Debug: "fleet-default/c1 machine pool workers"},
+		},
+		{
+			name: "no annotations",
+			pool: rancherv1.RKEMachinePool{Name: "workers", WorkerRole: true},
+		},
+		{
+			name: "control plane pool",
+			pool: rancherv1.RKEMachinePool{Name: "cp", WorkerRole: true, ControlPlaneRole: true, MachineDeploymentAnnotations: sizes},
+		},
+		{
+			name: "paused pool",
+			pool: rancherv1.RKEMachinePool{Name: "workers", WorkerRole: true, Paused: true, MachineDeploymentAnnotations: sizes},
+		},
+		{
+			name: "max below min",
+			pool: rancherv1.RKEMachinePool{Name: "workers", WorkerRole: true, MachineDeploymentAnnotations: map[string]string{
+				MinSizeAnnotation: "3", MaxSizeAnnotation: "2",
+			}},
+		},
+		{
+			name: "invalid size",
+			pool: rancherv1.RKEMachinePool{Name: "workers", WorkerRole: true, MachineDeploymentAnnotations: map[string]string{
+				MinSizeAnnotation: "one", MaxSizeAnnotation: "2",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group, ok := nodeGroupForPool(cluster, tt.pool)
+			assert.Equal(t, tt.want != nil, ok)
+			assert.Equal(t, tt.want, group)
+		})
+	}
+}
+
+func TestMachineInstance(t *testing.T) {
+	providerID := "vsphere://42"
+	running := &capi.Machine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "m1"},
+		Spec:       capi.MachineSpec{ProviderID: &providerID},
+		Status:     capi.MachineStatus{NodeRef: &corev1.ObjectReference{Name: "node1"}},
+	}
+	creating := &capi.Machine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "m2"},
+	}
+
+	assert.Equal(t, &externalgrpc.Instance{Id: providerID, Status: &externalgrpc.InstanceStatus{InstanceState: externalgrpc.InstanceStatus_instanceRunning}}, machineInstance(running))
+	assert.Equal(t, &externalgrpc.Instance{Id: "rancher://fleet-default/m2", Status: &externalgrpc.InstanceStatus{InstanceState: externalgrpc.InstanceStatus_instanceCreating}}, machineInstance(creating))
+
+	assert.True(t, machineIsNode(running, &externalgrpc.ExternalGrpcNode{ProviderID: providerID}))
+	assert.True(t, machineIsNode(running, &externalgrpc.ExternalGrpcNode{Name: "node1"}))
+	assert.True(t, machineIsNode(creating, &externalgrpc.ExternalGrpcNode{ProviderID: "rancher://fleet-default/m2"}))
+	assert.False(t, machineIsNode(creating, &externalgrpc.ExternalGrpcNode{Name: "node1"}))
+}
+
+func TestMessageEncoding(t *testing.T) {
+	codec := encoding.GetCodec("proto")
+
+	// NodeGroupNodesResponse{instances: [{id: "a", status: {instanceState: instanceCreating}}]} encoded with the
+	// upstream definitions
+	data, err := codec.Marshal(&externalgrpc.NodeGroupNodesResponse{Instances: []*externalgrpc.Instance{{
+		Id:     "a",
+		Status: &externalgrpc.InstanceStatus{InstanceState: externalgrpc.InstanceStatus_instanceCreating},
+	}}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0x07, 0x0a, 0x01, 'a', 0x12, 0x02, 0x08, 0x02}, data)
+
+	// NodeGroupDeleteNodesRequest{nodes: [{name: "n", labels: {"a": "b"}}], id: "workers"}
+	var req externalgrpc.NodeGroupDeleteNodesRequest
+	err = codec.Unmarshal([]byte{
+		0x0a, 0x0b, 0x12, 0x01, 'n', 0x1a, 0x06, 0x0a, 0x01, 'a', 0x12, 0x01, 'b',
+		0x12, 0x07, 'w', 'o', 'r', 'k', 'e', 'r', 's',
+	}, &req)
+	require.NoError(t, err)
+	assert.Equal(t, "workers", req.Id)
+	if assert.Len(t, req.Nodes, 1) {
+		assert.Equal(t, "n", req.Nodes[0].Name)
+		assert.Equal(t, map[string]string{"a": "b"}, req.Nodes[0].Labels)
+	}
+}
+
+func TestServiceMethods(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	externalgrpc.RegisterCloudProviderServer(grpcServer, &server{})
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// the method names are the ones the externalgrpc cloud provider of the cluster-autoscaler calls
+	var gpuLabel externalgrpc.GPULabelResponse
+	err = conn.Invoke(context.Background(), "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/GPULabel", &externalgrpc.GPULabelRequest{}, &gpuLabel)
+	assert.NoError(t, err)
+
+	var increase externalgrpc.NodeGroupIncreaseSizeResponse
+	err = conn.Invoke(context.Background(), "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupIncreaseSize", &externalgrpc.NodeGroupIncreaseSizeRequest{Id: "workers"}, &increase)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -37,5 +37,8 @@ func (p *Planner) generateClusterAgentManifest(controlPlane *rkev1.RKEControlPla
 		return nil, err
 	}
 
+	if source := controlPlane.Spec.ETCDSnapshotSource; source != nil && source.ServerURL != "" {
+		return systemtemplate.ForClusterWithServerURL(mgmtCluster, tokens[0].Status.Token, source.ServerURL, taints, p.secretCache)
+	}
 	return systemtemplate.ForCluster(mgmtCluster, tokens[0].Status.Token, taints, p.secretCache)
 }
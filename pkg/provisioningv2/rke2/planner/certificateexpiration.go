@@ -0,0 +1,119 @@
+package planner
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	certificateExpirationInstructionName = "certificate-expiration"
+	certificateExpirationPeriod          = 3600
+
+	// certificateExpirationLayout is the layout of the dates printed by openssl x509 -enddate
+	certificateExpirationLayout = "Jan _2 15:04:05 2006 MST"
+
+	// certificateExpirationScript prints the path and expiration date of each leaf certificate of the node. CA
+	// certificates are skipped as they are not rotated.
+	certificateExpirationScript = `
+for f in /var/lib/rancher/%[1]s/server/tls/*.crt /var/lib/rancher/%[1]s/server/tls/etcd/*.crt /var/lib/rancher/%[1]s/agent/*.crt; do
+  [ -f "$f" ] || continue
+  case "$f" in
+    *-ca.crt) continue ;;
+  esac
+  echo "$f $(openssl x509 -noout -enddate -in "$f" | cut -d= -f2)"
+done
+`
+)
+
+// automaticCertificateRotationEnabled returns true if the expiration of the node certificates should be collected.
+func automaticCertificateRotationEnabled(controlPlane *rkev1.RKEControlPlane) bool {
+	return controlPlane != nil && controlPlane.Spec.AutomaticCertificateRotation != nil &&
+		controlPlane.Spec.AutomaticCertificateRotation.Enabled
+}
+
+func (p *Planner) addCertificateExpirationPeriodicInstruction(nodePlan plan.NodePlan, controlPlane *rkev1.RKEControlPlane) (plan.NodePlan, error) {
+	nodePlan.PeriodicInstructions = append(nodePlan.PeriodicInstructions, plan.PeriodicInstruction{
+		Name:    certificateExpirationInstructionName,
+		Command: "sh",
+		Args: []string{
+			"-c",
+			fmt.Sprintf(certificateExpirationScript, rke2.GetRuntime(controlPlane.Spec.KubernetesVersion)),
+		},
+		PeriodSeconds: certificateExpirationPeriod,
+	})
+	return nodePlan, nil
+}
+
+// reconcileCertificateExpiration sets the certificate expiring first on each machine from the output of the
+// certificate-expiration periodic instruction. Output collected before the last certificate rotation is ignored.
+func reconcileCertificateExpiration(controlPlane *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, clusterPlan *plan.Plan) rkev1.RKEControlPlaneStatus {
+	if !automaticCertificateRotationEnabled(controlPlane) {
+		status.CertificateExpiration = nil
+		return status
+	}
+
+	var expirations []rkev1.CertificateExpiration
+	for _, entry := range collect(clusterPlan, anyRole) {
+		if entry.Plan == nil {
+			continue
+		}
+		output, ok := entry.Plan.PeriodicOutput[certificateExpirationInstructionName]
+		if !ok || output.ExitCode != 0 || output.LastSuccessfulRunTime == "" {
+			continue
+		}
+		checkedAt, err := time.Parse(time.UnixDate, output.LastSuccessfulRunTime)
+		if err != nil {
+			continue
+		}
+		if status.CertificateRotationTime != nil && checkedAt.Before(status.CertificateRotationTime.Time) {
+			continue
+		}
+		certificate, expiresAt, ok := earliestCertificateExpiration(string(output.Stdout))
+		if !ok {
+			continue
+		}
+		expirations = append(expirations, rkev1.CertificateExpiration{
+			MachineName: entry.Machine.Name,
+			Certificate: certificate,
+			ExpiresAt:   metav1.NewTime(expiresAt),
+			CheckedAt:   metav1.NewTime(checkedAt),
+		})
+	}
+
+	status.CertificateExpiration = expirations
+	return status
+}
+
+// earliestCertificateExpiration parses the output of the certificate-expiration periodic instruction and returns the
+// certificate expiring first. Lines which can not be parsed are skipped.
+func earliestCertificateExpiration(output string) (string, time.Time, bool) {
+	var (
+		certificate string
+		expiresAt   time.Time
+		found       bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		path, date, ok := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(certificateExpirationLayout, strings.TrimSpace(date))
+		if err != nil {
+			continue
+		}
+		if !found || t.Before(expiresAt) {
+			certificate, expiresAt, found = path, t, true
+		}
+	}
+
+	return certificate, expiresAt, found
+}
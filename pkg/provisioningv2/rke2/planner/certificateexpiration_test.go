@@ -0,0 +1,52 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_earliestCertificateExpiration(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		certificate string
+		expiresAt   time.Time
+		found       bool
+	}{
+		{
+			name:   "empty",
+			output: "",
+		},
+		{
+			name: "earliest",
+			output: "/var/lib/rancher/rke2/server/tls/client-admin.crt Mar  1 12:00:00 2025 GMT\n" +
+				"/var/lib/rancher/rke2/server/tls/serving-kube-apiserver.crt Feb 14 08:30:00 2025 GMT\n" +
+				"/var/lib/rancher/rke2/agent/client-kubelet.crt Dec 31 23:59:59 2025 GMT\n",
+			certificate: "/var/lib/rancher/rke2/server/tls/serving-kube-apiserver.crt",
+			expiresAt:   time.Date(2025, time.February, 14, 8, 30, 0, 0, time.UTC),
+			found:       true,
+		},
+		{
+			name: "unparsable lines are skipped",
+			output: "/var/lib/rancher/k3s/server/tls/client-admin.crt\n" +
+				"/var/lib/rancher/k3s/server/tls/client-controller.crt Could not open file\n" +
+				"/var/lib/rancher/k3s/agent/serving-kubelet.crt Jun 10 00:00:00 2025 GMT\n",
+			certificate: "/var/lib/rancher/k3s/agent/serving-kubelet.crt",
+			expiresAt:   time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC),
+			found:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certificate, expiresAt, found := earliestCertificateExpiration(tt.output)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.certificate, certificate)
+			if tt.found {
+				assert.True(t, tt.expiresAt.Equal(expiresAt), "expected %s, got %s", tt.expiresAt, expiresAt)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // rotateCertificates checks if there is a need to rotate any certificates and updates the plan accordingly.
@@ -20,26 +21,69 @@ func (p *Planner) rotateCertificates(controlPlane *rkev1.RKEControlPlane, status
 		return status, ErrWaiting("pausing CAPI cluster")
 	}
 
-	for _, node := range collect(clusterPlan, anyRole) {
-		if !shouldRotateEntry(controlPlane.Spec.RotateCertificates, node) {
-			continue
-		}
+	// etcd members are rotated one at a time to keep quorum, like they are upgraded
+	if err := p.rotateCertificatesTier(controlPlane, clusterPlan, isEtcd, "1"); err != nil {
+		return status, err
+	}
 
-		rotatePlan := rotateCertificatesPlan(controlPlane, controlPlane.Spec.RotateCertificates, node)
-		err := assignAndCheckPlan(p.store, fmt.Sprintf("[%s] certificate rotation", node.Machine.Name), node, rotatePlan, 0, 0)
-		if err != nil {
-			return status, err
-		}
+	if err := p.rotateCertificatesTier(controlPlane, clusterPlan, isOnlyControlPlane, controlPlane.Spec.UpgradeStrategy.ControlPlaneConcurrency); err != nil {
+		return status, err
+	}
+
+	if err := p.rotateCertificatesTier(controlPlane, clusterPlan, isOnlyWorker, controlPlane.Spec.UpgradeStrategy.WorkerConcurrency); err != nil {
+		return status, err
 	}
 
 	if err := p.pauseCAPICluster(controlPlane, false); err != nil {
 		return status, ErrWaiting("unpausing CAPI cluster")
 	}
 
+	now := metav1.Now()
 	status.CertificateRotationGeneration = controlPlane.Spec.RotateCertificates.Generation
+	status.CertificateRotationTime = &now
+	status.CertificateExpiration = nil
 	return status, ErrWaiting("certificate rotation done")
 }
 
+// rotateCertificatesTier rotates the certificates of the nodes matching include, rotating up to concurrency nodes at a
+// time. It returns an ErrWaiting until the certificates of all nodes have been rotated.
+func (p *Planner) rotateCertificatesTier(controlPlane *rkev1.RKEControlPlane, clusterPlan *plan.Plan, include roleFilter, maxUnavailable string) error {
+	entries := collect(clusterPlan, include)
+	concurrency, _, err := calculateConcurrency(maxUnavailable, entries, isDeleting)
+	if err != nil {
+		return err
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		inProgress   int
+		firstWaiting error
+	)
+	for _, node := range entries {
+		if !shouldRotateEntry(controlPlane.Spec.RotateCertificates, node) {
+			continue
+		}
+
+		rotatePlan := rotateCertificatesPlan(controlPlane, controlPlane.Spec.RotateCertificates, node)
+		err := assignAndCheckPlan(p.store, fmt.Sprintf("[%s] certificate rotation", node.Machine.Name), node, rotatePlan, 0, 0)
+		if err == nil {
+			continue
+		}
+		if !IsErrWaiting(err) {
+			return err
+		}
+		if firstWaiting == nil {
+			firstWaiting = err
+		}
+		if inProgress++; inProgress >= concurrency {
+			break
+		}
+	}
+	return firstWaiting
+}
+
 // shouldRotate `true` if the cluster is ready and the generation is stale
 func shouldRotate(cp *rkev1.RKEControlPlane) bool {
 	// The controlplane must be initialized before we rotate anything
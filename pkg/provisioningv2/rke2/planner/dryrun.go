@@ -0,0 +1,153 @@
+package planner
+
+import (
+	"sort"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// isDryRun returns true if the control plane is annotated to preview plan changes instead of applying them.
+func isDryRun(controlPlane *rkev1.RKEControlPlane) bool {
+	return controlPlane.Annotations[rke2.DryRunAnnotation] == "true"
+}
+
+// previewPlans computes the plan of every machine of the cluster like reconcile would and returns how it differs from
+// the plan last applied to the machine. No plans are changed.
+func (p *Planner) previewPlans(controlPlane *rkev1.RKEControlPlane, tokensSecret plan.Secret, clusterPlan *plan.Plan) (*rkev1.PlanPreview, error) {
+	var joinServer string
+	if _, initJoinServer, initNode, err := p.findInitNode(controlPlane, clusterPlan); err != nil {
+		return nil, err
+	} else if initNode != nil {
+		joinServer = initJoinServer
+	}
+	workerJoinServer := getControlPlaneJoinURL(clusterPlan)
+
+	preview := &rkev1.PlanPreview{
+		ObservedGeneration: controlPlane.Generation,
+	}
+	for _, entry := range collect(clusterPlan, anyRole) {
+		if isDeleting(entry) {
+			continue
+		}
+
+		entryJoinServer, drainOptions := joinServer, controlPlane.Spec.UpgradeStrategy.ControlPlaneDrainOptions
+		if isInitNode(entry) {
+			entryJoinServer = ""
+		} else if isOnlyWorker(entry) {
+			entryJoinServer, drainOptions = workerJoinServer, controlPlane.Spec.UpgradeStrategy.WorkerDrainOptions
+		}
+
+		desired, err := p.desiredPlan(controlPlane, tokensSecret, entry, entryJoinServer)
+		if err != nil {
+			return nil, err
+		}
+
+		var applied *plan.NodePlan
+		if entry.Plan != nil {
+			applied = entry.Plan.AppliedPlan
+		}
+		nodePreview := previewNodePlan(applied, desired)
+		nodePreview.MachineName = entry.Machine.Name
+		if entry.Machine.Status.NodeRef != nil {
+			nodePreview.NodeName = entry.Machine.Status.NodeRef.Name
+		}
+		nodePreview.Drain = nodePreview.Change == rkev1.PlanChangeMajor && drainOptions.Enabled &&
+			len(clusterPlan.Machines) > 1 && nodePreview.NodeName != "" && shouldDrain(applied, desired)
+		preview.Nodes = append(preview.Nodes, nodePreview)
+	}
+
+	sort.Slice(preview.Nodes, func(i, j int) bool {
+		return preview.Nodes[i].MachineName < preview.Nodes[j].MachineName
+	})
+	return preview, nil
+}
+
+// previewNodePlan returns the files, instructions and probes that differ between the applied and the desired plan, and
+// whether updating the plan is a minor or a major change. A node without an applied plan has a major change.
+func previewNodePlan(applied *plan.NodePlan, desired plan.NodePlan) rkev1.NodePlanPreview {
+	old := plan.NodePlan{}
+	if applied != nil {
+		old = *applied
+	}
+
+	preview := rkev1.NodePlanPreview{
+		ChangedFiles:                changedFiles(old.Files, desired.Files),
+		ChangedInstructions:         changedKeys(instructionsByName(old.Instructions), instructionsByName(desired.Instructions)),
+		ChangedPeriodicInstructions: changedKeys(periodicInstructionsByName(old.PeriodicInstructions), periodicInstructionsByName(desired.PeriodicInstructions)),
+		ChangedProbes:               changedKeys(probesByName(old.Probes), probesByName(desired.Probes)),
+	}
+
+	switch {
+	case applied == nil:
+		preview.Change = rkev1.PlanChangeMajor
+	case equality.Semantic.DeepEqual(old, desired):
+		preview.Change = rkev1.PlanChangeNone
+	case minorPlanChangeDetected(old, desired):
+		preview.Change = rkev1.PlanChangeMinor
+	default:
+		preview.Change = rkev1.PlanChangeMajor
+	}
+	return preview
+}
+
+// changedFiles returns the paths of the files that were added, removed or whose content changed.
+func changedFiles(old, new []plan.File) []string {
+	oldFiles := map[string]interface{}{}
+	for _, file := range old {
+		oldFiles[file.Path] = file.Content
+	}
+	newFiles := map[string]interface{}{}
+	for _, file := range new {
+		newFiles[file.Path] = file.Content
+	}
+	return changedKeys(oldFiles, newFiles)
+}
+
+// instructionsByName groups the instructions by name, as one-time instructions don't need to have unique names.
+func instructionsByName(instructions []plan.OneTimeInstruction) map[string]interface{} {
+	byName := map[string][]plan.OneTimeInstruction{}
+	for _, instruction := range instructions {
+		byName[instruction.Name] = append(byName[instruction.Name], instruction)
+	}
+	result := map[string]interface{}{}
+	for name, instructions := range byName {
+		result[name] = instructions
+	}
+	return result
+}
+
+func periodicInstructionsByName(instructions []plan.PeriodicInstruction) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, instruction := range instructions {
+		result[instruction.Name] = instruction
+	}
+	return result
+}
+
+func probesByName(probes map[string]plan.Probe) map[string]interface{} {
+	result := map[string]interface{}{}
+	for name, probe := range probes {
+		result[name] = probe
+	}
+	return result
+}
+
+// changedKeys returns the sorted keys that are only in one of the maps or whose values differ.
+func changedKeys(old, new map[string]interface{}) []string {
+	var result []string
+	for key, oldValue := range old {
+		if newValue, ok := new[key]; !ok || !equality.Semantic.DeepEqual(oldValue, newValue) {
+			result = append(result, key)
+		}
+	}
+	for key := range new {
+		if _, ok := old[key]; !ok {
+			result = append(result, key)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
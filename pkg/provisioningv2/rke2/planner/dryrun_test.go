@@ -0,0 +1,52 @@
+package planner
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviewNodePlan(t *testing.T) {
+	applied := plan.NodePlan{
+		Files: []plan.File{
+			{Path: "/etc/rancher/rke2/config.yaml.d/50-rancher.yaml", Content: "a"},
+			{Path: "/var/lib/rancher/rke2/server/db/rancher-snapshot-prune-local", Content: "a", Dynamic: true, Minor: true},
+		},
+		Instructions: []plan.OneTimeInstruction{{Name: "install", Args: []string{"a"}}},
+		PeriodicInstructions: []plan.PeriodicInstruction{
+			{Name: "etcd-snapshot-list-local", PeriodSeconds: 600},
+		},
+		Probes: map[string]plan.Probe{"kubelet": {FailureThreshold: 2}},
+	}
+
+	preview := previewNodePlan(&applied, applied)
+	assert.Equal(t, rkev1.PlanChangeNone, preview.Change)
+	assert.Empty(t, preview.ChangedFiles)
+
+	minor := applied
+	minor.Files = []plan.File{
+		applied.Files[0],
+		{Path: "/var/lib/rancher/rke2/server/db/rancher-snapshot-prune-local", Content: "b", Dynamic: true, Minor: true},
+	}
+	preview = previewNodePlan(&applied, minor)
+	assert.Equal(t, rkev1.PlanChangeMinor, preview.Change)
+	assert.Equal(t, []string{"/var/lib/rancher/rke2/server/db/rancher-snapshot-prune-local"}, preview.ChangedFiles)
+	assert.Empty(t, preview.ChangedInstructions)
+
+	major := applied
+	major.Files = applied.Files[:1]
+	major.Instructions = []plan.OneTimeInstruction{{Name: "install", Args: []string{"b"}}}
+	major.Probes = map[string]plan.Probe{"kubelet": {FailureThreshold: 3}, "etcd": {}}
+	preview = previewNodePlan(&applied, major)
+	assert.Equal(t, rkev1.PlanChangeMajor, preview.Change)
+	assert.Equal(t, []string{"/var/lib/rancher/rke2/server/db/rancher-snapshot-prune-local"}, preview.ChangedFiles)
+	assert.Equal(t, []string{"install"}, preview.ChangedInstructions)
+	assert.Empty(t, preview.ChangedPeriodicInstructions)
+	assert.Equal(t, []string{"etcd", "kubelet"}, preview.ChangedProbes)
+
+	preview = previewNodePlan(nil, applied)
+	assert.Equal(t, rkev1.PlanChangeMajor, preview.Change)
+	assert.Equal(t, []string{"etcd-snapshot-list-local"}, preview.ChangedPeriodicInstructions)
+}
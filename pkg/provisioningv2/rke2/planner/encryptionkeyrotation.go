@@ -5,6 +5,7 @@
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -124,9 +125,16 @@
 )
 
 func (p *Planner) setEncryptionKeyRotateState(status rkev1.RKEControlPlaneStatus, rotate *rkev1.RotateEncryptionKeys, phase rkev1.RotateEncryptionKeysPhase) (rkev1.RKEControlPlaneStatus, error) {
+	return p.setEncryptionKeyRotateStateWithReason(status, rotate, phase, "")
+}
+
+// setEncryptionKeyRotateStateWithReason sets the encryption key rotation phase, recording the reason in the rotation
+// history if the phase is failed.
+func (p *Planner) setEncryptionKeyRotateStateWithReason(status rkev1.RKEControlPlaneStatus, rotate *rkev1.RotateEncryptionKeys, phase rkev1.RotateEncryptionKeysPhase, reason string) (rkev1.RKEControlPlaneStatus, error) {
 	if equality.Semantic.DeepEqual(status.RotateEncryptionKeys, rotate) && equality.Semantic.DeepEqual(status.RotateEncryptionKeysPhase, phase) {
 		return status, nil
 	}
+	status = recordEncryptionKeyRotationPhase(status, rotate, phase, reason, time.Now())
 	status.RotateEncryptionKeys = rotate
 	status.RotateEncryptionKeysPhase = phase
 	return status, ErrWaiting("refreshing encryption key rotation state")
@@ -154,7 +162,8 @@ func (p *Planner) rotateEncryptionKeys(cp *rkev1.RKEControlPlane, status rkev1.R
 		return status, err
 	} else if !supported {
 		logrus.Debugf("rkecluster %s/%s: marking encryption key rotation phase as failed as it was not supported by version: %s", cp.Namespace, cp.Name, cp.Spec.KubernetesVersion)
-		return p.setEncryptionKeyRotateState(status, cp.Spec.RotateEncryptionKeys, rkev1.RotateEncryptionKeysPhaseFailed)
+		return p.setEncryptionKeyRotateStateWithReason(status, cp.Spec.RotateEncryptionKeys, rkev1.RotateEncryptionKeysPhaseFailed,
+			fmt.Sprintf("encryption key rotation is not supported by %s", cp.Spec.KubernetesVersion))
 	}
 
 	if !canRotateEncryptionKeys(cp) {
@@ -173,6 +182,7 @@ func (p *Planner) rotateEncryptionKeys(cp *rkev1.RKEControlPlane, status rkev1.R
 
 	if status.RotateEncryptionKeysLeader != leader.Machine.Name {
 		status.RotateEncryptionKeysLeader = leader.Machine.Name
+		status = recordEncryptionKeyRotationLeader(status, leader.Machine.Name)
 		return status, ErrWaitingf("elected %s as control plane leader for encryption key rotation", leader.Machine.Name)
 	}
 
@@ -783,6 +793,7 @@ func encryptionKeyRotationIsCurrentStageAllowed(leaderStage string, currentPhase
 // encryptionKeyRotationFailed updates the various status objects on the control plane, allowing the cluster to
 // continue the reconciliation loop. Encryption key rotation will not be restarted again until requested.
 func (p *Planner) encryptionKeyRotationFailed(status rkev1.RKEControlPlaneStatus, err error) (rkev1.RKEControlPlaneStatus, error) {
+	status = recordEncryptionKeyRotationPhase(status, status.RotateEncryptionKeys, rkev1.RotateEncryptionKeysPhaseFailed, err.Error(), time.Now())
 	status.RotateEncryptionKeysPhase = rkev1.RotateEncryptionKeysPhaseFailed
 	return status, errors.Wrap(err, "encryption key rotation failed, please perform an etcd restore")
 }
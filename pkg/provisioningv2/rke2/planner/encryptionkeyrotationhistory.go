@@ -0,0 +1,74 @@
+package planner
+
+import (
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const maxEncryptionKeyRotationHistory = 10
+
+// recordEncryptionKeyRotationPhase records the transition of the encryption key rotation of the given generation to
+// phase in the history of the status, the most recent rotation first. Entering the prepare phase starts a new entry,
+// an unfinished rotation it replaces is recorded as failed.
+func recordEncryptionKeyRotationPhase(status rkev1.RKEControlPlaneStatus, rotate *rkev1.RotateEncryptionKeys, phase rkev1.RotateEncryptionKeysPhase, reason string, now time.Time) rkev1.RKEControlPlaneStatus {
+	if rotate == nil || phase == "" {
+		return status
+	}
+
+	history := append([]rkev1.EncryptionKeyRotationHistoryEntry{}, status.EncryptionKeyRotationHistory...)
+	if len(history) > 0 && history[0].Generation == rotate.Generation && history[0].Result == "" &&
+		len(history[0].Phases) > 0 && history[0].Phases[len(history[0].Phases)-1].Phase == phase {
+		return status
+	}
+
+	if phase == rkev1.RotateEncryptionKeysPhasePrepare || len(history) == 0 ||
+		history[0].Generation != rotate.Generation || history[0].Result != "" {
+		if len(history) > 0 && history[0].Result == "" {
+			history[0] = finishEncryptionKeyRotation(history[0], rkev1.RotateEncryptionKeysPhaseFailed, "encryption key rotation was restarted", now)
+		}
+		history = append([]rkev1.EncryptionKeyRotationHistoryEntry{{
+			Generation: rotate.Generation,
+			StartedAt:  metav1.NewTime(now),
+		}}, history...)
+	}
+
+	entry := history[0]
+	entry.Phases = append(append([]rkev1.EncryptionKeyRotationPhaseTransition{}, entry.Phases...), rkev1.EncryptionKeyRotationPhaseTransition{
+		Phase: phase,
+		Time:  metav1.NewTime(now),
+	})
+	if status.RotateEncryptionKeysLeader != "" {
+		entry.Leader = status.RotateEncryptionKeysLeader
+	}
+	if phase == rkev1.RotateEncryptionKeysPhaseDone || phase == rkev1.RotateEncryptionKeysPhaseFailed {
+		entry = finishEncryptionKeyRotation(entry, phase, reason, now)
+	}
+	history[0] = entry
+
+	if len(history) > maxEncryptionKeyRotationHistory {
+		history = history[:maxEncryptionKeyRotationHistory]
+	}
+	status.EncryptionKeyRotationHistory = history
+	return status
+}
+
+// recordEncryptionKeyRotationLeader records the leader elected for the encryption key rotation in progress.
+func recordEncryptionKeyRotationLeader(status rkev1.RKEControlPlaneStatus, leader string) rkev1.RKEControlPlaneStatus {
+	if len(status.EncryptionKeyRotationHistory) == 0 || status.EncryptionKeyRotationHistory[0].Result != "" {
+		return status
+	}
+	status.EncryptionKeyRotationHistory = append([]rkev1.EncryptionKeyRotationHistoryEntry{}, status.EncryptionKeyRotationHistory...)
+	status.EncryptionKeyRotationHistory[0].Leader = leader
+	return status
+}
+
+func finishEncryptionKeyRotation(entry rkev1.EncryptionKeyRotationHistoryEntry, result rkev1.RotateEncryptionKeysPhase, reason string, now time.Time) rkev1.EncryptionKeyRotationHistoryEntry {
+	entry.Result = result
+	entry.FinishedAt = metav1.NewTime(now)
+	if result == rkev1.RotateEncryptionKeysPhaseFailed {
+		entry.FailureReason = reason
+	}
+	return entry
+}
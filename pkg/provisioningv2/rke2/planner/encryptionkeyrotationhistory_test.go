@@ -0,0 +1,78 @@
+package planner
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rancher/channelserver/pkg/model"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordEncryptionKeyRotationPhase(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	rotate := &rkev1.RotateEncryptionKeys{Generation: 1}
+
+	var status rkev1.RKEControlPlaneStatus
+	status = recordEncryptionKeyRotationPhase(status, rotate, rkev1.RotateEncryptionKeysPhasePrepare, "", now)
+	status = recordEncryptionKeyRotationLeader(status, "cp-0")
+	status = recordEncryptionKeyRotationPhase(status, rotate, rkev1.RotateEncryptionKeysPhasePrepare, "", now)
+	status = recordEncryptionKeyRotationPhase(status, rotate, rkev1.RotateEncryptionKeysPhaseRotate, "", now.Add(time.Minute))
+	status = recordEncryptionKeyRotationPhase(status, rotate, rkev1.RotateEncryptionKeysPhaseDone, "", now.Add(2*time.Minute))
+
+	if assert.Len(t, status.EncryptionKeyRotationHistory, 1) {
+		entry := status.EncryptionKeyRotationHistory[0]
+		assert.Equal(t, int64(1), entry.Generation)
+		assert.Equal(t, "cp-0", entry.Leader)
+		assert.Equal(t, rkev1.RotateEncryptionKeysPhaseDone, entry.Result)
+		assert.True(t, entry.StartedAt.Time.Equal(now))
+		assert.True(t, entry.FinishedAt.Time.Equal(now.Add(2*time.Minute)))
+		var phases []rkev1.RotateEncryptionKeysPhase
+		for _, transition := range entry.Phases {
+			phases = append(phases, transition.Phase)
+		}
+		assert.Equal(t, []rkev1.RotateEncryptionKeysPhase{
+			rkev1.RotateEncryptionKeysPhasePrepare,
+			rkev1.RotateEncryptionKeysPhaseRotate,
+			rkev1.RotateEncryptionKeysPhaseDone,
+		}, phases)
+	}
+
+	// a restarted rotation fails the unfinished one
+	rotate = &rkev1.RotateEncryptionKeys{Generation: 2}
+	status = recordEncryptionKeyRotationPhase(status, rotate, rkev1.RotateEncryptionKeysPhasePrepare, "", now.Add(time.Hour))
+	status = recordEncryptionKeyRotationPhase(status, rotate, rkev1.RotateEncryptionKeysPhaseRotate, "", now.Add(90*time.Minute))
+	status = recordEncryptionKeyRotationPhase(status, rotate, rkev1.RotateEncryptionKeysPhasePrepare, "", now.Add(2*time.Hour))
+	status = recordEncryptionKeyRotationPhase(status, rotate, rkev1.RotateEncryptionKeysPhaseFailed, "leader failed", now.Add(3*time.Hour))
+
+	if assert.Len(t, status.EncryptionKeyRotationHistory, 3) {
+		assert.Equal(t, rkev1.RotateEncryptionKeysPhaseFailed, status.EncryptionKeyRotationHistory[0].Result)
+		assert.Equal(t, "leader failed", status.EncryptionKeyRotationHistory[0].FailureReason)
+		assert.Equal(t, rkev1.RotateEncryptionKeysPhaseFailed, status.EncryptionKeyRotationHistory[1].Result)
+		assert.Equal(t, rkev1.RotateEncryptionKeysPhaseDone, status.EncryptionKeyRotationHistory[2].Result)
+	}
+
+	for i := 0; i < maxEncryptionKeyRotationHistory; i++ {
+		rotate = &rkev1.RotateEncryptionKeys{Generation: int64(i + 3)}
+		status = recordEncryptionKeyRotationPhase(status, rotate, rkev1.RotateEncryptionKeysPhasePrepare, "", now)
+		status = recordEncryptionKeyRotationPhase(status, rotate, rkev1.RotateEncryptionKeysPhaseDone, "", now)
+	}
+	assert.Len(t, status.EncryptionKeyRotationHistory, maxEncryptionKeyRotationHistory, fmt.Sprintf("history is capped at %d", maxEncryptionKeyRotationHistory))
+}
+
+func TestRotateEncryptionKeysUnsupportedVersionRecordsReason(t *testing.T) {
+	cp := createTestControlPlane("v1.22.6+rke2r1")
+	cp.Spec.RotateEncryptionKeys = &rkev1.RotateEncryptionKeys{Generation: 1}
+	releaseData := &model.Release{FeatureVersions: map[string]string{"encryption-key-rotation": "1.0.0"}}
+
+	status, err := (&Planner{}).rotateEncryptionKeys(cp, cp.Status, plan.Secret{}, &plan.Plan{}, releaseData)
+	assert.Error(t, err)
+	assert.Equal(t, rkev1.RotateEncryptionKeysPhaseFailed, status.RotateEncryptionKeysPhase)
+	if assert.Len(t, status.EncryptionKeyRotationHistory, 1) {
+		entry := status.EncryptionKeyRotationHistory[0]
+		assert.Equal(t, rkev1.RotateEncryptionKeysPhaseFailed, entry.Result)
+		assert.Equal(t, "encryption key rotation is not supported by v1.22.6+rke2r1", entry.FailureReason)
+	}
+}
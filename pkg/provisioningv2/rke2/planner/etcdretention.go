@@ -0,0 +1,265 @@
+package planner
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	etcdSnapshotPruneInstructionName = "etcd-snapshot-prune"
+	// nodeSnapshotRetentionFactor is how many times the number of snapshots the retention policies can keep the etcd
+	// nodes retain, so they don't prune snapshots the policies keep but still prune if Rancher doesn't.
+	nodeSnapshotRetentionFactor = 2
+	// defaultSnapshotRetention is the etcd-snapshot-retention default of RKE2 and K3s.
+	defaultSnapshotRetention = 5
+)
+
+// etcdSnapshotPruneScript deletes the local snapshots listed in the local prune file that still exist on the node and
+// the S3 snapshots listed in the S3 prune file.
+const etcdSnapshotPruneScript = `dir=/var/lib/rancher/%[1]s/server/db/snapshots
+names=""
+for name in $(cat %[3]s 2>/dev/null); do
+  if [ -e "$dir/$name" ]; then
+    names="$names $name"
+  fi
+done
+if [ -n "$names" ]; then
+  %[2]s etcd-snapshot delete --etcd-s3=false $names || exit 1
+fi
+if [ -s %[4]s ]; then
+  %[2]s etcd-snapshot delete --etcd-s3 $(cat %[4]s) || exit 1
+fi
+`
+
+// retentionPoliciesEnabled returns true if snapshots of the control plane are pruned by Rancher.
+func retentionPoliciesEnabled(controlPlane *rkev1.RKEControlPlane) bool {
+	return controlPlane != nil && controlPlane.Spec.ETCD != nil &&
+		(controlPlane.Spec.ETCD.LocalSnapshotRetentionPolicy != nil || controlPlane.Spec.ETCD.S3SnapshotRetentionPolicy != nil)
+}
+
+// retentionPolicies returns the local and S3 retention policies of the control plane. If only one of them is set,
+// the other keeps the SnapshotRetention number of most recent snapshots like the etcd nodes would.
+func retentionPolicies(controlPlane *rkev1.RKEControlPlane) (local, s3 rkev1.ETCDSnapshotRetentionPolicy) {
+	countPolicy := rkev1.ETCDSnapshotRetentionPolicy{Last: controlPlane.Spec.ETCD.SnapshotRetention}
+	if countPolicy.Last <= 0 {
+		countPolicy.Last = defaultSnapshotRetention
+	}
+	local, s3 = countPolicy, countPolicy
+	if controlPlane.Spec.ETCD.LocalSnapshotRetentionPolicy != nil {
+		local = *controlPlane.Spec.ETCD.LocalSnapshotRetentionPolicy
+	}
+	if controlPlane.Spec.ETCD.S3SnapshotRetentionPolicy != nil {
+		s3 = *controlPlane.Spec.ETCD.S3SnapshotRetentionPolicy
+	}
+	return
+}
+
+// nodeSnapshotRetention returns the etcd-snapshot-retention of the etcd nodes once retention policies are set. The
+// nodes keep a multiple of the snapshots the policies can keep as a backstop, at least SnapshotRetention.
+func nodeSnapshotRetention(controlPlane *rkev1.RKEControlPlane) int {
+	local, s3 := retentionPolicies(controlPlane)
+	capacity := policyCapacity(local)
+	if s3Capacity := policyCapacity(s3); s3Capacity > capacity {
+		capacity = s3Capacity
+	}
+
+	retention := controlPlane.Spec.ETCD.SnapshotRetention
+	if retention <= 0 {
+		retention = defaultSnapshotRetention
+	}
+	if capacity*nodeSnapshotRetentionFactor > retention {
+		retention = capacity * nodeSnapshotRetentionFactor
+	}
+	return retention
+}
+
+// policyCapacity returns the maximum number of snapshots a retention policy keeps, not counting the max age.
+func policyCapacity(policy rkev1.ETCDSnapshotRetentionPolicy) int {
+	return policy.Last + policy.Hourly + policy.Daily + policy.Weekly + policy.Monthly + policy.Yearly
+}
+
+// addEtcdSnapshotPrune adds the files listing the snapshots to prune and the periodic instruction deleting them to the
+// plan of an etcd node. Local snapshots are pruned by every etcd node, S3 snapshots by the init node. The files are
+// minor so changes to them don't cause the node to be drained.
+func (p *Planner) addEtcdSnapshotPrune(nodePlan plan.NodePlan, controlPlane *rkev1.RKEControlPlane, entry *planEntry) (plan.NodePlan, error) {
+	runtime := rke2.GetRuntime(controlPlane.Spec.KubernetesVersion)
+	localFile := fmt.Sprintf("/var/lib/rancher/%s/server/db/rancher-snapshot-prune-local", runtime)
+	s3File := fmt.Sprintf("/var/lib/rancher/%s/server/db/rancher-snapshot-prune-s3", runtime)
+
+	snapshots, err := p.etcdSnapshotCache.List(controlPlane.Namespace, labels.SelectorFromSet(map[string]string{
+		rke2.ClusterNameLabel: controlPlane.Spec.ClusterName,
+	}))
+	if err != nil {
+		return nodePlan, err
+	}
+
+	localPolicy, s3Policy := retentionPolicies(controlPlane)
+	now := time.Now()
+
+	// local snapshots are pruned per node, like the etcd nodes would prune them.
+	localSnapshots := map[string][]*rkev1.ETCDSnapshot{}
+	var s3Snapshots []*rkev1.ETCDSnapshot
+	for _, snapshot := range snapshots {
+		if snapshot.SnapshotFile.S3 != nil || snapshot.SnapshotFile.NodeName == "s3" {
+			s3Snapshots = append(s3Snapshots, snapshot)
+		} else if key := localSnapshotKey(snapshot); key != "" {
+			localSnapshots[key] = append(localSnapshots[key], snapshot)
+		}
+	}
+
+	var localPrune, s3Prune []string
+	if key := entryLocalSnapshotKey(entry); key != "" {
+		for _, snapshot := range pruneEtcdSnapshots(localSnapshots[key], localPolicy, now) {
+			localPrune = append(localPrune, snapshot.SnapshotFile.Name)
+		}
+	}
+
+	if isInitNode(entry) && S3Enabled(controlPlane.Spec.ETCD.S3) {
+		// Deleting a snapshot from S3 also deletes the local copy of the same name, so snapshots still kept locally are
+		// kept in S3 as well.
+		keptLocally := map[string]bool{}
+		for _, nodeSnapshots := range localSnapshots {
+			pruned := map[*rkev1.ETCDSnapshot]bool{}
+			for _, snapshot := range pruneEtcdSnapshots(nodeSnapshots, localPolicy, now) {
+				pruned[snapshot] = true
+			}
+			for _, snapshot := range nodeSnapshots {
+				if !pruned[snapshot] {
+					keptLocally[snapshot.SnapshotFile.Name] = true
+				}
+			}
+		}
+		for _, snapshot := range pruneEtcdSnapshots(s3Snapshots, s3Policy, now) {
+			if !keptLocally[snapshot.SnapshotFile.Name] {
+				s3Prune = append(s3Prune, snapshot.SnapshotFile.Name)
+			}
+		}
+	}
+
+	sort.Strings(localPrune)
+	sort.Strings(s3Prune)
+	nodePlan.Files = append(nodePlan.Files,
+		plan.File{
+			Content: base64.StdEncoding.EncodeToString([]byte(strings.Join(localPrune, "\n"))),
+			Path:    localFile,
+			Dynamic: true,
+			Minor:   true,
+		},
+		plan.File{
+			Content: base64.StdEncoding.EncodeToString([]byte(strings.Join(s3Prune, "\n"))),
+			Path:    s3File,
+			Dynamic: true,
+			Minor:   true,
+		})
+	nodePlan.PeriodicInstructions = append(nodePlan.PeriodicInstructions, plan.PeriodicInstruction{
+		Name:    etcdSnapshotPruneInstructionName,
+		Command: "sh",
+		Args: []string{
+			"-c",
+			fmt.Sprintf(etcdSnapshotPruneScript, runtime, rke2.GetRuntimeCommand(controlPlane.Spec.KubernetesVersion), localFile, s3File),
+		},
+		PeriodSeconds: 600,
+	})
+	return nodePlan, nil
+}
+
+// localSnapshotKey returns the key local snapshots of the same node are grouped by: the machine ID of the node, or
+// the node name for snapshots without the machine ID label.
+func localSnapshotKey(snapshot *rkev1.ETCDSnapshot) string {
+	if machineID := snapshot.Labels[rke2.MachineIDLabel]; machineID != "" {
+		return machineID
+	}
+	if snapshot.SnapshotFile.NodeName != "" {
+		return "node/" + snapshot.SnapshotFile.NodeName
+	}
+	return ""
+}
+
+// entryLocalSnapshotKey returns the key of the local snapshots of the node of the entry. Snapshots of the node are
+// labeled with its machine ID once it is known, so the node name is only used until then.
+func entryLocalSnapshotKey(entry *planEntry) string {
+	if machineID := entry.Machine.Labels[rke2.MachineIDLabel]; machineID != "" {
+		return machineID
+	}
+	if entry.Machine.Status.NodeRef != nil {
+		return "node/" + entry.Machine.Status.NodeRef.Name
+	}
+	return ""
+}
+
+// snapshotTime returns when the snapshot was taken, or when its object was created if the snapshot file doesn't
+// record it.
+func snapshotTime(snapshot *rkev1.ETCDSnapshot) time.Time {
+	if snapshot.SnapshotFile.CreatedAt != nil {
+		return snapshot.SnapshotFile.CreatedAt.Time
+	}
+	return snapshot.CreationTimestamp.Time
+}
+
+// pruneEtcdSnapshots returns the snapshots not kept by the retention policy.
+func pruneEtcdSnapshots(snapshots []*rkev1.ETCDSnapshot, policy rkev1.ETCDSnapshotRetentionPolicy, now time.Time) []*rkev1.ETCDSnapshot {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	dated := append([]*rkev1.ETCDSnapshot{}, snapshots...)
+	sort.SliceStable(dated, func(i, j int) bool {
+		return snapshotTime(dated[i]).After(snapshotTime(dated[j]))
+	})
+
+	keep := make([]bool, len(dated))
+	for i := 0; i < policy.Last && i < len(dated); i++ {
+		keep[i] = true
+	}
+	tiers := []struct {
+		count  int
+		bucket func(time.Time) string
+	}{
+		{policy.Hourly, func(t time.Time) string { return t.Format("2006-01-02 15") }},
+		{policy.Daily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{policy.Weekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-%d", year, week)
+		}},
+		{policy.Monthly, func(t time.Time) string { return t.Format("2006-01") }},
+		{policy.Yearly, func(t time.Time) string { return t.Format("2006") }},
+	}
+	for _, tier := range tiers {
+		seen := map[string]bool{}
+		for i, snapshot := range dated {
+			if len(seen) >= tier.count {
+				break
+			}
+			bucket := tier.bucket(snapshotTime(snapshot).UTC())
+			if !seen[bucket] {
+				seen[bucket] = true
+				keep[i] = true
+			}
+		}
+	}
+	// the most recent snapshot is always kept, even if it is older than the max age or the policy keeps nothing.
+	keep[0] = true
+	if policy.MaxAgeDays > 0 {
+		maxAge := time.Duration(policy.MaxAgeDays) * 24 * time.Hour
+		for i := 1; i < len(dated); i++ {
+			if now.Sub(snapshotTime(dated[i])) > maxAge {
+				keep[i] = false
+			}
+		}
+	}
+
+	var prune []*rkev1.ETCDSnapshot
+	for i, snapshot := range dated {
+		if !keep[i] {
+			prune = append(prune, snapshot)
+		}
+	}
+	return prune
+}
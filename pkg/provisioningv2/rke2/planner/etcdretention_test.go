@@ -0,0 +1,156 @@
+package planner
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestPruneEtcdSnapshots(t *testing.T) {
+	now := time.Date(2023, 4, 12, 12, 30, 0, 0, time.UTC)
+	// one snapshot every 6 hours for the last 60 days, newest first
+	var snapshots []*rkev1.ETCDSnapshot
+	for i := 0; i < 60*4; i++ {
+		createdAt := metav1.NewTime(now.Add(-time.Duration(i) * 6 * time.Hour))
+		snapshots = append(snapshots, &rkev1.ETCDSnapshot{
+			SnapshotFile: rkev1.ETCDSnapshotFile{
+				Name:      createdAt.Format(time.RFC3339),
+				CreatedAt: &createdAt,
+			},
+		})
+	}
+	// snapshots without a creation time are pruned by the creation time of their object
+	undated := &rkev1.ETCDSnapshot{
+		ObjectMeta:   metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-1000 * time.Hour))},
+		SnapshotFile: rkev1.ETCDSnapshotFile{Name: "undated"},
+	}
+
+	kept := func(policy rkev1.ETCDSnapshotRetentionPolicy) []string {
+		pruned := map[string]bool{}
+		for _, snapshot := range pruneEtcdSnapshots(append([]*rkev1.ETCDSnapshot{undated}, snapshots...), policy, now) {
+			pruned[snapshot.SnapshotFile.Name] = true
+		}
+		assert.True(t, pruned["undated"])
+		var result []string
+		for _, snapshot := range snapshots {
+			if !pruned[snapshot.SnapshotFile.Name] {
+				result = append(result, snapshot.SnapshotFile.Name)
+			}
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(result)))
+		return result
+	}
+
+	assert.Equal(t, []string{
+		"2023-04-12T12:30:00Z",
+		"2023-04-12T06:30:00Z",
+		"2023-04-12T00:30:00Z",
+	}, kept(rkev1.ETCDSnapshotRetentionPolicy{Last: 3}))
+
+	assert.Equal(t, []string{
+		"2023-04-12T12:30:00Z",
+		"2023-04-12T06:30:00Z",
+		"2023-04-11T18:30:00Z", // daily
+		"2023-04-10T18:30:00Z", // daily
+		"2023-04-09T18:30:00Z", // weekly, week 14
+		"2023-03-31T18:30:00Z", // monthly, march
+		"2023-02-28T18:30:00Z", // monthly, february
+	}, kept(rkev1.ETCDSnapshotRetentionPolicy{Last: 2, Daily: 3, Weekly: 2, Monthly: 3}))
+
+	assert.Equal(t, []string{
+		"2023-04-12T12:30:00Z",
+		"2023-04-11T18:30:00Z",
+	}, kept(rkev1.ETCDSnapshotRetentionPolicy{Daily: 30, MaxAgeDays: 1}))
+
+	// the most recent snapshot is kept even if the policy keeps nothing.
+	assert.Equal(t, []string{"2023-04-12T12:30:00Z"}, kept(rkev1.ETCDSnapshotRetentionPolicy{}))
+	assert.Empty(t, pruneEtcdSnapshots([]*rkev1.ETCDSnapshot{undated}, rkev1.ETCDSnapshotRetentionPolicy{}, now))
+}
+
+func TestRetentionPolicies(t *testing.T) {
+	controlPlane := createTestControlPlane("v1.25.7+rke2r1")
+	controlPlane.Spec.ETCD = &rkev1.ETCD{}
+	assert.False(t, retentionPoliciesEnabled(controlPlane))
+
+	controlPlane.Spec.ETCD.S3SnapshotRetentionPolicy = &rkev1.ETCDSnapshotRetentionPolicy{Daily: 7, Monthly: 12}
+	assert.True(t, retentionPoliciesEnabled(controlPlane))
+	local, s3 := retentionPolicies(controlPlane)
+	assert.Equal(t, rkev1.ETCDSnapshotRetentionPolicy{Last: defaultSnapshotRetention}, local)
+	assert.Equal(t, rkev1.ETCDSnapshotRetentionPolicy{Daily: 7, Monthly: 12}, s3)
+
+	controlPlane.Spec.ETCD.SnapshotRetention = 10
+	local, _ = retentionPolicies(controlPlane)
+	assert.Equal(t, rkev1.ETCDSnapshotRetentionPolicy{Last: 10}, local)
+}
+
+func TestNodeSnapshotRetention(t *testing.T) {
+	controlPlane := createTestControlPlane("v1.25.7+rke2r1")
+	controlPlane.Spec.ETCD = &rkev1.ETCD{S3SnapshotRetentionPolicy: &rkev1.ETCDSnapshotRetentionPolicy{Daily: 7, Monthly: 12}}
+	assert.Equal(t, 38, nodeSnapshotRetention(controlPlane))
+
+	controlPlane.Spec.ETCD.S3SnapshotRetentionPolicy = &rkev1.ETCDSnapshotRetentionPolicy{MaxAgeDays: 30}
+	controlPlane.Spec.ETCD.SnapshotRetention = 50
+	assert.Equal(t, 100, nodeSnapshotRetention(controlPlane), "the local policy keeps the last SnapshotRetention snapshots")
+
+	controlPlane.Spec.ETCD.LocalSnapshotRetentionPolicy = &rkev1.ETCDSnapshotRetentionPolicy{Last: 2}
+	assert.Equal(t, 50, nodeSnapshotRetention(controlPlane))
+}
+
+func TestLocalSnapshotKey(t *testing.T) {
+	labeled := &rkev1.ETCDSnapshot{
+		ObjectMeta:   metav1.ObjectMeta{Labels: map[string]string{rke2.MachineIDLabel: "machine-id"}},
+		SnapshotFile: rkev1.ETCDSnapshotFile{NodeName: "node1"},
+	}
+	unlabeled := &rkev1.ETCDSnapshot{SnapshotFile: rkev1.ETCDSnapshotFile{NodeName: "node1"}}
+	assert.Equal(t, "machine-id", localSnapshotKey(labeled))
+	assert.Equal(t, "node/node1", localSnapshotKey(unlabeled))
+	assert.Empty(t, localSnapshotKey(&rkev1.ETCDSnapshot{}))
+
+	entry := &planEntry{Machine: &capi.Machine{Status: capi.MachineStatus{NodeRef: &corev1.ObjectReference{Name: "node1"}}}}
+	assert.Equal(t, "node/node1", entryLocalSnapshotKey(entry))
+	entry.Machine.Labels = map[string]string{rke2.MachineIDLabel: "machine-id"}
+	assert.Equal(t, "machine-id", entryLocalSnapshotKey(entry))
+}
+
+type fakeETCDSnapshotCache struct {
+	rkecontrollers.ETCDSnapshotCache
+	snapshots []*rkev1.ETCDSnapshot
+}
+
+func (f *fakeETCDSnapshotCache) List(namespace string, selector labels.Selector) ([]*rkev1.ETCDSnapshot, error) {
+	return f.snapshots, nil
+}
+
+// Enabling retention policies adds the prune instruction to the etcd nodes, which must not drain and restart them.
+func TestEtcdSnapshotPruneIsMinorPlanChange(t *testing.T) {
+	now := metav1.Now()
+	p := &Planner{etcdSnapshotCache: &fakeETCDSnapshotCache{snapshots: []*rkev1.ETCDSnapshot{{
+		ObjectMeta:   metav1.ObjectMeta{Labels: map[string]string{rke2.MachineIDLabel: "machine-1"}},
+		SnapshotFile: rkev1.ETCDSnapshotFile{Name: "snapshot", NodeName: "node-1", CreatedAt: &now},
+	}}}}
+	controlPlane := createTestControlPlane("v1.25.7+rke2r1")
+	controlPlane.Spec.ETCD = &rkev1.ETCD{LocalSnapshotRetentionPolicy: &rkev1.ETCDSnapshotRetentionPolicy{Last: 3}}
+	entry := createTestPlanEntry("linux")
+	entry.Machine.Labels[rke2.MachineIDLabel] = "machine-1"
+	base := plan.NodePlan{
+		Files:        []plan.File{{Path: "/etc/rancher/rke2/config.yaml", Content: "a"}},
+		Instructions: []plan.OneTimeInstruction{{Name: "install"}},
+	}
+
+	prune, err := p.addEtcdSnapshotPrune(base, controlPlane, entry)
+	require.NoError(t, err)
+	require.Len(t, prune.PeriodicInstructions, 1)
+	assert.True(t, minorPlanChangeDetected(base, prune))
+	assert.True(t, minorPlanChangeDetected(prune, base))
+}
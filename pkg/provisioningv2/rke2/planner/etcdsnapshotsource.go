@@ -0,0 +1,49 @@
+package planner
+
+import (
+	"fmt"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"github.com/rancher/wrangler/pkg/name"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// etcdSnapshotSourceServerToken returns the server token of the cluster the etcd snapshot source of the control plane
+// was taken from. The bootstrap data in the snapshot is encrypted with the server token, so a cluster seeded from the
+// snapshot has to use the same token to be able to restore it. The agent token isn't shared, agents of the new cluster
+// can't join the source cluster. The creator of the cluster must be allowed to restore snapshots of the source cluster.
+// An empty token is returned if no source is set.
+func (p *Planner) etcdSnapshotSourceServerToken(controlPlane *rkev1.RKEControlPlane) (string, error) {
+	if controlPlane.Spec.ETCDSnapshotSource == nil || controlPlane.Spec.ETCDSnapshotSource.Name == "" {
+		return "", nil
+	}
+
+	snapshot, err := p.etcdSnapshotCache.Get(controlPlane.Namespace, controlPlane.Spec.ETCDSnapshotSource.Name)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving etcd snapshot source %s/%s: %w", controlPlane.Namespace, controlPlane.Spec.ETCDSnapshotSource.Name, err)
+	}
+	if snapshot.Spec.ClusterName == "" {
+		return "", fmt.Errorf("etcd snapshot source %s/%s does not reference a cluster", snapshot.Namespace, snapshot.Name)
+	}
+
+	// the cache may lag behind or hold a copy modified by another handler, the creator annotation is read from the
+	// cluster itself
+	cluster, err := p.rancherClusters.Get(controlPlane.Namespace, controlPlane.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error retrieving cluster %s/%s: %w", controlPlane.Namespace, controlPlane.Name, err)
+	}
+	if err := rke2.AuthorizeETCDSnapshotSource(p.ctx, p.subjectAccessReviews, cluster, snapshot.Spec.ClusterName); err != nil {
+		return "", err
+	}
+
+	stateSecretName := name.SafeConcatName(snapshot.Spec.ClusterName, "rke", "state")
+	secret, err := p.secretCache.Get(controlPlane.Namespace, stateSecretName)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving state secret %s/%s of the cluster of etcd snapshot source %s: %w", controlPlane.Namespace, stateSecretName, snapshot.Name, err)
+	}
+	if len(secret.Data["serverToken"]) == 0 {
+		return "", fmt.Errorf("state secret %s/%s of the cluster of etcd snapshot source %s has no server token", secret.Namespace, secret.Name, snapshot.Name)
+	}
+	return string(secret.Data["serverToken"]), nil
+}
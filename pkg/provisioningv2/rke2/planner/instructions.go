@@ -7,13 +7,63 @@
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"github.com/rancher/rancher/pkg/provisioningv2/image"
+	"github.com/rancher/rancher/pkg/settings"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
 	captureAddressInstructionName = "capture-address"
 	etcdNameInstructionName       = "etcd-name"
+
+	// ETCDSnapshotVerifyInstructionName is the periodic instruction restoring the latest local snapshot into a scratch
+	// etcd instance. Its output is turned into the Verified condition of the snapshot by the plansecret controller.
+	ETCDSnapshotVerifyInstructionName = "etcd-snapshot-verify"
+	defaultETCDSnapshotVerifyPeriod   = 24 * 60 * 60
+	etcdSnapshotVerifyClientPort      = 12379
+	etcdSnapshotVerifyPeerPort        = 12380
 )
 
+// etcdSnapshotVerifyScript restores the latest local snapshot into a scratch etcd listening on localhost only and
+// prints the snapshot name, the total key count, the key count under /registry and whether the kube-system namespace
+// exists as key=value pairs. The snapshot hash is checked as part of the restore.
+const etcdSnapshotVerifyScript = `set -e
+snapshot=$(ls -t /var/lib/rancher/%[1]s/server/db/snapshots/* 2>/dev/null | head -n 1)
+if [ -z "$snapshot" ]; then
+  exit 0
+fi
+echo "snapshot=$(basename "$snapshot")"
+export PATH="$PWD/usr/local/bin:$PATH"
+scratch=$(mktemp -d)
+pid=""
+trap 'if [ -n "$pid" ]; then kill $pid; fi; rm -rf "$scratch"' EXIT
+case "$snapshot" in
+*.zip)
+  unzip -p "$snapshot" > "$scratch/snapshot.db"
+  snapshot="$scratch/snapshot.db"
+  ;;
+esac
+peer=http://127.0.0.1:%[3]d
+client=http://127.0.0.1:%[2]d
+etcdctl snapshot restore "$snapshot" --name verify --data-dir "$scratch/data" --initial-cluster "verify=$peer" --initial-advertise-peer-urls "$peer" >&2
+etcd --name verify --data-dir "$scratch/data" --listen-peer-urls "$peer" --listen-client-urls "$client" --advertise-client-urls "$client" > "$scratch/etcd.log" 2>&1 &
+pid=$!
+for i in $(seq 1 30); do
+  if etcdctl --endpoints "$client" endpoint health > /dev/null 2>&1; then
+    break
+  fi
+  sleep 1
+done
+if ! etcdctl --endpoints "$client" endpoint health >&2; then
+  tail -n 20 "$scratch/etcd.log" >&2
+  exit 1
+fi
+echo "keys=$(etcdctl --endpoints "$client" get "" --prefix --count-only -w fields | awk '/"Count"/ {print $3}')"
+echo "registryKeys=$(etcdctl --endpoints "$client" get /registry/ --prefix --count-only -w fields | awk '/"Count"/ {print $3}')"
+echo "kubeSystemNamespace=$(etcdctl --endpoints "$client" get /registry/namespaces/kube-system --count-only -w fields | awk '/"Count"/ {print ($3 > 0) ? "true" : "false"}')"
+`
+
 // generateInstallInstruction generates the instruction necessary to install the desired tool.
 func generateInstallInstruction(controlPlane *rkev1.RKEControlPlane, entry *planEntry, env []string) plan.OneTimeInstruction {
 	var instruction plan.OneTimeInstruction
@@ -151,3 +201,44 @@ func (p *Planner) addEtcdSnapshotListS3PeriodicInstruction(nodePlan plan.NodePla
 	})
 	return nodePlan, nil
 }
+
+func (p *Planner) addEtcdSnapshotVerifyPeriodicInstruction(nodePlan plan.NodePlan, controlPlane *rkev1.RKEControlPlane) (plan.NodePlan, error) {
+	verification := controlPlane.Spec.ETCD.SnapshotVerification
+	period := verification.IntervalSeconds
+	if period <= 0 {
+		period = defaultETCDSnapshotVerifyPeriod
+	}
+	verifyImage := verification.Image
+	if verifyImage == "" {
+		verifyImage = settings.EtcdSnapshotVerificationImage.Get()
+	}
+	nodePlan.PeriodicInstructions = append(nodePlan.PeriodicInstructions, plan.PeriodicInstruction{
+		Name:    ETCDSnapshotVerifyInstructionName,
+		Image:   image.ResolveWithControlPlane(verifyImage, controlPlane),
+		Command: "sh",
+		Args: []string{
+			"-c",
+			fmt.Sprintf(etcdSnapshotVerifyScript, rke2.GetRuntime(controlPlane.Spec.KubernetesVersion), etcdSnapshotVerifyClientPort, etcdSnapshotVerifyPeerPort),
+		},
+		PeriodSeconds: period,
+	})
+	return nodePlan, nil
+}
+
+// verifiesEtcdSnapshots returns true if snapshot verification is enabled and the entry is an etcd node selected to
+// verify its snapshots. Without a machine label selector only the init node verifies its snapshots.
+func verifiesEtcdSnapshots(controlPlane *rkev1.RKEControlPlane, entry *planEntry) (bool, error) {
+	if controlPlane == nil || controlPlane.Spec.ETCD == nil || controlPlane.Spec.ETCD.SnapshotVerification == nil ||
+		!controlPlane.Spec.ETCD.SnapshotVerification.Enabled || !isEtcd(entry) {
+		return false, nil
+	}
+	selector := controlPlane.Spec.ETCD.SnapshotVerification.MachineLabelSelector
+	if selector == nil {
+		return isInitNode(entry), nil
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return entry.Machine != nil && sel.Matches(labels.Set(entry.Machine.Labels)), nil
+}
@@ -7,6 +7,7 @@
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestPlanner_generateInstallInstruction(t *testing.T) {
@@ -226,3 +227,101 @@ type args struct {
 		})
 	}
 }
+
+func TestPlanner_verifiesEtcdSnapshots(t *testing.T) {
+	newEntry := func(etcd, initNode bool, machineLabels map[string]string) *planEntry {
+		entry := createTestPlanEntry("linux")
+		entry.Machine.Labels = machineLabels
+		if etcd {
+			entry.Metadata.Labels[rke2.EtcdRoleLabel] = "true"
+		}
+		if initNode {
+			entry.Metadata.Labels[rke2.InitNodeLabel] = "true"
+		}
+		return entry
+	}
+	withVerification := func(verification *v1.ETCDSnapshotVerification) *v1.RKEControlPlane {
+		controlPlane := createTestControlPlane("v1.25.7+rke2r1")
+		controlPlane.Spec.ETCD = &v1.ETCD{SnapshotVerification: verification}
+		return controlPlane
+	}
+
+	tests := []struct {
+		name         string
+		controlPlane *v1.RKEControlPlane
+		entry        *planEntry
+		expected     bool
+	}{
+		{
+			name:         "not configured",
+			controlPlane: createTestControlPlane("v1.25.7+rke2r1"),
+			entry:        newEntry(true, true, nil),
+		},
+		{
+			name:         "disabled",
+			controlPlane: withVerification(&v1.ETCDSnapshotVerification{}),
+			entry:        newEntry(true, true, nil),
+		},
+		{
+			name:         "init node by default",
+			controlPlane: withVerification(&v1.ETCDSnapshotVerification{Enabled: true}),
+			entry:        newEntry(true, true, nil),
+			expected:     true,
+		},
+		{
+			name:         "other etcd node by default",
+			controlPlane: withVerification(&v1.ETCDSnapshotVerification{Enabled: true}),
+			entry:        newEntry(true, false, nil),
+		},
+		{
+			name: "selected etcd node",
+			controlPlane: withVerification(&v1.ETCDSnapshotVerification{
+				Enabled:              true,
+				MachineLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"verify": "true"}},
+			}),
+			entry:    newEntry(true, false, map[string]string{"verify": "true"}),
+			expected: true,
+		},
+		{
+			name: "selected worker node",
+			controlPlane: withVerification(&v1.ETCDSnapshotVerification{
+				Enabled:              true,
+				MachineLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"verify": "true"}},
+			}),
+			entry: newEntry(false, false, map[string]string{"verify": "true"}),
+		},
+		{
+			name: "init node not selected",
+			controlPlane: withVerification(&v1.ETCDSnapshotVerification{
+				Enabled:              true,
+				MachineLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"verify": "true"}},
+			}),
+			entry: newEntry(true, true, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verify, err := verifiesEtcdSnapshots(tt.controlPlane, tt.entry)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, verify)
+		})
+	}
+}
+
+func TestPlanner_addEtcdSnapshotVerifyPeriodicInstruction(t *testing.T) {
+	a := assert.New(t)
+	var planner Planner
+	controlPlane := createTestControlPlane("v1.25.7+k3s1")
+	controlPlane.Spec.ETCD = &v1.ETCD{SnapshotVerification: &v1.ETCDSnapshotVerification{Enabled: true, Image: "example.com/etcd:v3.5.7"}}
+
+	p, err := planner.addEtcdSnapshotVerifyPeriodicInstruction(plan.NodePlan{}, controlPlane)
+	a.NoError(err)
+	if a.Len(p.PeriodicInstructions, 1) {
+		instruction := p.PeriodicInstructions[0]
+		a.Equal(ETCDSnapshotVerifyInstructionName, instruction.Name)
+		a.Equal("example.com/etcd:v3.5.7", instruction.Image)
+		a.Equal(defaultETCDSnapshotVerifyPeriod, instruction.PeriodSeconds)
+		a.Contains(instruction.Args[1], "/var/lib/rancher/k3s/server/db/snapshots/")
+		a.NotContains(instruction.Args[1], "%!")
+	}
+}
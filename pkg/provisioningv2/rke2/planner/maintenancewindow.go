@@ -0,0 +1,77 @@
+package planner
+
+import (
+	"fmt"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"github.com/robfig/cron"
+)
+
+// maintenanceWindow tracks whether changes that restart or drain nodes may be applied during a run of the planner, and
+// the machines whose changes were held back because they may not.
+type maintenanceWindow struct {
+	open     bool
+	heldBack []string
+}
+
+// newMaintenanceWindow returns the maintenance window state of the control plane at the given time. The window is
+// always open if the control plane has no maintenance windows.
+func newMaintenanceWindow(controlPlane *rkev1.RKEControlPlane, now time.Time) (*maintenanceWindow, error) {
+	open, err := maintenanceWindowOpen(controlPlane.Spec.MaintenanceWindows, now)
+	if err != nil {
+		return nil, err
+	}
+	return &maintenanceWindow{open: open}, nil
+}
+
+// holdBack records that the changes of the machine are held back until a window opens. The message keeps the Reconciled
+// condition of the machine from becoming true while its plan isn't applied.
+func (w *maintenanceWindow) holdBack(machineName string, messages map[string][]string) {
+	w.heldBack = append(w.heldBack, machineName)
+	messages[machineName] = append(messages[machineName], "waiting for maintenance window")
+}
+
+// maintenanceWindowOpen returns true if no windows are set or one of the windows is open at the given time.
+func maintenanceWindowOpen(windows []rkev1.MaintenanceWindow, now time.Time) (bool, error) {
+	if len(windows) == 0 {
+		return true, nil
+	}
+	for _, window := range windows {
+		schedule, err := cron.ParseStandard(window.Schedule)
+		if err != nil {
+			return false, fmt.Errorf("invalid maintenance window schedule %q: %w", window.Schedule, err)
+		}
+		duration, err := time.ParseDuration(window.Duration)
+		if err != nil {
+			return false, fmt.Errorf("invalid maintenance window duration %q: %w", window.Duration, err)
+		}
+		if duration <= 0 {
+			return false, fmt.Errorf("invalid maintenance window duration %q: must be positive", window.Duration)
+		}
+		location := time.UTC
+		if window.TimeZone != "" {
+			if location, err = time.LoadLocation(window.TimeZone); err != nil {
+				return false, fmt.Errorf("invalid maintenance window time zone %q: %w", window.TimeZone, err)
+			}
+		}
+		// the window is open if it started within the last duration.
+		if start := schedule.Next(now.In(location).Add(-duration)); !start.After(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// setMaintenanceWindowCondition sets the WaitingForMaintenanceWindow condition to true with the given message, or to
+// false if the message is empty and the condition was set before.
+func setMaintenanceWindowCondition(status *rkev1.RKEControlPlaneStatus, message string) {
+	if message != "" {
+		rke2.WaitingForMaintenanceWindow.True(status)
+		rke2.WaitingForMaintenanceWindow.Message(status, message)
+	} else if rke2.WaitingForMaintenanceWindow.GetStatus(status) != "" {
+		rke2.WaitingForMaintenanceWindow.False(status)
+		rke2.WaitingForMaintenanceWindow.Message(status, "")
+	}
+}
@@ -0,0 +1,84 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+type fakeMachineClient struct {
+	capicontrollers.MachineClient
+	updated []*capi.Machine
+}
+
+func (f *fakeMachineClient) UpdateStatus(machine *capi.Machine) (*capi.Machine, error) {
+	f.updated = append(f.updated, machine)
+	return machine, nil
+}
+
+func TestMaintenanceWindowOpen(t *testing.T) {
+	// Saturday 2023-04-15
+	saturday := time.Date(2023, 4, 15, 0, 0, 0, 0, time.UTC)
+	windows := []rkev1.MaintenanceWindow{{Schedule: "0 2 * * 6", Duration: "4h"}}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		open bool
+	}{
+		{name: "before window", now: saturday.Add(time.Hour + 59*time.Minute), open: false},
+		{name: "window start", now: saturday.Add(2 * time.Hour), open: true},
+		{name: "within window", now: saturday.Add(5*time.Hour + 59*time.Minute), open: true},
+		{name: "window end", now: saturday.Add(6 * time.Hour), open: false},
+		{name: "other day", now: saturday.Add(24*time.Hour + 3*time.Hour), open: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, err := maintenanceWindowOpen(windows, tt.now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.open, open)
+		})
+	}
+
+	// 2am in Berlin is midnight UTC during daylight saving time.
+	open, err := maintenanceWindowOpen([]rkev1.MaintenanceWindow{{Schedule: "0 2 * * *", Duration: "1h", TimeZone: "Europe/Berlin"}}, saturday.Add(30*time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, open)
+
+	open, err = maintenanceWindowOpen(nil, saturday)
+	assert.NoError(t, err)
+	assert.True(t, open)
+
+	_, err = maintenanceWindowOpen([]rkev1.MaintenanceWindow{{Schedule: "0 2 * * 6", Duration: "forever"}}, saturday)
+	assert.Error(t, err)
+	_, err = maintenanceWindowOpen([]rkev1.MaintenanceWindow{{Schedule: "0 2 * * 6", Duration: "1h", TimeZone: "Nowhere/Special"}}, saturday)
+	assert.Error(t, err)
+}
+
+func TestHeldBackMachineIsNotReconciled(t *testing.T) {
+	machine := &capi.Machine{ObjectMeta: metav1.ObjectMeta{Name: "m1", Namespace: "fleet-default"}}
+	conditions.MarkTrue(machine, capi.InfrastructureReadyCondition)
+	clusterPlan := &plan.Plan{Machines: map[string]*capi.Machine{"m1": machine}}
+	machines := &fakeMachineClient{}
+	p := &Planner{machines: machines}
+
+	window := &maintenanceWindow{}
+	messages := map[string][]string{}
+	window.holdBack("m1", messages)
+	assert.Equal(t, []string{"m1"}, window.heldBack)
+
+	err := p.setMachineConditionStatus(clusterPlan, window.heldBack, "waiting for maintenance window to configure worker node(s) ", messages)
+	assert.True(t, IsErrWaiting(err))
+	require.Len(t, machines.updated, 1)
+	assert.False(t, rke2.Reconciled.IsTrue(machines.updated[0]))
+	assert.Equal(t, "waiting for maintenance window", rke2.Reconciled.GetMessage(machines.updated[0]))
+}
@@ -11,6 +11,7 @@
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/moby/locker"
@@ -36,6 +37,7 @@
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	capiannotations "sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -134,6 +136,8 @@ type Planner struct {
 	capiClusters                  capicontrollers.ClusterCache
 	managementClusters            mgmtcontrollers.ClusterCache
 	rancherClusterCache           ranchercontrollers.ClusterCache
+	rancherClusters               ranchercontrollers.ClusterClient
+	subjectAccessReviews          authorizationv1.SubjectAccessReviewInterface
 	locker                        locker.Locker
 	etcdS3Args                    s3Args
 }
@@ -156,6 +160,8 @@ func New(ctx context.Context, clients *wrangler.Context) *Planner {
 		capiClusters:                  clients.CAPI.Cluster().Cache(),
 		managementClusters:            clients.Mgmt.Cluster().Cache(),
 		rancherClusterCache:           clients.Provisioning.Cluster().Cache(),
+		rancherClusters:               clients.Provisioning.Cluster(),
+		subjectAccessReviews:          clients.K8s.AuthorizationV1().SubjectAccessReviews(),
 		rkeControlPlanes:              clients.RKE.RKEControlPlane(),
 		etcdSnapshotCache:             clients.RKE.ETCDSnapshot().Cache(),
 		etcdS3Args: s3Args{
@@ -259,6 +265,14 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 		return status, err
 	}
 
+	if isDryRun(cp) {
+		if status.PlanPreview, err = p.previewPlans(cp, clusterSecretTokens, plan); err != nil {
+			return status, err
+		}
+		return status, ErrWaiting("dry run enabled, plan changes are not applied")
+	}
+	status.PlanPreview = nil
+
 	var (
 		firstIgnoreError error
 		joinServer       string
@@ -272,10 +286,27 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 		return status, err
 	}
 
+	window, err := newMaintenanceWindow(cp, time.Now())
+	if err != nil {
+		return status, err
+	}
+
+	status = reconcileCertificateExpiration(cp, status, plan)
+
+	if shouldRotate(cp) && !window.open && !capiannotations.IsPaused(capiCluster, cp) {
+		setMaintenanceWindowCondition(&status, "waiting for maintenance window to rotate certificates")
+		return status, ErrWaiting("waiting for maintenance window to rotate certificates")
+	}
+
 	if status, err = p.rotateCertificates(cp, status, plan); err != nil {
 		return status, err
 	}
 
+	if canRotateEncryptionKeys(cp) && shouldRestartEncryptionKeyRotation(cp) && !window.open {
+		setMaintenanceWindowCondition(&status, "waiting for maintenance window to rotate encryption keys")
+		return status, ErrWaiting("waiting for maintenance window to rotate encryption keys")
+	}
+
 	if status, err = p.rotateEncryptionKeys(cp, status, clusterSecretTokens, plan, releaseData); err != nil {
 		return status, err
 	}
@@ -286,6 +317,17 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 		return status, ErrWaitingf("CAPI cluster or RKEControlPlane is paused")
 	}
 
+	if cp.Spec.StrictConfigValidation {
+		results := rke2.ValidateRKEConfig(releaseData, cp.Spec.KubernetesVersion, cp.Spec.RKEClusterSpecCommon, cp.Spec.LocalClusterAuthEndpoint)
+		if errs := rke2.ConfigValidationErrors(results); len(errs) > 0 {
+			return status, ErrWaitingf("waiting for config validation errors to be fixed: %s", strings.Join(errs, "; "))
+		}
+	}
+
+	if status, err = p.reconcileUpgradeHealth(cp, status, clusterSecretTokens, plan, time.Now()); err != nil {
+		return status, err
+	}
+
 	// on the first run through, electInitNode will return a `generic.ErrSkip` as it is attempting to wait for the cache to catch up.
 	joinServer, err = p.electInitNode(cp, plan)
 	if err != nil {
@@ -295,7 +337,7 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 	// select all etcd and then filter to just initNodes so that unavailable count is correct
 	err = p.reconcile(cp, clusterSecretTokens, plan, true, bootstrapTier, isEtcd, isNotInitNodeOrIsDeleting,
 		"1", "",
-		cp.Spec.UpgradeStrategy.ControlPlaneDrainOptions)
+		cp.Spec.UpgradeStrategy.ControlPlaneDrainOptions, window)
 	firstIgnoreError, err = ignoreErrors(firstIgnoreError, err)
 	if err != nil {
 		return status, err
@@ -314,7 +356,7 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 
 	err = p.reconcile(cp, clusterSecretTokens, plan, true, etcdTier, isEtcd, isInitNodeOrDeleting,
 		"1", joinServer,
-		cp.Spec.UpgradeStrategy.ControlPlaneDrainOptions)
+		cp.Spec.UpgradeStrategy.ControlPlaneDrainOptions, window)
 	firstIgnoreError, err = ignoreErrors(firstIgnoreError, err)
 	if err != nil {
 		return status, err
@@ -322,7 +364,7 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 
 	err = p.reconcile(cp, clusterSecretTokens, plan, true, controlPlaneTier, isControlPlane, isInitNodeOrDeleting,
 		cp.Spec.UpgradeStrategy.ControlPlaneConcurrency, joinServer,
-		cp.Spec.UpgradeStrategy.ControlPlaneDrainOptions)
+		cp.Spec.UpgradeStrategy.ControlPlaneDrainOptions, window)
 	firstIgnoreError, err = ignoreErrors(firstIgnoreError, err)
 	if err != nil {
 		return status, err
@@ -339,14 +381,25 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 		return status, ErrWaiting("marking control plane as initialized and ready")
 	}
 
-	err = p.reconcile(cp, clusterSecretTokens, plan, false, workerTier, isOnlyWorker, isInitNodeOrDeleting,
-		cp.Spec.UpgradeStrategy.WorkerConcurrency, joinServer,
-		cp.Spec.UpgradeStrategy.WorkerDrainOptions)
+	if len(cp.Spec.UpgradeStrategy.WorkerRolloutStages) > 0 {
+		status, err = p.reconcileWorkerStages(cp, status, clusterSecretTokens, plan, joinServer, window, time.Now())
+	} else {
+		status.WorkerRollout = nil
+		err = p.reconcile(cp, clusterSecretTokens, plan, false, workerTier, isOnlyWorker, isInitNodeOrDeleting,
+			cp.Spec.UpgradeStrategy.WorkerConcurrency, joinServer,
+			cp.Spec.UpgradeStrategy.WorkerDrainOptions, window)
+	}
 	firstIgnoreError, err = ignoreErrors(firstIgnoreError, err)
 	if err != nil {
 		return status, err
 	}
 
+	if len(window.heldBack) > 0 {
+		setMaintenanceWindowCondition(&status, "waiting for maintenance window to configure machine(s) "+atMostThree(window.heldBack))
+	} else {
+		setMaintenanceWindowCondition(&status, "")
+	}
+
 	if firstIgnoreError != nil {
 		return status, ErrWaiting(firstIgnoreError.Error())
 	}
@@ -470,17 +523,21 @@ func calculateConcurrency(maxUnavailable string, entries []*planEntry, exclude r
 	return int(math.Ceil(max)), unavailable, nil
 }
 
+// minorPlanChangeDetected returns true if the plans only differ in minor files or periodic instructions. Periodic
+// instructions, e.g. the certificate expiration check or the etcd snapshot verification, only gather information and
+// neither restart nor require draining the node, so adding, changing or removing them is minor.
 func minorPlanChangeDetected(old, new plan.NodePlan) bool {
 	if !equality.Semantic.DeepEqual(old.Instructions, new.Instructions) ||
-		!equality.Semantic.DeepEqual(old.PeriodicInstructions, new.PeriodicInstructions) ||
 		!equality.Semantic.DeepEqual(old.Probes, new.Probes) ||
 		old.Error != new.Error {
 		return false
 	}
 
+	periodicChanged := !equality.Semantic.DeepEqual(old.PeriodicInstructions, new.PeriodicInstructions)
+
 	if len(old.Files) == 0 && len(new.Files) == 0 {
-		// if the old plan had no files and no new files were found, there was no plan change detected
-		return false
+		// if the old plan had no files and no new files were found, only a change of the periodic instructions is left
+		return periodicChanged
 	}
 
 	newFiles := make(map[string]plan.File)
@@ -514,7 +571,7 @@ func minorPlanChangeDetected(old, new plan.NodePlan) bool {
 		// There were new files and all were not major
 		return true
 	}
-	return false
+	return periodicChanged
 }
 
 func kubeletVersionUpToDate(controlPlane *rkev1.RKEControlPlane, machine *capi.Machine) bool {
@@ -722,10 +779,10 @@ func getTaints(entry *planEntry, cp *rkev1.RKEControlPlane) (result []corev1.Tai
 }
 
 func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret plan.Secret, clusterPlan *plan.Plan, required bool,
-	tierName string, include, exclude roleFilter, maxUnavailable string, joinServer string, drainOptions rkev1.DrainOptions) error {
+	tierName string, include, exclude roleFilter, maxUnavailable string, joinServer string, drainOptions rkev1.DrainOptions, window *maintenanceWindow) error {
 	var (
-		ready, outOfSync, reconciling, nonReady, errMachines, draining, uncordoned []string
-		messages                                                                   = map[string][]string{}
+		ready, outOfSync, reconciling, nonReady, errMachines, draining, uncordoned, heldBack []string
+		messages                                                                             = map[string][]string{}
 	)
 
 	entries := collect(clusterPlan, include)
@@ -777,6 +834,11 @@ func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret pl
 			if err := p.store.UpdatePlan(entry, plan, -1, 1); err != nil {
 				return err
 			}
+		} else if !equality.Semantic.DeepEqual(entry.Plan.Plan, plan) && !window.open && entry.Plan.AppliedPlan != nil && !isInDrain(entry) {
+			// Major plan changes restart or drain the node, so they are held back until a maintenance window opens.
+			logrus.Debugf("[planner] rkecluster %s/%s reconcile tier %s - plan for machine %s/%s did not match, waiting for maintenance window", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
+			heldBack = append(heldBack, entry.Machine.Name)
+			window.holdBack(entry.Machine.Name, messages)
 		} else if !equality.Semantic.DeepEqual(entry.Plan.Plan, plan) {
 			logrus.Debugf("[planner] rkecluster %s/%s reconcile tier %s - plan for machine %s/%s did not match, appending to outOfSync", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
 			outOfSync = append(outOfSync, entry.Machine.Name)
@@ -867,6 +929,10 @@ func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret pl
 		firstError = err
 	}
 
+	if err := p.setMachineConditionStatus(clusterPlan, heldBack, fmt.Sprintf("waiting for maintenance window to configure %s node(s) ", tierName), messages); err != nil && firstError == nil {
+		firstError = err
+	}
+
 	// Ensure that the conditions that we control are updated.
 	if err := p.setMachineConditionStatus(clusterPlan, ready, "", nil); err != nil && firstError == nil {
 		firstError = err
@@ -886,6 +952,10 @@ func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret pl
 		return errIgnore("non-ready " + tierName + " machine(s) " + atMostThree(nonReady) + detailedMessage(nonReady, messages))
 	}
 
+	if len(heldBack) > 0 {
+		return errIgnore("waiting for maintenance window to configure " + tierName + " machine(s) " + atMostThree(heldBack))
+	}
+
 	return nil
 }
 
@@ -974,6 +1044,31 @@ func (p *Planner) desiredPlan(controlPlane *rkev1.RKEControlPlane, tokensSecret
 			}
 		}
 	}
+
+	if isEtcd(entry) && retentionPoliciesEnabled(controlPlane) {
+		nodePlan, err = p.addEtcdSnapshotPrune(nodePlan, controlPlane, entry)
+		if err != nil {
+			return nodePlan, err
+		}
+	}
+
+	verify, err := verifiesEtcdSnapshots(controlPlane, entry)
+	if err != nil {
+		return nodePlan, err
+	}
+	if verify {
+		nodePlan, err = p.addEtcdSnapshotVerifyPeriodicInstruction(nodePlan, controlPlane)
+		if err != nil {
+			return nodePlan, err
+		}
+	}
+
+	if automaticCertificateRotationEnabled(controlPlane) && entry.Metadata.Labels[rke2.CattleOSLabel] != windows {
+		nodePlan, err = p.addCertificateExpirationPeriodicInstruction(nodePlan, controlPlane)
+		if err != nil {
+			return nodePlan, err
+		}
+	}
 	return nodePlan, nil
 }
 
@@ -1107,11 +1202,18 @@ func (p *Planner) ensureRKEStateSecret(controlPlane *rkev1.RKEControlPlane) (str
 	name := name.SafeConcatName(controlPlane.Name, "rke", "state")
 	secret, err := p.secretCache.Get(controlPlane.Namespace, name)
 	if apierror.IsNotFound(err) {
-		serverToken, err := randomtoken.Generate()
+		serverToken, err := p.etcdSnapshotSourceServerToken(controlPlane)
 		if err != nil {
 			return "", plan.Secret{}, err
 		}
 
+		if serverToken == "" {
+			serverToken, err = randomtoken.Generate()
+			if err != nil {
+				return "", plan.Secret{}, err
+			}
+		}
+
 		agentToken, err := randomtoken.Generate()
 		if err != nil {
 			return "", plan.Secret{}, err
@@ -10,6 +10,7 @@
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -245,3 +246,67 @@ type args struct {
 		})
 	}
 }
+
+func Test_minorPlanChangeDetected(t *testing.T) {
+	base := plan.NodePlan{
+		Files:        []plan.File{{Path: "/etc/rancher/rke2/config.yaml", Content: "a"}},
+		Instructions: []plan.OneTimeInstruction{{Name: "install"}},
+	}
+	withPeriodic := func(p plan.NodePlan, name string) plan.NodePlan {
+		p.PeriodicInstructions = append([]plan.PeriodicInstruction{}, p.PeriodicInstructions...)
+		p.PeriodicInstructions = append(p.PeriodicInstructions, plan.PeriodicInstruction{Name: name})
+		return p
+	}
+	withFile := func(p plan.NodePlan, file plan.File) plan.NodePlan {
+		p.Files = append(append([]plan.File{}, p.Files...), file)
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		old, new plan.NodePlan
+		want     bool
+	}{
+		{name: "unchanged", old: base, new: base, want: false},
+		{name: "added periodic instruction", old: base, new: withPeriodic(base, "check-certificate-expiration"), want: true},
+		{name: "removed periodic instruction", old: withPeriodic(base, "check-certificate-expiration"), new: base, want: true},
+		{name: "periodic instruction without files", old: plan.NodePlan{}, new: withPeriodic(plan.NodePlan{}, "etcd-snapshot-verify"), want: true},
+		{name: "added minor file", old: base, new: withFile(base, plan.File{Path: "/minor", Minor: true}), want: true},
+		{name: "added major file", old: base, new: withFile(base, plan.File{Path: "/major"}), want: false},
+		{
+			name: "periodic instruction and major file",
+			old:  base,
+			new:  withFile(withPeriodic(base, "check-certificate-expiration"), plan.File{Path: "/major"}),
+			want: false,
+		},
+		{
+			name: "changed instruction",
+			old:  base,
+			new:  plan.NodePlan{Files: base.Files, Instructions: []plan.OneTimeInstruction{{Name: "upgrade"}}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, minorPlanChangeDetected(tt.old, tt.new))
+		})
+	}
+}
+
+// Enabling the periodic etcd snapshot instructions must not drain and restart the etcd nodes.
+func Test_minorPlanChangeDetectedForEtcdSnapshotInstructions(t *testing.T) {
+	p := &Planner{}
+	controlPlane := createTestControlPlane("v1.25.7+rke2r1")
+	controlPlane.Spec.ETCD = &rkev1.ETCD{
+		SnapshotVerification: &rkev1.ETCDSnapshotVerification{Enabled: true},
+	}
+	base := plan.NodePlan{
+		Files:        []plan.File{{Path: "/etc/rancher/rke2/config.yaml", Content: "a"}},
+		Instructions: []plan.OneTimeInstruction{{Name: "install"}},
+	}
+
+	verify, err := p.addEtcdSnapshotVerifyPeriodicInstruction(base, controlPlane)
+	require.NoError(t, err)
+	assert.True(t, minorPlanChangeDetected(base, verify))
+	assert.True(t, minorPlanChangeDetected(verify, base))
+}
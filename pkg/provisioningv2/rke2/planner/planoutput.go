@@ -0,0 +1,210 @@
+package planner
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// maxPlanOutputHistory is the number of applied plans whose output is kept in the plan output history of a machine.
+	maxPlanOutputHistory = 10
+	// maxInstructionOutputSize is the number of bytes of the end of each output kept in the plan output history.
+	maxInstructionOutputSize = 32 * 1024
+	// maxPlanOutputHistorySize is the maximum compressed size of the plan output history. The oldest entries are
+	// dropped to keep the history secret well below the size limit of secrets.
+	maxPlanOutputHistorySize = 512 * 1024
+	planOutputHistoryKey     = "history"
+)
+
+// PlanOutput is the output of the instructions of a plan applied by rancher-system-agent.
+type PlanOutput struct {
+	AppliedChecksum      string                      `json:"appliedChecksum"`
+	RecordedAt           metav1.Time                 `json:"recordedAt"`
+	Instructions         []InstructionOutput         `json:"instructions,omitempty"`
+	PeriodicInstructions []PeriodicInstructionOutput `json:"periodicInstructions,omitempty"`
+}
+
+type InstructionOutput struct {
+	Name   string `json:"name"`
+	Output string `json:"output"`
+}
+
+type PeriodicInstructionOutput struct {
+	Name                  string `json:"name"`
+	Stdout                string `json:"stdout"`
+	Stderr                string `json:"stderr"`
+	ExitCode              int    `json:"exitCode"`
+	LastSuccessfulRunTime string `json:"lastSuccessfulRunTime,omitempty"`
+}
+
+// PlanOutputHistorySecretName returns the name of the secret holding the plan output history of the plan secret.
+func PlanOutputHistorySecretName(planSecretName string) string {
+	return name.SafeConcatName(planSecretName, "output", "history")
+}
+
+// SecretToPlanOutput returns the output of the plan last applied according to the plan secret, or nil if no plan was
+// applied yet.
+func SecretToPlanOutput(secret *corev1.Secret) (*PlanOutput, error) {
+	appliedChecksum := string(secret.Data["applied-checksum"])
+	if appliedChecksum == "" {
+		return nil, nil
+	}
+	node, err := SecretToNode(secret)
+	if err != nil || node == nil {
+		return nil, err
+	}
+
+	output := &PlanOutput{
+		AppliedChecksum: appliedChecksum,
+		RecordedAt:      metav1.Now(),
+	}
+	for name, out := range node.Output {
+		output.Instructions = append(output.Instructions, InstructionOutput{
+			Name:   name,
+			Output: string(out),
+		})
+	}
+	for name, out := range node.PeriodicOutput {
+		output.PeriodicInstructions = append(output.PeriodicInstructions, PeriodicInstructionOutput{
+			Name:                  name,
+			Stdout:                string(out.Stdout),
+			Stderr:                string(out.Stderr),
+			ExitCode:              out.ExitCode,
+			LastSuccessfulRunTime: out.LastSuccessfulRunTime,
+		})
+	}
+	sort.Slice(output.Instructions, func(i, j int) bool {
+		return output.Instructions[i].Name < output.Instructions[j].Name
+	})
+	sort.Slice(output.PeriodicInstructions, func(i, j int) bool {
+		return output.PeriodicInstructions[i].Name < output.PeriodicInstructions[j].Name
+	})
+	return output, nil
+}
+
+// SecretToPlanOutputHistory returns the plan output history stored in the secret, from the most recent applied plan
+// to the oldest.
+func SecretToPlanOutputHistory(secret *corev1.Secret) ([]PlanOutput, error) {
+	data := secret.Data[planOutputHistoryKey]
+	if len(data) == 0 {
+		return nil, nil
+	}
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	data, err = io.ReadAll(gz)
+	if err != nil {
+		return nil, err
+	}
+	var history []PlanOutput
+	return history, json.Unmarshal(data, &history)
+}
+
+// AddPlanOutputToHistory adds the output to the plan output history stored in the secret. The output replaces the most
+// recent entry of the history if it is the output of the same applied plan. It returns false if the history didn't
+// change, the times the periodic instructions last ran successfully are ignored. Only the end of long outputs is kept,
+// and the oldest entries are dropped once the history grows past maxPlanOutputHistorySize.
+func AddPlanOutputToHistory(secret *corev1.Secret, output PlanOutput) (bool, error) {
+	history, err := SecretToPlanOutputHistory(secret)
+	if err != nil {
+		return false, err
+	}
+
+	output = truncatePlanOutput(output)
+
+	if len(history) > 0 && history[0].AppliedChecksum == output.AppliedChecksum {
+		if samePlanOutput(history[0], output) {
+			return false, nil
+		}
+		output.RecordedAt = history[0].RecordedAt
+		history = history[1:]
+	}
+	history = append([]PlanOutput{output}, history...)
+	if len(history) > maxPlanOutputHistory {
+		history = history[:maxPlanOutputHistory]
+	}
+
+	data, err := compressPlanOutputHistory(history)
+	for err == nil && len(data) > maxPlanOutputHistorySize && len(history) > 1 {
+		history = history[:len(history)-1]
+		data, err = compressPlanOutputHistory(history)
+	}
+	if err != nil {
+		return false, err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[planOutputHistoryKey] = data
+	return true, nil
+}
+
+func compressPlanOutputHistory(history []PlanOutput) ([]byte, error) {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// truncatePlanOutput keeps the last maxInstructionOutputSize bytes of each output, which usually hold the errors.
+func truncatePlanOutput(output PlanOutput) PlanOutput {
+	output.Instructions = append([]InstructionOutput(nil), output.Instructions...)
+	for i := range output.Instructions {
+		output.Instructions[i].Output = truncateOutput(output.Instructions[i].Output)
+	}
+	output.PeriodicInstructions = append([]PeriodicInstructionOutput(nil), output.PeriodicInstructions...)
+	for i := range output.PeriodicInstructions {
+		output.PeriodicInstructions[i].Stdout = truncateOutput(output.PeriodicInstructions[i].Stdout)
+		output.PeriodicInstructions[i].Stderr = truncateOutput(output.PeriodicInstructions[i].Stderr)
+	}
+	return output
+}
+
+func truncateOutput(out string) string {
+	if len(out) <= maxInstructionOutputSize {
+		return out
+	}
+	start := len(out) - maxInstructionOutputSize
+	for start < len(out) && !utf8.RuneStart(out[start]) {
+		start++
+	}
+	return fmt.Sprintf("[%d bytes truncated]\n", start) + out[start:]
+}
+
+func samePlanOutput(a, b PlanOutput) bool {
+	if len(a.Instructions) != len(b.Instructions) || len(a.PeriodicInstructions) != len(b.PeriodicInstructions) {
+		return false
+	}
+	for i := range a.Instructions {
+		if a.Instructions[i] != b.Instructions[i] {
+			return false
+		}
+	}
+	for i := range a.PeriodicInstructions {
+		aOut, bOut := a.PeriodicInstructions[i], b.PeriodicInstructions[i]
+		aOut.LastSuccessfulRunTime, bOut.LastSuccessfulRunTime = "", ""
+		if aOut != bOut {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package planner
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAddPlanOutputToHistory(t *testing.T) {
+	secret := &corev1.Secret{}
+	output := PlanOutput{
+		AppliedChecksum: "a",
+		Instructions:    []InstructionOutput{{Name: "install", Output: "installed"}},
+		PeriodicInstructions: []PeriodicInstructionOutput{
+			{Name: "etcd-snapshot-list-local", Stdout: "snapshot", LastSuccessfulRunTime: "Mon Jan  2 15:04:05 MST 2006"},
+		},
+	}
+
+	changed, err := AddPlanOutputToHistory(secret, output)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	// only the time the periodic instructions last ran changed
+	output.PeriodicInstructions[0].LastSuccessfulRunTime = "Mon Jan  2 15:05:05 MST 2006"
+	changed, err = AddPlanOutputToHistory(secret, output)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	// the output of the same applied plan is replaced
+	output.PeriodicInstructions = []PeriodicInstructionOutput{{Name: "etcd-snapshot-list-local", Stdout: "snapshots"}}
+	changed, err = AddPlanOutputToHistory(secret, output)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	history, err := SecretToPlanOutputHistory(secret)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, "snapshots", history[0].PeriodicInstructions[0].Stdout)
+
+	for i := 0; i < maxPlanOutputHistory+5; i++ {
+		output.AppliedChecksum = fmt.Sprint(i)
+		_, err = AddPlanOutputToHistory(secret, output)
+		assert.NoError(t, err)
+	}
+	history, err = SecretToPlanOutputHistory(secret)
+	assert.NoError(t, err)
+	assert.Len(t, history, maxPlanOutputHistory)
+	assert.Equal(t, fmt.Sprint(maxPlanOutputHistory+4), history[0].AppliedChecksum)
+}
+
+func TestAddPlanOutputToHistoryLimitsSize(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomOutput := func(size int) string {
+		data := make([]byte, size/2)
+		random.Read(data)
+		return hex.EncodeToString(data)
+	}
+
+	secret := &corev1.Secret{}
+	long := "first line\n" + randomOutput(2*maxInstructionOutputSize)
+	_, err := AddPlanOutputToHistory(secret, PlanOutput{
+		AppliedChecksum:      "long",
+		Instructions:         []InstructionOutput{{Name: "install", Output: long}},
+		PeriodicInstructions: []PeriodicInstructionOutput{{Name: "etcd-snapshot-list-local", Stderr: long}},
+	})
+	assert.NoError(t, err)
+	history, err := SecretToPlanOutputHistory(secret)
+	assert.NoError(t, err)
+	// the end of long outputs is kept
+	for _, out := range []string{history[0].Instructions[0].Output, history[0].PeriodicInstructions[0].Stderr} {
+		assert.True(t, strings.HasPrefix(out, fmt.Sprintf("[%d bytes truncated]\n", len(long)-maxInstructionOutputSize)))
+		assert.True(t, strings.HasSuffix(out, long[len(long)-maxInstructionOutputSize:]))
+	}
+
+	for i := 0; i < maxPlanOutputHistory; i++ {
+		var instructions []InstructionOutput
+		for j := 0; j < 5; j++ {
+			instructions = append(instructions, InstructionOutput{Name: fmt.Sprint(j), Output: randomOutput(maxInstructionOutputSize)})
+		}
+		_, err := AddPlanOutputToHistory(secret, PlanOutput{AppliedChecksum: fmt.Sprint(i), Instructions: instructions})
+		assert.NoError(t, err)
+	}
+	assert.LessOrEqual(t, len(secret.Data[planOutputHistoryKey]), maxPlanOutputHistorySize)
+	history, err = SecretToPlanOutputHistory(secret)
+	assert.NoError(t, err)
+	assert.Less(t, len(history), maxPlanOutputHistory, "the oldest entries are dropped")
+	assert.Equal(t, fmt.Sprint(maxPlanOutputHistory-1), history[0].AppliedChecksum)
+}
@@ -0,0 +1,255 @@
+package planner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"github.com/rancher/wrangler/pkg/name"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// maxUpgradeHistory is the number of upgrades kept in the upgrade history of a control plane.
+const maxUpgradeHistory = 10
+
+// reconcileUpgradeHealth tracks Kubernetes version upgrades of control planes with an upgrade health policy. An etcd
+// snapshot is taken with the previously applied spec before the upgrade starts if requested, and the upgrade is rolled
+// back if its machines fail too many probes or it doesn't finish in time. Finished upgrades are recorded in the upgrade
+// history.
+func (p *Planner) reconcileUpgradeHealth(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, tokensSecret plan.Secret, clusterPlan *plan.Plan, now time.Time) (rkev1.RKEControlPlaneStatus, error) {
+	policy := cp.Spec.UpgradeStrategy.UpgradeHealthPolicy
+	upgrade := status.Upgrade
+
+	if upgrade != nil {
+		switch {
+		case policy == nil && upgrade.Phase != rkev1.UpgradePhaseRollingBack:
+			return finishUpgrade(status, rkev1.UpgradeResultCancelled, "upgrade health policy removed", now)
+		case upgrade.Phase == rkev1.UpgradePhaseRollingBack:
+			if cp.Spec.KubernetesVersion != upgrade.FromVersion {
+				return status, ErrWaitingf("waiting for rollback of upgrade to %s", upgrade.ToVersion)
+			}
+			return finishUpgrade(status, rkev1.UpgradeResultRolledBack, upgrade.Message, now)
+		case cp.Spec.KubernetesVersion == upgrade.FromVersion:
+			return finishUpgrade(status, rkev1.UpgradeResultCancelled, "kubernetes version reverted", now)
+		case cp.Spec.KubernetesVersion != upgrade.ToVersion:
+			status, _ = finishUpgrade(status, rkev1.UpgradeResultSuperseded, "upgrade to "+cp.Spec.KubernetesVersion+" requested", now)
+			upgrade = nil
+		case status.AppliedSpec != nil && status.AppliedSpec.KubernetesVersion == upgrade.ToVersion:
+			return finishUpgrade(status, rkev1.UpgradeResultSucceeded, "", now)
+		}
+	}
+
+	if upgrade == nil {
+		if policy == nil || !status.Initialized || status.AppliedSpec == nil || status.AppliedSpec.KubernetesVersion == cp.Spec.KubernetesVersion {
+			return status, nil
+		}
+		status.Upgrade = &rkev1.UpgradeStatus{
+			FromVersion: status.AppliedSpec.KubernetesVersion,
+			ToVersion:   cp.Spec.KubernetesVersion,
+			Phase:       rkev1.UpgradePhaseUpgrading,
+			StartedAt:   metav1.NewTime(now),
+		}
+		if policy.RestoreSnapshot {
+			status.Upgrade.Phase = rkev1.UpgradePhaseSnapshot
+			status.Upgrade.SnapshotName = name.SafeConcatName("pre-upgrade", strings.ReplaceAll(cp.Spec.KubernetesVersion, "+", "-"), fmt.Sprint(now.Unix()))
+		}
+		return status, ErrWaitingf("starting upgrade from %s to %s", status.Upgrade.FromVersion, status.Upgrade.ToVersion)
+	}
+
+	if upgrade.Phase == rkev1.UpgradePhaseUpgrading && upgrade.RolloutStartedAt == nil && upgradeRolledOut(cp, clusterPlan) {
+		status.Upgrade = upgrade.DeepCopy()
+		status.Upgrade.RolloutStartedAt = &metav1.Time{Time: now}
+		upgrade = status.Upgrade
+	}
+
+	if reason := upgradeUnhealthyReason(policy, upgrade, clusterPlan, now); reason != "" {
+		return p.rollbackUpgrade(cp, status, reason)
+	}
+
+	switch upgrade.Phase {
+	case rkev1.UpgradePhaseSnapshot:
+		if err := p.snapshotBeforeUpgrade(cp, status.AppliedSpec, upgrade.SnapshotName, clusterPlan); err != nil {
+			return status, err
+		}
+		status.Upgrade = upgrade.DeepCopy()
+		status.Upgrade.Phase = rkev1.UpgradePhaseSnapshotRestart
+		return status, ErrWaiting("pre-upgrade etcd snapshot taken")
+	case rkev1.UpgradePhaseSnapshotRestart:
+		if err := p.runEtcdSnapshotManagementServiceStart(previousControlPlane(cp, status.AppliedSpec), tokensSecret, clusterPlan, isEtcd, "pre-upgrade etcd snapshot"); err != nil {
+			return status, err
+		}
+		status.Upgrade = upgrade.DeepCopy()
+		status.Upgrade.Phase = rkev1.UpgradePhaseUpgrading
+		return status, ErrWaitingf("upgrading from %s to %s", upgrade.FromVersion, upgrade.ToVersion)
+	}
+	return status, nil
+}
+
+// upgradeUnhealthyReason returns why the upgrade is to be rolled back according to the policy, or an empty string if
+// it isn't.
+func upgradeUnhealthyReason(policy *rkev1.UpgradeHealthPolicy, upgrade *rkev1.UpgradeStatus, clusterPlan *plan.Plan, now time.Time) string {
+	if started, ok := upgradeTimeoutStart(upgrade); ok && policy.TimeoutSeconds > 0 && now.Sub(started) > time.Duration(policy.TimeoutSeconds)*time.Second {
+		return fmt.Sprintf("upgrade did not finish within %d seconds", policy.TimeoutSeconds)
+	}
+	if policy.ProbeFailureBudget <= 0 || upgrade.Phase != rkev1.UpgradePhaseUpgrading {
+		return ""
+	}
+
+	var (
+		failures int
+		machines []string
+	)
+	for _, entry := range collect(clusterPlan, anyRole) {
+		if entry.Plan == nil || !planAppliedButWaitingForProbes(entry) {
+			continue
+		}
+		machineFailures := 0
+		for _, probeStatus := range entry.Plan.ProbeStatus {
+			machineFailures += probeStatus.FailureCount
+		}
+		if machineFailures > 0 {
+			failures += machineFailures
+			machines = append(machines, entry.Machine.Name)
+		}
+	}
+	if failures > policy.ProbeFailureBudget {
+		return fmt.Sprintf("%d probe failures on upgraded machine(s) %s exceeded the budget of %d", failures, atMostThree(machines), policy.ProbeFailureBudget)
+	}
+	return ""
+}
+
+// upgradeTimeoutStart returns the time the timeout of the upgrade runs from. The pre-upgrade etcd snapshot is taken
+// right away, so its phases run from the start of the upgrade. The rollout runs from the first machine receiving the
+// plan of the new version, as plans may be held back until a maintenance window opens.
+func upgradeTimeoutStart(upgrade *rkev1.UpgradeStatus) (time.Time, bool) {
+	if upgrade.Phase != rkev1.UpgradePhaseUpgrading {
+		return upgrade.StartedAt.Time, true
+	}
+	if upgrade.RolloutStartedAt == nil {
+		return time.Time{}, false
+	}
+	return upgrade.RolloutStartedAt.Time, true
+}
+
+// upgradeRolledOut returns true if any machine received a plan installing the Kubernetes version of the control plane.
+func upgradeRolledOut(cp *rkev1.RKEControlPlane, clusterPlan *plan.Plan) bool {
+	image := getInstallerImage(cp)
+	for _, entry := range collect(clusterPlan, anyRole) {
+		if entry.Plan == nil {
+			continue
+		}
+		for _, instruction := range entry.Plan.Plan.Instructions {
+			if instruction.Image == image {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rollbackUpgrade stops the upgrade and waits for the spec applied before the upgrade to be reapplied. The pre-upgrade
+// etcd snapshot is restored along with it if one was taken.
+func (p *Planner) rollbackUpgrade(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, reason string) (rkev1.RKEControlPlaneStatus, error) {
+	status.Upgrade = status.Upgrade.DeepCopy()
+	status.Upgrade.Phase = rkev1.UpgradePhaseRollingBack
+	status.Upgrade.Message = reason
+	if status.Upgrade.SnapshotName != "" {
+		snapshot, err := p.findPreUpgradeSnapshot(cp, status.Upgrade.SnapshotName)
+		if err != nil {
+			return status, err
+		}
+		if snapshot == "" {
+			status.Upgrade.Message += ", pre-upgrade etcd snapshot not found"
+		}
+		status.Upgrade.RestoreSnapshot = snapshot
+	}
+	return status, ErrWaitingf("rolling back upgrade to %s: %s", status.Upgrade.ToVersion, status.Upgrade.Message)
+}
+
+// findPreUpgradeSnapshot returns the name of the etcdsnapshot object of the snapshot saved with the given name. Etcd
+// nodes append their name and a timestamp to the name of the snapshot. S3 snapshots are preferred over local ones.
+func (p *Planner) findPreUpgradeSnapshot(cp *rkev1.RKEControlPlane, snapshotName string) (string, error) {
+	snapshots, err := p.etcdSnapshotCache.List(cp.Namespace, labels.SelectorFromSet(map[string]string{
+		rke2.ClusterNameLabel: cp.Spec.ClusterName,
+	}))
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []*rkev1.ETCDSnapshot
+	for _, snapshot := range snapshots {
+		if strings.HasPrefix(snapshot.SnapshotFile.Name, snapshotName+"-") {
+			candidates = append(candidates, snapshot)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		iS3, jS3 := candidates[i].SnapshotFile.S3 != nil, candidates[j].SnapshotFile.S3 != nil
+		if iS3 != jS3 {
+			return iS3
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates[0].Name, nil
+}
+
+// snapshotBeforeUpgrade takes an etcd snapshot on the init node with the previously applied spec, so the node isn't
+// upgraded by the snapshot plan.
+func (p *Planner) snapshotBeforeUpgrade(cp *rkev1.RKEControlPlane, appliedSpec *rkev1.RKEControlPlaneSpec, snapshotName string, clusterPlan *plan.Plan) error {
+	_, _, initNode, err := p.findInitNode(cp, clusterPlan)
+	if err != nil {
+		return err
+	}
+	if initNode == nil {
+		return ErrWaiting("waiting for init node to take pre-upgrade etcd snapshot")
+	}
+
+	previous := previousControlPlane(cp, appliedSpec)
+	snapshotPlan, err := p.commonNodePlan(previous, plan.NodePlan{
+		Instructions: []plan.OneTimeInstruction{
+			p.generateInstallInstructionWithSkipStart(previous, initNode),
+			{
+				Name:    "create",
+				Command: rke2.GetRuntimeCommand(previous.Spec.KubernetesVersion),
+				Args:    []string{"etcd-snapshot", "--name", snapshotName},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return assignAndCheckPlan(p.store, fmt.Sprintf("pre-upgrade etcd snapshot on machine %s", initNode.Machine.Name), initNode, snapshotPlan, 3, 3)
+}
+
+// previousControlPlane returns a copy of the control plane with the given previously applied spec.
+func previousControlPlane(cp *rkev1.RKEControlPlane, appliedSpec *rkev1.RKEControlPlaneSpec) *rkev1.RKEControlPlane {
+	previous := cp.DeepCopy()
+	previous.Spec = *appliedSpec.DeepCopy()
+	return previous
+}
+
+// finishUpgrade records the upgrade in the upgrade history, keeping the most recent maxUpgradeHistory upgrades.
+func finishUpgrade(status rkev1.RKEControlPlaneStatus, result rkev1.UpgradeResult, message string, now time.Time) (rkev1.RKEControlPlaneStatus, error) {
+	upgrade := status.Upgrade
+	status.UpgradeHistory = append([]rkev1.UpgradeHistoryEntry{{
+		FromVersion:     upgrade.FromVersion,
+		ToVersion:       upgrade.ToVersion,
+		StartedAt:       upgrade.StartedAt,
+		FinishedAt:      metav1.NewTime(now),
+		Result:          result,
+		RestoreSnapshot: upgrade.RestoreSnapshot,
+		Message:         message,
+	}}, status.UpgradeHistory...)
+	if len(status.UpgradeHistory) > maxUpgradeHistory {
+		status.UpgradeHistory = status.UpgradeHistory[:maxUpgradeHistory]
+	}
+	status.Upgrade = nil
+	return status, ErrWaitingf("upgrade from %s to %s %s", upgrade.FromVersion, upgrade.ToVersion, strings.ToLower(string(result)))
+}
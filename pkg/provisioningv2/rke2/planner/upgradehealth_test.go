@@ -0,0 +1,106 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/rke2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestUpgradeUnhealthyReason(t *testing.T) {
+	started := time.Date(2023, 4, 15, 0, 0, 0, 0, time.UTC)
+	nodePlan := plan.NodePlan{Files: []plan.File{{Path: "/etc/rancher/rke2/config.yaml"}}}
+	clusterPlan := &plan.Plan{
+		Machines: map[string]*capi.Machine{
+			"upgraded":  {ObjectMeta: metav1.ObjectMeta{Name: "upgraded"}},
+			"upgrading": {ObjectMeta: metav1.ObjectMeta{Name: "upgrading"}},
+			"unchanged": {ObjectMeta: metav1.ObjectMeta{Name: "unchanged"}},
+		},
+		Metadata: map[string]*plan.Metadata{
+			"upgraded":  {Labels: map[string]string{rke2.WorkerRoleLabel: "true"}},
+			"upgrading": {Labels: map[string]string{rke2.WorkerRoleLabel: "true"}},
+			"unchanged": {Labels: map[string]string{rke2.WorkerRoleLabel: "true"}},
+		},
+		Nodes: map[string]*plan.Node{
+			"upgraded": {
+				Plan:        nodePlan,
+				AppliedPlan: &nodePlan,
+				ProbeStatus: map[string]plan.ProbeStatus{
+					"kubelet":        {FailureCount: 2},
+					"kube-apiserver": {FailureCount: 1},
+				},
+			},
+			// probe failures of machines that haven't applied their plan yet aren't counted
+			"upgrading": {
+				Plan:        nodePlan,
+				ProbeStatus: map[string]plan.ProbeStatus{"kubelet": {FailureCount: 5}},
+			},
+			"unchanged": {
+				Plan:        nodePlan,
+				AppliedPlan: &nodePlan,
+				Healthy:     true,
+			},
+		},
+	}
+	rolloutStarted := metav1.NewTime(started)
+	upgrade := &rkev1.UpgradeStatus{Phase: rkev1.UpgradePhaseUpgrading, StartedAt: metav1.NewTime(started.Add(-time.Hour)), RolloutStartedAt: &rolloutStarted}
+
+	tests := []struct {
+		name     string
+		policy   rkev1.UpgradeHealthPolicy
+		upgrade  *rkev1.UpgradeStatus
+		now      time.Time
+		rollback bool
+	}{
+		{name: "within budget", policy: rkev1.UpgradeHealthPolicy{ProbeFailureBudget: 3}, upgrade: upgrade, now: started},
+		{name: "budget exceeded", policy: rkev1.UpgradeHealthPolicy{ProbeFailureBudget: 2}, upgrade: upgrade, now: started, rollback: true},
+		{name: "budget disabled", policy: rkev1.UpgradeHealthPolicy{}, upgrade: upgrade, now: started.Add(24 * time.Hour)},
+		{name: "within timeout", policy: rkev1.UpgradeHealthPolicy{TimeoutSeconds: 600}, upgrade: upgrade, now: started.Add(10 * time.Minute)},
+		{name: "timeout exceeded", policy: rkev1.UpgradeHealthPolicy{TimeoutSeconds: 600}, upgrade: upgrade, now: started.Add(10*time.Minute + time.Second), rollback: true},
+		{
+			name:    "timeout paused until rollout starts",
+			policy:  rkev1.UpgradeHealthPolicy{TimeoutSeconds: 600},
+			upgrade: &rkev1.UpgradeStatus{Phase: rkev1.UpgradePhaseUpgrading, StartedAt: metav1.NewTime(started)},
+			now:     started.Add(24 * time.Hour),
+		},
+		{
+			name:     "snapshot timeout exceeded",
+			policy:   rkev1.UpgradeHealthPolicy{TimeoutSeconds: 600},
+			upgrade:  &rkev1.UpgradeStatus{Phase: rkev1.UpgradePhaseSnapshot, StartedAt: metav1.NewTime(started)},
+			now:      started.Add(10*time.Minute + time.Second),
+			rollback: true,
+		},
+		{
+			name:    "budget ignored while taking snapshot",
+			policy:  rkev1.UpgradeHealthPolicy{ProbeFailureBudget: 1},
+			upgrade: &rkev1.UpgradeStatus{Phase: rkev1.UpgradePhaseSnapshot, StartedAt: metav1.NewTime(started)},
+			now:     started,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := upgradeUnhealthyReason(&tt.policy, tt.upgrade, clusterPlan, tt.now)
+			assert.Equal(t, tt.rollback, reason != "", reason)
+		})
+	}
+}
+
+func TestUpgradeRolledOut(t *testing.T) {
+	cp := &rkev1.RKEControlPlane{Spec: rkev1.RKEControlPlaneSpec{KubernetesVersion: "v1.25.9+rke2r1"}}
+	oldPlan := plan.NodePlan{Instructions: []plan.OneTimeInstruction{{Image: getInstallerImage(&rkev1.RKEControlPlane{Spec: rkev1.RKEControlPlaneSpec{KubernetesVersion: "v1.24.13+rke2r1"}})}}}
+	newPlan := plan.NodePlan{Instructions: []plan.OneTimeInstruction{{Image: getInstallerImage(cp)}}}
+	clusterPlan := &plan.Plan{
+		Machines: map[string]*capi.Machine{"m1": {ObjectMeta: metav1.ObjectMeta{Name: "m1"}}},
+		Metadata: map[string]*plan.Metadata{"m1": {Labels: map[string]string{rke2.WorkerRoleLabel: "true"}}},
+		Nodes:    map[string]*plan.Node{"m1": {Plan: oldPlan, AppliedPlan: &oldPlan}},
+	}
+	assert.False(t, upgradeRolledOut(cp, clusterPlan), "plans held back by a maintenance window")
+
+	clusterPlan.Nodes["m1"].Plan = newPlan
+	assert.True(t, upgradeRolledOut(cp, clusterPlan))
+}
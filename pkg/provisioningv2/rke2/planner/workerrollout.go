@@ -0,0 +1,176 @@
+package planner
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// workerStage is a stage of a worker rollout with the names of the machines it selected.
+type workerStage struct {
+	rkev1.RolloutStage
+	machines map[string]bool
+}
+
+func (s *workerStage) include(entry *planEntry) bool {
+	return s.machines[entry.Machine.Name]
+}
+
+// includeNew includes the machines of the stage that never applied a plan.
+func (s *workerStage) includeNew(entry *planEntry) bool {
+	return s.include(entry) && (entry.Plan == nil || entry.Plan.AppliedPlan == nil)
+}
+
+// reconcileWorkerStages reconciles the workers in the rollout stages of the upgrade strategy, one stage after the
+// other. A stage is only started once the previous stages are reconciled, and once the approval annotation of the
+// stage is populated with the pending approval of the rollout, if the stage has one. Machines that never applied a plan
+// are not gated by the stages, they are reconciled in every stage.
+func (p *Planner) reconcileWorkerStages(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, tokensSecret plan.Secret, clusterPlan *plan.Plan,
+	joinServer string, window *maintenanceWindow, now time.Time) (rkev1.RKEControlPlaneStatus, error) {
+	stages, err := workerStages(cp.Spec.UpgradeStrategy, collect(clusterPlan, isOnlyWorker))
+	if err != nil {
+		return status, err
+	}
+
+	var gated error
+	for _, stage := range stages {
+		include := stage.include
+		if gated != nil {
+			include = stage.includeNew
+		} else {
+			outOfSync, err := p.stageOutOfSync(cp, tokensSecret, clusterPlan, stage, joinServer)
+			if err != nil {
+				return status, err
+			}
+			if outOfSync {
+				status.WorkerRollout = status.WorkerRollout.DeepCopy()
+				if status.WorkerRollout == nil {
+					status.WorkerRollout = &rkev1.WorkerRolloutStatus{ID: strconv.FormatInt(now.Unix(), 10)}
+				}
+				status.WorkerRollout.Stage = stage.Name
+				status.WorkerRollout.PendingApproval = ""
+				if stage.ApprovalAnnotation != "" {
+					approval := status.WorkerRollout.ID + "/" + stage.Name
+					if cp.Annotations[stage.ApprovalAnnotation] != approval {
+						status.WorkerRollout.PendingApproval = approval
+						gated = errIgnore(fmt.Sprintf("waiting for approval of worker rollout stage %s: annotate cluster with %s=%s", stage.Name, stage.ApprovalAnnotation, approval))
+						include = stage.includeNew
+					}
+				}
+			}
+		}
+
+		if err := p.reconcile(cp, tokensSecret, clusterPlan, false, workerTier+" stage "+stage.Name, include, isInitNodeOrDeleting,
+			stage.Concurrency, joinServer, cp.Spec.UpgradeStrategy.WorkerDrainOptions, window); err != nil && gated == nil {
+			// the following stages only reconcile new machines until this one is done
+			gated = err
+		}
+	}
+
+	if gated != nil {
+		return status, gated
+	}
+	status.WorkerRollout = nil
+	return status, nil
+}
+
+// stageOutOfSync returns true if the plan of any machine of the stage that applied a plan before has a pending major
+// change. New machines are not gated by the stages.
+func (p *Planner) stageOutOfSync(cp *rkev1.RKEControlPlane, tokensSecret plan.Secret, clusterPlan *plan.Plan, stage *workerStage, joinServer string) (bool, error) {
+	for _, entry := range collect(clusterPlan, stage.include) {
+		if entry.Plan == nil || entry.Plan.AppliedPlan == nil || isInitNodeOrDeleting(entry) {
+			continue
+		}
+		desired, err := p.desiredPlan(cp, tokensSecret, entry, joinServer)
+		if err != nil {
+			return false, err
+		}
+		if !equality.Semantic.DeepEqual(entry.Plan.Plan, desired) && !minorPlanChangeDetected(entry.Plan.Plan, desired) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// workerStages assigns the workers to the rollout stages of the upgrade strategy in order of their names. The workers
+// not selected by any stage are assigned to a final stage rolled out with the worker concurrency.
+func workerStages(strategy rkev1.ClusterUpgradeStrategy, entries []*planEntry) ([]*workerStage, error) {
+	var (
+		stages    []*workerStage
+		remaining = entries
+		names     = map[string]bool{}
+	)
+
+	for _, rolloutStage := range strategy.WorkerRolloutStages {
+		if names[rolloutStage.Name] {
+			return nil, fmt.Errorf("worker rollout stage %s is defined more than once", rolloutStage.Name)
+		}
+		names[rolloutStage.Name] = true
+
+		selector, err := metav1.LabelSelectorAsSelector(rolloutStage.MachineSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid machine selector of worker rollout stage %s: %w", rolloutStage.Name, err)
+		}
+		if rolloutStage.MachineSelector == nil {
+			selector = labels.Everything()
+		}
+		size, err := stageSize(rolloutStage.Size, len(entries))
+		if err != nil {
+			return nil, fmt.Errorf("invalid size of worker rollout stage %s: %w", rolloutStage.Name, err)
+		}
+
+		stage := &workerStage{
+			RolloutStage: rolloutStage,
+			machines:     map[string]bool{},
+		}
+		if stage.Concurrency == "" {
+			stage.Concurrency = strategy.WorkerConcurrency
+		}
+
+		var unselected []*planEntry
+		for _, entry := range remaining {
+			if (size < 0 || len(stage.machines) < size) && selector.Matches(labels.Set(entry.Machine.Labels)) {
+				stage.machines[entry.Machine.Name] = true
+			} else {
+				unselected = append(unselected, entry)
+			}
+		}
+		remaining = unselected
+		stages = append(stages, stage)
+	}
+
+	last := &workerStage{
+		RolloutStage: rkev1.RolloutStage{
+			Name:        "remaining",
+			Concurrency: strategy.WorkerConcurrency,
+		},
+		machines: map[string]bool{},
+	}
+	for _, entry := range remaining {
+		last.machines[entry.Machine.Name] = true
+	}
+	return append(stages, last), nil
+}
+
+// stageSize returns the number of workers of a stage of the given size, or -1 if the size is not limited.
+func stageSize(size string, workers int) (int, error) {
+	if size == "" {
+		return -1, nil
+	}
+	if num, err := strconv.Atoi(size); err == nil {
+		return num, nil
+	}
+	percentage, err := strconv.ParseFloat(strings.TrimSuffix(size, "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("size must be a number or a percentage: %w", err)
+	}
+	return int(math.Ceil(float64(workers) * (percentage / float64(100)))), nil
+}
@@ -0,0 +1,77 @@
+package planner
+
+import (
+	"fmt"
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestWorkerStages(t *testing.T) {
+	var entries []*planEntry
+	for i := 0; i < 10; i++ {
+		pool := "general"
+		if i >= 8 {
+			pool = "gpu"
+		}
+		entries = append(entries, &planEntry{
+			Machine: &capi.Machine{ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("worker-%d", i),
+				Labels: map[string]string{"pool": pool},
+			}},
+		})
+	}
+
+	strategy := rkev1.ClusterUpgradeStrategy{
+		WorkerConcurrency: "2",
+		WorkerRolloutStages: []rkev1.RolloutStage{
+			{Name: "canary", Size: "1", MachineSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "general"}}},
+			{Name: "ten-percent", Size: "10%", Concurrency: "1"},
+			{Name: "gpu", MachineSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}}},
+		},
+	}
+	stages, err := workerStages(strategy, entries)
+	assert.NoError(t, err)
+
+	machines := map[string][]string{}
+	for _, stage := range stages {
+		for _, entry := range entries {
+			if stage.include(entry) {
+				machines[stage.Name] = append(machines[stage.Name], entry.Machine.Name)
+			}
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"canary":      {"worker-0"},
+		"ten-percent": {"worker-1"},
+		"gpu":         {"worker-8", "worker-9"},
+		"remaining":   {"worker-2", "worker-3", "worker-4", "worker-5", "worker-6", "worker-7"},
+	}, machines)
+	assert.Equal(t, "2", stages[0].Concurrency)
+	assert.Equal(t, "1", stages[1].Concurrency)
+	assert.Equal(t, "2", stages[3].Concurrency)
+
+	strategy.WorkerRolloutStages = append(strategy.WorkerRolloutStages, rkev1.RolloutStage{Name: "canary"})
+	_, err = workerStages(strategy, entries)
+	assert.Error(t, err)
+
+	_, err = workerStages(rkev1.ClusterUpgradeStrategy{WorkerRolloutStages: []rkev1.RolloutStage{{Name: "canary", Size: "one"}}}, entries)
+	assert.Error(t, err)
+}
+
+func TestWorkerStageIncludeNew(t *testing.T) {
+	applied := plan.NodePlan{}
+	stage := &workerStage{machines: map[string]bool{"provisioning": true, "pending": true, "existing": true}}
+	entry := func(name string, node *plan.Node) *planEntry {
+		return &planEntry{Machine: &capi.Machine{ObjectMeta: metav1.ObjectMeta{Name: name}}, Plan: node}
+	}
+
+	assert.True(t, stage.includeNew(entry("provisioning", nil)))
+	assert.True(t, stage.includeNew(entry("pending", &plan.Node{})))
+	assert.False(t, stage.includeNew(entry("existing", &plan.Node{AppliedPlan: &applied})))
+	assert.False(t, stage.includeNew(entry("other", nil)), "machines of other stages aren't included")
+}
@@ -32,6 +32,7 @@
 	mgmntv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/multiclustermanager"
 	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/provisioningv2/autoscaler"
 	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/rancher/pkg/tls"
 	"github.com/rancher/rancher/pkg/ui"
@@ -75,6 +76,8 @@ type Options struct {
 	AuditLogMaxsize   int
 	AuditLogMaxbackup int
 	AuditLevel        int
+	AuditLogSinks     audit.SinkConfig
+	AuditLogChain     audit.ChainConfig
 	Features          string
 	ClusterRegistry   string
 }
@@ -210,6 +213,13 @@ func New(ctx context.Context, clientConfg clientcmd.ClientConfig, opts *Options)
 	}
 
 	auditLogWriter := audit.NewLogWriter(opts.AuditLogPath, audit.Level(opts.AuditLevel), opts.AuditLogMaxage, opts.AuditLogMaxbackup, opts.AuditLogMaxsize)
+	if err := auditLogWriter.ConfigureSinks(opts.AuditLogSinks); err != nil {
+		return nil, err
+	}
+	if err := auditLogWriter.ConfigureHashChain(opts.AuditLogChain, wranglerContext.Core.Secret()); err != nil {
+		return nil, err
+	}
+	audit.RegisterPolicyHandler(ctx, auditLogWriter, wranglerContext.Mgmt.AuditPolicy())
 	auditFilter, err := audit.NewAuditLogMiddleware(auditLogWriter)
 	if err != nil {
 		return nil, err
@@ -276,6 +286,11 @@ func (r *Rancher) Start(ctx context.Context) error {
 	r.Wrangler.OnLeader(r.authServer.OnLeader)
 	r.auditLog.Start(ctx)
 
+	if features.ProvisioningV2.Enabled() && features.RKE2.Enabled() && features.MCM.Enabled() {
+		// the cloud provider of the cluster-autoscalers is served by every replica, not only by the leader
+		autoscaler.Serve(ctx, r.Wrangler)
+	}
+
 	return r.Wrangler.Start(ctx)
 }
 
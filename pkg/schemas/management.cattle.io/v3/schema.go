@@ -57,7 +57,8 @@
 		Init(driverMetadataCisTypes).
 		Init(encryptionTypes).
 		Init(fleetTypes).
-		Init(notificationTypes)
+		Init(notificationTypes).
+		Init(auditTypes)
 
 	TokenSchemas = factory.Schemas(&Version).
 			Init(tokens)
@@ -473,6 +474,8 @@ func authnTypes(schemas *types.Schemas) *types.Schemas {
 		MustImport(&Version, v3.SearchPrincipalsInput{}).
 		MustImport(&Version, v3.ChangePasswordInput{}).
 		MustImport(&Version, v3.SetPasswordInput{}).
+		MustImport(&Version, v3.EnrollTOTPOutput{}).
+		MustImport(&Version, v3.VerifyTOTPInput{}).
 		MustImportAndCustomize(&Version, v3.User{}, func(schema *types.Schema) {
 			schema.ResourceActions = map[string]types.Action{
 				"setpassword": {
@@ -480,12 +483,26 @@ func authnTypes(schemas *types.Schemas) *types.Schemas {
 					Output: "user",
 				},
 				"refreshauthprovideraccess": {},
+				"enrolltotp": {
+					Output: "enrollTOTPOutput",
+				},
+				"resettotp":   {},
+				"unlocklogin": {},
 			}
 			schema.CollectionActions = map[string]types.Action{
 				"changepassword": {
 					Input: "changePasswordInput",
 				},
 				"refreshauthprovideraccess": {},
+				"enrolltotp": {
+					Output: "enrollTOTPOutput",
+				},
+				"activatetotp": {
+					Input: "verifyTOTPInput",
+				},
+				"disabletotp": {
+					Input: "verifyTOTPInput",
+				},
 			}
 		}).
 		MustImportAndCustomize(&Version, v3.AuthConfig{}, func(schema *types.Schema) {
@@ -686,6 +703,10 @@ func userTypes(schema *types.Schemas) *types.Schemas {
 		MustImportAndCustomize(&Version, v3.UserAttribute{}, func(schema *types.Schema) {
 			schema.CollectionMethods = []string{}
 			schema.ResourceMethods = []string{}
+		}).
+		MustImportAndCustomize(&Version, v3.LoginLockout{}, func(schema *types.Schema) {
+			schema.CollectionMethods = []string{http.MethodGet}
+			schema.ResourceMethods = []string{http.MethodGet, http.MethodDelete}
 		})
 }
 
@@ -1001,3 +1022,7 @@ func encryptionTypes(schemas *types.Schemas) *types.Schemas {
 func notificationTypes(schemas *types.Schemas) *types.Schemas {
 	return schemas.MustImport(&Version, v3.RancherUserNotification{})
 }
+
+func auditTypes(schemas *types.Schemas) *types.Schemas {
+	return schemas.MustImport(&Version, v3.AuditPolicy{})
+}
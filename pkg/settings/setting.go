@@ -104,7 +104,10 @@
 	GKEUpstreamRefresh                  = NewSetting("gke-refresh", "300")
 	HideLocalCluster                    = NewSetting("hide-local-cluster", "false")
 	MachineProvisionImage               = NewSetting("machine-provision-image", "rancher/machine:v0.15.0-rancher96")
+	EtcdSnapshotVerificationImage       = NewSetting("etcd-snapshot-verification-image", "rancher/hardened-etcd:v3.5.7-k3s1-build20230406")
 	SystemFeatureChartRefreshSeconds    = NewSetting("system-feature-chart-refresh-seconds", "900")
+	ClusterAutoscalerGRPCPort           = NewSetting("cluster-autoscaler-grpc-port", "")    // port of the externalgrpc cloud provider of the cluster-autoscaler served by every replica, disabled if empty; not routed by the ingress, expose it with a TCP service
+	ClusterAutoscalerGRPCAddress        = NewSetting("cluster-autoscaler-grpc-address", "") // host:port the cluster-autoscalers connect to, e.g. of a LoadBalancer service exposing cluster-autoscaler-grpc-port; defaults to the server-url host with cluster-autoscaler-grpc-port
 
 	Rke2DefaultVersion = NewSetting("rke2-default-version", "")
 	K3sDefaultVersion  = NewSetting("k3s-default-version", "")
@@ -112,6 +115,57 @@
 	// AuthTokenMaxTTLMinutes is the max allowable time to live for tokens. Excluding those created for UI sessions which is controlled by AuthUserSessionTTLMinutes.
 	AuthTokenMaxTTLMinutes = NewSetting("auth-token-max-ttl-minutes", "0") // never expire
 
+	// AuthTokenMaxIdleDays is the number of days after which tokens that weren't used are disabled. Tokens without
+	// recorded usage are idle since their creation. System tokens used by Rancher components are not affected.
+	AuthTokenMaxIdleDays = NewSetting("auth-token-max-idle-days", "0") // never disable
+
+	// LocalAuthMFARequiredForAdmins requires local users bound to admin global roles to log in with a TOTP code.
+	// Admins that aren't enrolled yet log in with a code of an enrollment created in an existing session or issued by
+	// another admin.
+	LocalAuthMFARequiredForAdmins = NewSetting("local-auth-mfa-required-for-admins", "false")
+
+	// AuthLoginBackoffBaseSeconds is the delay before the next login of a username or source IP after repeated failed
+	// logins. It doubles with each further failed login. 0 disables the backoff.
+	AuthLoginBackoffBaseSeconds = NewSetting("auth-login-backoff-base-seconds", "1")
+
+	// AuthLoginLockoutUserThreshold is the number of failed logins that lock out a username. 0 disables the lockout.
+	AuthLoginLockoutUserThreshold = NewSetting("auth-login-lockout-user-threshold", "10")
+
+	// AuthLoginLockoutSourceIPThreshold is the number of failed logins that lock out a source IP. 0 disables the lockout.
+	AuthLoginLockoutSourceIPThreshold = NewSetting("auth-login-lockout-source-ip-threshold", "50")
+
+	// AuthLoginLockoutMinutes is the duration of the first lockout, and the time after which failed logins are
+	// forgotten. It doubles with each consecutive lockout up to AuthLoginLockoutMaxMinutes.
+	AuthLoginLockoutMinutes = NewSetting("auth-login-lockout-minutes", "15")
+
+	// AuthLoginLockoutMaxMinutes is the maximum duration of a lockout.
+	AuthLoginLockoutMaxMinutes = NewSetting("auth-login-lockout-max-minutes", "1440") // 1 day
+
+	// TrustedProxyCIDRs is a comma separated list of the addresses or CIDRs of the proxies in front of Rancher, e.g. the
+	// ingress controller. The X-Forwarded-For header is only trusted for requests from these proxies when determining
+	// the source IP of a request, for lockouts and token usage. It must be set for source IP lockouts to work behind
+	// a proxy.
+	TrustedProxyCIDRs = NewSetting("trusted-proxy-cidrs", "")
+
+	// PasswordRequiredCharacterClasses is a comma separated list of the character classes passwords of local users
+	// must contain, out of lowercase, uppercase, digit and symbol.
+	PasswordRequiredCharacterClasses = NewSetting("password-required-character-classes", "")
+
+	// PasswordDenyCommonPasswords rejects passwords of local users that are in the bundled list of common passwords.
+	PasswordDenyCommonPasswords = NewSetting("password-deny-common-passwords", "false")
+
+	// PasswordDenyListConfigMap is the name of a ConfigMap in the cattle-system namespace with further passwords that
+	// local users can't use, one per line in any of its keys.
+	PasswordDenyListConfigMap = NewSetting("password-deny-list-configmap", "")
+
+	// PasswordHistoryCount is the number of most recent passwords of a local user, including the current one, that can't
+	// be reused. 0 disables the check.
+	PasswordHistoryCount = NewSetting("password-history-count", "0")
+
+	// PasswordMaxAgeDays is the number of days after which local users must change their password on their next login.
+	// 0 disables the expiry.
+	PasswordMaxAgeDays = NewSetting("password-max-age-days", "0")
+
 	// AuthUserInfoMaxAgeSeconds represents the maximum age of a users auth tokens before an auth provider group membership sync will be performed.
 	AuthUserInfoMaxAgeSeconds = NewSetting("auth-user-info-max-age-seconds", "3600") // 1 hour
 
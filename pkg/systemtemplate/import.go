@@ -129,10 +129,16 @@ func GetDesiredFeatures(cluster *apimgmtv3.Cluster) map[string]bool {
 }
 
 func ForCluster(cluster *apimgmtv3.Cluster, token string, taints []corev1.Taint, secretLister v1.SecretLister) ([]byte, error) {
+	return ForClusterWithServerURL(cluster, token, settings.ServerURL.Get(), taints, secretLister)
+}
+
+// ForClusterWithServerURL renders the agent manifest of the cluster like ForCluster, but with the agent connecting to
+// the given Rancher server URL.
+func ForClusterWithServerURL(cluster *apimgmtv3.Cluster, token, serverURL string, taints []corev1.Taint, secretLister v1.SecretLister) ([]byte, error) {
 	buf := &bytes.Buffer{}
 	err := SystemTemplate(buf, GetDesiredAgentImage(cluster),
 		GetDesiredAuthImage(cluster),
-		cluster.Name, token, settings.ServerURL.Get(), cluster.Spec.WindowsPreferedCluster,
+		cluster.Name, token, serverURL, cluster.Spec.WindowsPreferedCluster,
 		cluster, GetDesiredFeatures(cluster), taints, secretLister)
 	return buf.Bytes(), err
 }